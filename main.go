@@ -38,6 +38,8 @@ func main() {
 				return provider
 			},
 		})
+
+	openapi.ShutdownTelemetry()
 }
 
 func getProviderName(binaryName string) (string, error) {
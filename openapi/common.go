@@ -109,6 +109,10 @@ func updateStateWithPayloadDataAndOptions(openAPIResource SpecResource, remoteDa
 		if property.isPropertyNamedID() {
 			continue
 		}
+		// write-only properties are never returned by the API so the value from the configuration (or prior state) is kept
+		if property.WriteOnly {
+			continue
+		}
 
 		propValue := propertyRemoteValue
 		if ignoreListOrderEnabled && property.shouldIgnoreOrder() {
@@ -120,6 +124,9 @@ func updateStateWithPayloadDataAndOptions(openAPIResource SpecResource, remoteDa
 		if err != nil {
 			return err
 		}
+		if property.SpecSchemaDefinition != nil {
+			value = mergeWriteOnlyLocalValues(property, value, resourceLocalData.Get(property.GetTerraformCompliantPropertyName()))
+		}
 		if value != nil {
 			if err := setResourceDataProperty(openAPIResource, propertyName, value, resourceLocalData); err != nil {
 				return err
@@ -174,6 +181,45 @@ func processIgnoreOrderIfEnabled(property SpecSchemaDefinitionProperty, inputPro
 	return remoteValue
 }
 
+// mergeWriteOnlyLocalValues replaces the values of the nested write-only properties in the remote value (object or list of
+// objects already converted to the local state format) with the local values (configuration or prior state), since the
+// API never returns them. List items are matched by position
+func mergeWriteOnlyLocalValues(property *SpecSchemaDefinitionProperty, remoteValue, localValue interface{}) interface{} {
+	if property.SpecSchemaDefinition == nil || remoteValue == nil {
+		return remoteValue
+	}
+	switch remote := remoteValue.(type) {
+	case map[string]interface{}:
+		local, _ := localValue.(map[string]interface{})
+		for _, nestedProperty := range property.SpecSchemaDefinition.Properties {
+			name := nestedProperty.GetTerraformCompliantPropertyName()
+			if nestedProperty.WriteOnly {
+				if localNestedValue, exists := local[name]; exists && localNestedValue != nil {
+					remote[name] = localNestedValue
+				} else {
+					delete(remote, name)
+				}
+				continue
+			}
+			if nestedValue, exists := remote[name]; exists && nestedProperty.SpecSchemaDefinition != nil {
+				remote[name] = mergeWriteOnlyLocalValues(nestedProperty, nestedValue, local[name])
+			}
+		}
+		return remote
+	case []interface{}:
+		local, _ := localValue.([]interface{})
+		for idx := range remote {
+			var localItem interface{}
+			if idx < len(local) {
+				localItem = local[idx]
+			}
+			remote[idx] = mergeWriteOnlyLocalValues(property, remote[idx], localItem)
+		}
+		return remote
+	}
+	return remoteValue
+}
+
 func convertPayloadToLocalStateDataValue(property *SpecSchemaDefinitionProperty, propertyValue interface{}, useString bool) (interface{}, error) {
 	if propertyValue == nil {
 		return nil, nil
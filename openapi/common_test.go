@@ -506,6 +506,61 @@ func TestDataSourceUpdateStateWithPayloadData(t *testing.T) {
 	})
 }
 
+func TestUpdateStateWithPayloadDataWriteOnlyProperties(t *testing.T) {
+	Convey("Given a resource factory with top level and nested write-only properties", t, func() {
+		writeOnlyProperty := newStringSchemaDefinitionPropertyWithDefaults("password", "", true, false, "localSecret")
+		writeOnlyProperty.WriteOnly = true
+		nestedWriteOnlyProperty := newStringSchemaDefinitionPropertyWithDefaults("secret", "", false, false, "nestedSecret")
+		nestedWriteOnlyProperty.WriteOnly = true
+		objectSchemaDefinition := &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				newIntSchemaDefinitionPropertyWithDefaults("origin_port", "", true, false, 80),
+				nestedWriteOnlyProperty,
+			},
+		}
+		objectProperty := newObjectSchemaDefinitionPropertyWithDefaults("object_property", "", true, false, false, map[string]interface{}{"origin_port": 80, "secret": "nestedSecret"}, objectSchemaDefinition)
+		listOfObjectsProperty := newListSchemaDefinitionPropertyWithDefaults("slice_object_property", "", true, false, false, []interface{}{map[string]interface{}{"origin_port": 80, "secret": "nestedSecret"}}, TypeObject, objectSchemaDefinition)
+		r, resourceData := testCreateResourceFactory(t, stringProperty, writeOnlyProperty, objectProperty, listOfObjectsProperty)
+		Convey("When updateStateWithPayloadData is called with a payload that does not contain the write-only values (or contains masked values)", func() {
+			remoteData := map[string]interface{}{
+				stringProperty.Name:        "someUpdatedStringValue",
+				writeOnlyProperty.Name:     "********",
+				objectProperty.Name:        map[string]interface{}{"origin_port": 443},
+				listOfObjectsProperty.Name: []interface{}{map[string]interface{}{"origin_port": 443, "secret": "********"}},
+			}
+			err := updateStateWithPayloadData(r.openAPIResource, remoteData, resourceData)
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the non write-only values should be updated with the remote values", func() {
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, "someUpdatedStringValue")
+				So(resourceData.Get(objectProperty.Name).(map[string]interface{})["origin_port"], ShouldEqual, "443")
+				So(resourceData.Get(listOfObjectsProperty.Name).([]interface{})[0].(map[string]interface{})["origin_port"], ShouldEqual, 443)
+			})
+			Convey("And the write-only values should be kept from the local state", func() {
+				So(resourceData.Get(writeOnlyProperty.Name), ShouldEqual, "localSecret")
+				So(resourceData.Get(objectProperty.Name).(map[string]interface{})["secret"], ShouldEqual, "nestedSecret")
+				So(resourceData.Get(listOfObjectsProperty.Name).([]interface{})[0].(map[string]interface{})["secret"], ShouldEqual, "nestedSecret")
+			})
+		})
+		Convey("When updateStateWithPayloadData is called with a payload that contains more list items than the local state", func() {
+			remoteData := map[string]interface{}{
+				listOfObjectsProperty.Name: []interface{}{map[string]interface{}{"origin_port": 443}, map[string]interface{}{"origin_port": 8080, "secret": "********"}},
+			}
+			err := updateStateWithPayloadData(r.openAPIResource, remoteData, resourceData)
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the write-only values of the items not present in the local state should be empty", func() {
+				items := resourceData.Get(listOfObjectsProperty.Name).([]interface{})
+				So(items[0].(map[string]interface{})["secret"], ShouldEqual, "nestedSecret")
+				So(items[1].(map[string]interface{})["origin_port"], ShouldEqual, 8080)
+				So(items[1].(map[string]interface{})["secret"], ShouldEqual, "")
+			})
+		})
+	})
+}
+
 func TestUpdateStateWithPayloadDataAndOptions(t *testing.T) {
 	Convey("Given a resource factory containing a schema with property lists that have the IgnoreItemsOrder set to true", t, func() {
 		specResource := &specStubResource{
@@ -5,6 +5,7 @@ import (
 	"math"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
@@ -34,9 +35,10 @@ func (d dataSourceFactory) createTerraformDataSource() (*schema.Resource, error)
 	if err != nil {
 		return nil, err
 	}
+	resourceName := d.openAPIResource.GetResourceName()
 	return &schema.Resource{
 		Schema: s,
-		Read:   d.read,
+		Read:   traceOperation(fmt.Sprintf("read data source %s", resourceName), resourceName, TelemetryResourceOperationRead, d.read),
 	}, nil
 }
 
@@ -74,7 +76,7 @@ func (d dataSourceFactory) dataSourceFiltersSchema() *schema.Schema {
 	}
 }
 
-func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) error {
+func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) (err error) {
 	openAPIClient := i.(ClientOpenAPI)
 
 	if d.openAPIResource == nil {
@@ -83,6 +85,7 @@ func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) error
 	resourceName := d.openAPIResource.GetResourceName()
 
 	submitTelemetryMetricDataSource(openAPIClient, TelemetryResourceOperationRead, resourceName)
+	defer submitTelemetryOperationDurationMetric(openAPIClient, TelemetryResourceOperationRead, resourceName, "data_", time.Now(), &err)
 
 	parentIDs, resourcePath, err := getParentIDsAndResourcePath(d.openAPIResource, data)
 	if err != nil {
@@ -96,6 +99,7 @@ func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) error
 
 	responsePayload := []map[string]interface{}{}
 	resp, err := openAPIClient.List(d.openAPIResource, &responsePayload, parentIDs...)
+	submitTelemetryHTTPStatusMetric(openAPIClient, TelemetryResourceOperationRead, resourceName, "data_", resp)
 	if err != nil {
 		return err
 	}
@@ -136,7 +140,12 @@ func (d dataSourceFactory) filterMatch(filters filters, payloadItem map[string]i
 			var value string
 			switch schemaProperty.Type {
 			case TypeInt:
-				value = strconv.Itoa(val.(int))
+				// payloads decoded from JSON contain numbers as float64
+				if v, isFloat := val.(float64); isFloat {
+					value = strconv.Itoa(int(v))
+				} else {
+					value = strconv.Itoa(val.(int))
+				}
 			case TypeFloat:
 				v := val.(float64)                            //because of payloadItem is map[string]interface{} a float with decimal point is treat as an int
 				if _, decimal := math.Modf(v); decimal == 0 { //we recognize this special case here and print the value accordingly
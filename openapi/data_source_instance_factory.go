@@ -3,6 +3,7 @@ package openapi
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
@@ -28,9 +29,10 @@ func (d dataSourceInstanceFactory) createTerraformInstanceDataSource() (*schema.
 	if err != nil {
 		return nil, err
 	}
+	dataSourceName := d.getDataSourceInstanceName()
 	return &schema.Resource{
 		Schema: s,
-		Read:   d.read,
+		Read:   traceOperation(fmt.Sprintf("read data source %s", dataSourceName), d.openAPIResource.GetResourceName(), TelemetryResourceOperationRead, d.read),
 	}, nil
 }
 
@@ -54,7 +56,7 @@ func (d dataSourceInstanceFactory) dataSourceInstanceSchema() *schema.Schema {
 	}
 }
 
-func (d dataSourceInstanceFactory) read(data *schema.ResourceData, i interface{}) error {
+func (d dataSourceInstanceFactory) read(data *schema.ResourceData, i interface{}) (err error) {
 	openAPIClient := i.(ClientOpenAPI)
 
 	if d.openAPIResource == nil {
@@ -63,6 +65,7 @@ func (d dataSourceInstanceFactory) read(data *schema.ResourceData, i interface{}
 	resourceName := d.getDataSourceInstanceName()
 
 	submitTelemetryMetricDataSource(openAPIClient, TelemetryResourceOperationRead, resourceName)
+	defer submitTelemetryOperationDurationMetric(openAPIClient, TelemetryResourceOperationRead, resourceName, "data_", time.Now(), &err)
 
 	parentIDs, resourcePath, err := getParentIDsAndResourcePath(d.openAPIResource, data)
 	if err != nil {
@@ -74,6 +77,7 @@ func (d dataSourceInstanceFactory) read(data *schema.ResourceData, i interface{}
 	}
 	responsePayload := map[string]interface{}{}
 	resp, err := openAPIClient.Get(d.openAPIResource, id.(string), &responsePayload, parentIDs...)
+	submitTelemetryHTTPStatusMetric(openAPIClient, TelemetryResourceOperationRead, resourceName, "data_", resp)
 	if err != nil {
 		return err
 	}
@@ -1,11 +1,15 @@
 package openapi
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/version"
 
@@ -21,6 +25,13 @@ const (
 	httpDelete httpMethodSupported = "DELETE"
 )
 
+// idempotentRequestMaxRetries is the number of times a request sent with an idempotency key is retried if no response is received
+const idempotentRequestMaxRetries = 2
+
+// idempotentRequestRetryBackoff is the time to wait before retrying a request sent with an idempotency key, the time is
+// multiplied by the attempt number
+var idempotentRequestRetryBackoff = time.Duration(1 * time.Second)
+
 // ClientOpenAPI defines the behaviour expected to be implemented for the OpenAPI Client used in the Terraform OpenAPI Provider
 type ClientOpenAPI interface {
 	Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
@@ -40,6 +51,12 @@ type ProviderClient struct {
 	providerConfiguration       providerConfiguration
 	apiAuthenticator            specAuthenticator
 	telemetryHandler            TelemetryHandler
+	// tracer is used to trace the HTTP calls, tracing is disabled if nil
+	tracer *otlpTracer
+	// parentSpan is the span the HTTP calls performed by the client belong to (e,g: the resource operation span)
+	parentSpan *traceSpan
+	// idempotencyKeys stores the idempotency keys of the pending creates, the keys are only kept in memory if nil
+	idempotencyKeys *idempotencyKeyStore
 }
 
 // Post performs a POST request to the server API based on the resource configuration and the payload passed in
@@ -97,8 +114,75 @@ func (o *ProviderClient) GetTelemetryHandler() TelemetryHandler {
 	return o.telemetryHandler
 }
 
+// startSpan starts a new span child of the client's parent span (if any) and returns a copy of the client that will
+// create the spans for the HTTP calls as children of the new span. If tracing is not enabled the same client is returned
+func (o *ProviderClient) startSpan(name string) (*traceSpan, ClientOpenAPI) {
+	span := o.tracer.startSpan(name, spanKindInternal, o.parentSpan)
+	if span == nil {
+		return nil, o
+	}
+	tracedClient := *o
+	tracedClient.parentSpan = span
+	return span, &tracedClient
+}
+
 func (o *ProviderClient) performRequest(method httpMethodSupported, resourceURL string, operation *specResourceOperation, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
-	reqContext, err := o.apiAuthenticator.prepareAuth(resourceURL, operation.SecuritySchemes, o.providerConfiguration)
+	if operation.IdempotencyKeyHeader != "" {
+		return o.performIdempotentRequest(method, resourceURL, operation, requestPayload, responsePayload)
+	}
+	return o.performRequestAttempt(method, resourceURL, operation, requestPayload, responsePayload, "", 0)
+}
+
+// performIdempotentRequest performs the request sending an idempotency key (UUID version 4) in the operation's idempotency
+// key header. The key is stored in a pending create marker before the request is sent and the same key is sent on every
+// attempt: requests that were sent but did not receive a response (e,g: connection errors or timeouts) are retried up to
+// idempotentRequestMaxRetries times, and the API can use the key to detect that a previous attempt was already processed
+// and avoid creating duplicates. The marker is removed once a response is received; otherwise it is kept so the create
+// re-applied later on (e,g: after the provider process was interrupted) is sent with the same key
+func (o *ProviderClient) performIdempotentRequest(method httpMethodSupported, resourceURL string, operation *specResourceOperation, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
+	requestID, err := getIdempotentRequestID(method, resourceURL, requestPayload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure the API request for %s %s: %s", method, resourceURL, err)
+	}
+	idempotencyKeys := o.idempotencyKeys
+	if idempotencyKeys == nil {
+		idempotencyKeys = &idempotencyKeyStore{claimed: map[string]bool{}}
+	}
+	idempotencyKey := idempotencyKeys.claim(requestID)
+	for attempt := 0; ; attempt++ {
+		resp, err := o.performRequestAttempt(method, resourceURL, operation, requestPayload, responsePayload, idempotencyKey, attempt)
+		_, noResponse := err.(*httpRequestNoResponseError)
+		if !noResponse {
+			idempotencyKeys.release(requestID, idempotencyKey)
+			return resp, err
+		}
+		if attempt >= idempotentRequestMaxRetries {
+			log.Printf("[WARN] %s %s did not receive a response after %d retries, the idempotency key will be reused the next time the create is applied", method, resourceURL, idempotentRequestMaxRetries)
+			return resp, err
+		}
+		backoff := idempotentRequestRetryBackoff * time.Duration(attempt+1)
+		log.Printf("[WARN] %s %s did not receive a response (%s), retrying with the same idempotency key in %s (retry %d/%d)", method, resourceURL, err, backoff, attempt+1, idempotentRequestMaxRetries)
+		time.Sleep(backoff)
+	}
+}
+
+// performRequestAttempt performs the request tracing it in its own span. The retryCount is the number of times the request
+// has been retried before this attempt (0 for the first attempt)
+func (o *ProviderClient) performRequestAttempt(method httpMethodSupported, resourceURL string, operation *specResourceOperation, requestPayload interface{}, responsePayload interface{}, idempotencyKey string, retryCount int) (*http.Response, error) {
+	span := o.tracer.startSpan(fmt.Sprintf("HTTP %s", method), spanKindClient, o.parentSpan)
+	span.setAttribute("http.method", string(method))
+	span.setAttribute("http.url", resourceURL)
+	span.setAttribute("http.retry_count", retryCount)
+	resp, err := o.performTracedRequest(span, method, resourceURL, operation, requestPayload, responsePayload, idempotencyKey)
+	if resp != nil {
+		span.setAttribute("http.status_code", resp.StatusCode)
+	}
+	span.end(err)
+	return resp, err
+}
+
+func (o *ProviderClient) performTracedRequest(span *traceSpan, method httpMethodSupported, resourceURL string, operation *specResourceOperation, requestPayload interface{}, responsePayload interface{}, idempotencyKey string) (*http.Response, error) {
+	reqContext, err := o.apiAuthenticator.prepareAuth(resourceURL, operation.SecuritySchemes, o.providerConfiguration, span)
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure the API request for %s %s: %s", method, resourceURL, err)
 	}
@@ -107,24 +191,68 @@ func (o *ProviderClient) performRequest(method httpMethodSupported, resourceURL
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure the API request for %s %s: %s", method, resourceURL, err)
 	}
+	if idempotencyKey != "" {
+		reqContext.headers[operation.IdempotencyKeyHeader] = idempotencyKey
+	}
 	log.Printf("[DEBUG] Performing %s %s", method, reqContext.url)
 
 	userAgentHeader := version.BuildUserAgent(runtime.GOOS, runtime.GOARCH)
 	o.appendUserAgentHeader(reqContext.headers, userAgentHeader)
+	if span != nil {
+		// propagating the trace context so the API traces are connected with the provider traces
+		reqContext.headers[traceparentHeader] = span.traceparent()
+	}
 
 	o.logHeadersSafely(reqContext.headers)
 
+	// validating the request can be built before sending it so the errors returned by the http client with no response
+	// are only the ones where the request was actually sent
+	if err := o.validateRequest(method, reqContext.url, requestPayload); err != nil {
+		return nil, fmt.Errorf("failed to configure the API request for %s %s: %s", method, resourceURL, err)
+	}
+
+	var resp *http.Response
 	switch method {
 	case httpPost:
-		return o.httpClient.PostJson(reqContext.url, reqContext.headers, requestPayload, &responsePayload)
+		resp, err = o.httpClient.PostJson(reqContext.url, reqContext.headers, requestPayload, &responsePayload)
 	case httpPut:
-		return o.httpClient.PutJson(reqContext.url, reqContext.headers, requestPayload, &responsePayload)
+		resp, err = o.httpClient.PutJson(reqContext.url, reqContext.headers, requestPayload, &responsePayload)
 	case httpGet:
-		return o.httpClient.Get(reqContext.url, reqContext.headers, &responsePayload)
+		resp, err = o.httpClient.Get(reqContext.url, reqContext.headers, &responsePayload)
 	case httpDelete:
-		return o.httpClient.Delete(reqContext.url, reqContext.headers)
+		resp, err = o.httpClient.Delete(reqContext.url, reqContext.headers)
+	default:
+		return nil, fmt.Errorf("method '%s' not supported", method)
 	}
-	return nil, fmt.Errorf("method '%s' not supported", method)
+	if err != nil && resp == nil {
+		return nil, &httpRequestNoResponseError{err: err}
+	}
+	return resp, err
+}
+
+// validateRequest checks that the payload can be serialised and the request created for the given URL, which are the
+// failures the http client can run into before sending the request
+func (o *ProviderClient) validateRequest(method httpMethodSupported, requestURL string, requestPayload interface{}) error {
+	var body io.Reader
+	if method == httpPost || method == httpPut {
+		payload, err := json.Marshal(requestPayload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+	_, err := http.NewRequest(string(method), requestURL, body)
+	return err
+}
+
+// httpRequestNoResponseError describes a request that was sent but failed without receiving a response from the API (e,g:
+// connection errors or timeouts)
+type httpRequestNoResponseError struct {
+	err error
+}
+
+func (e *httpRequestNoResponseError) Error() string {
+	return e.err.Error()
 }
 
 func (o *ProviderClient) appendUserAgentHeader(headers map[string]string, value string) {
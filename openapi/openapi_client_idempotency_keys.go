@@ -0,0 +1,183 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pborman/uuid"
+)
+
+// idempotencyKeysDirName is the name of the folder (inside the plugin's cache directory) where the idempotency keys of the
+// pending creates are stored
+const idempotencyKeysDirName = "idempotency-keys"
+
+// idempotencyKeyMaxAge defines how long the idempotency key of a pending create is kept. Keys older than this are discarded
+// and a new key is generated the next time the create is applied
+const idempotencyKeyMaxAge = 24 * time.Hour
+
+// pendingIdempotencyKey describes an idempotency key that was sent to the API without receiving a response back
+type pendingIdempotencyKey struct {
+	Key       string    `json:"key"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// idempotencyKeyStore keeps track of the idempotency keys of the pending creates. A pending create marker containing the key
+// is stored before the request is sent and it is removed once the API responds. If no response is received (e,g: the
+// provider process was interrupted or all the retries failed), the marker is kept and the next apply of the same create
+// (same method, URL and payload) reuses the key so the API can detect the create was already processed.
+// The markers are stored in the user's cache directory; if the directory is not available the keys are only kept in memory
+type idempotencyKeyStore struct {
+	dir string
+	// claimed contains the keys in use by the current plugin execution so two identical creates never share the same key
+	claimed map[string]bool
+	mutex   sync.Mutex
+}
+
+// idempotencyKeyStores contains the idempotency key store of each provider so all the provider configurations (e,g: provider
+// aliases) of the same plugin execution share the keys in use
+var idempotencyKeyStores = struct {
+	sync.Mutex
+	stores map[string]*idempotencyKeyStore
+}{stores: map[string]*idempotencyKeyStore{}}
+
+// newIdempotencyKeyStore returns the idempotencyKeyStore that stores the pending create markers in the provider's cache directory
+func newIdempotencyKeyStore(providerName string) *idempotencyKeyStore {
+	idempotencyKeyStores.Lock()
+	defer idempotencyKeyStores.Unlock()
+	if store, exists := idempotencyKeyStores.stores[providerName]; exists {
+		return store
+	}
+	store := &idempotencyKeyStore{claimed: map[string]bool{}}
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		log.Printf("[WARN] failed to resolve the user's cache directory, the idempotency keys of the pending creates will not be persisted: %s", err)
+	} else {
+		store.dir = filepath.Join(cacheDir, pluginConfigurationCacheDirName, providerName, idempotencyKeysDirName)
+	}
+	idempotencyKeyStores.stores[providerName] = store
+	return store
+}
+
+// getIdempotentRequestID returns the identifier of the request used to look up its pending create marker
+func getIdempotentRequestID(method httpMethodSupported, resourceURL string, requestPayload interface{}) (string, error) {
+	payload, err := json.Marshal(requestPayload)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%s %s %s", method, resourceURL, payload)))), nil
+}
+
+// claim returns the idempotency key to use for the request. The key of a pending create of a previous execution is reused
+// if there is one that is not already in use; otherwise a new random key (UUID version 4) is generated and stored in the
+// request's pending create marker before returning it
+func (s *idempotencyKeyStore) claim(requestID string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	pendingKeys := s.read(requestID)
+	for _, pendingKey := range pendingKeys {
+		if !s.claimed[pendingKey.Key] {
+			log.Printf("[INFO] reusing the idempotency key of a pending create that did not receive a response (created at %s)", pendingKey.CreatedAt.Format(time.RFC3339))
+			s.claimed[pendingKey.Key] = true
+			return pendingKey.Key
+		}
+	}
+	key := uuid.New()
+	if err := s.write(requestID, append(pendingKeys, pendingIdempotencyKey{Key: key, CreatedAt: time.Now()})); err != nil {
+		log.Printf("[WARN] failed to store the idempotency key of the pending create, the key will not be reused if the create is re-applied: %s", err)
+	}
+	s.claimed[key] = true
+	return key
+}
+
+// release removes the key from the request's pending create marker. It is called once the API has responded to the request
+func (s *idempotencyKeyStore) release(requestID, key string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.claimed, key)
+	var pendingKeys []pendingIdempotencyKey
+	for _, pendingKey := range s.read(requestID) {
+		if pendingKey.Key != key {
+			pendingKeys = append(pendingKeys, pendingKey)
+		}
+	}
+	if err := s.write(requestID, pendingKeys); err != nil {
+		log.Printf("[WARN] failed to remove the idempotency key of the create from the pending creates: %s", err)
+	}
+}
+
+func (s *idempotencyKeyStore) getMarkerFilePath(requestID string) string {
+	if s.dir == "" {
+		return ""
+	}
+	return filepath.Join(s.dir, fmt.Sprintf("%s.json", requestID))
+}
+
+// read returns the pending keys stored for the request, expired keys are discarded
+func (s *idempotencyKeyStore) read(requestID string) []pendingIdempotencyKey {
+	markerFilePath := s.getMarkerFilePath(requestID)
+	if markerFilePath == "" {
+		return nil
+	}
+	content, err := ioutil.ReadFile(markerFilePath) // #nosec G304
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("[WARN] failed to read the pending create marker '%s': %s", markerFilePath, err)
+		}
+		return nil
+	}
+	var storedKeys []pendingIdempotencyKey
+	if err := json.Unmarshal(content, &storedKeys); err != nil {
+		log.Printf("[WARN] ignoring invalid pending create marker '%s': %s", markerFilePath, err)
+		return nil
+	}
+	var pendingKeys []pendingIdempotencyKey
+	for _, storedKey := range storedKeys {
+		if storedKey.Key != "" && time.Since(storedKey.CreatedAt) < idempotencyKeyMaxAge {
+			pendingKeys = append(pendingKeys, storedKey)
+		}
+	}
+	return pendingKeys
+}
+
+// write stores the pending keys for the request; the marker file is removed if there are no pending keys left. The file is
+// written to a temporary file first and then renamed so a process interrupted while writing never leaves a corrupted marker
+func (s *idempotencyKeyStore) write(requestID string, pendingKeys []pendingIdempotencyKey) error {
+	markerFilePath := s.getMarkerFilePath(requestID)
+	if markerFilePath == "" {
+		return nil
+	}
+	if len(pendingKeys) == 0 {
+		if err := os.Remove(markerFilePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	content, err := json.Marshal(pendingKeys)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return err
+	}
+	tmpFile, err := ioutil.TempFile(s.dir, fmt.Sprintf("%s.*.tmp", requestID))
+	if err != nil {
+		return err
+	}
+	if _, err := tmpFile.Write(content); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpFile.Name())
+		return err
+	}
+	return os.Rename(tmpFile.Name(), markerFilePath)
+}
@@ -0,0 +1,84 @@
+package openapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetIdempotentRequestID(t *testing.T) {
+	Convey("Given two requests with the same method, URL and payload", t, func() {
+		requestID, err := getIdempotentRequestID(httpPost, "http://host.com/v1/resource", map[string]interface{}{"name": "some name", "size": 1})
+		So(err, ShouldBeNil)
+		sameRequestID, err := getIdempotentRequestID(httpPost, "http://host.com/v1/resource", map[string]interface{}{"size": 1, "name": "some name"})
+		So(err, ShouldBeNil)
+		Convey("Then the request IDs should be the same", func() {
+			So(requestID, ShouldEqual, sameRequestID)
+		})
+		Convey("And the request ID of a request with a different payload should be different", func() {
+			otherRequestID, err := getIdempotentRequestID(httpPost, "http://host.com/v1/resource", map[string]interface{}{"name": "some other name", "size": 1})
+			So(err, ShouldBeNil)
+			So(otherRequestID, ShouldNotEqual, requestID)
+		})
+	})
+	Convey("Given a request with a payload that can not be serialised", t, func() {
+		_, err := getIdempotentRequestID(httpPost, "http://host.com/v1/resource", make(chan int))
+		Convey("Then the error returned should not be nil", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestIdempotencyKeyStore(t *testing.T) {
+	Convey("Given an idempotencyKeyStore", t, func() {
+		storeDir, err := ioutil.TempDir("", "idempotency-keys")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(storeDir)
+		store := &idempotencyKeyStore{dir: storeDir, claimed: map[string]bool{}}
+		Convey("When claim is called", func() {
+			key := store.claim("requestID")
+			Convey("Then the key should be stored in the request's pending create marker", func() {
+				So(store.read("requestID"), ShouldHaveLength, 1)
+				So(store.read("requestID")[0].Key, ShouldEqual, key)
+			})
+			Convey("And claiming the same request again in the same execution should return a different key", func() {
+				So(store.claim("requestID"), ShouldNotEqual, key)
+				So(store.read("requestID"), ShouldHaveLength, 2)
+			})
+			Convey("And claiming the same request from a new execution should return the same key", func() {
+				newStore := &idempotencyKeyStore{dir: storeDir, claimed: map[string]bool{}}
+				So(newStore.claim("requestID"), ShouldEqual, key)
+			})
+			Convey("And releasing the key should remove the pending create marker", func() {
+				store.release("requestID", key)
+				_, err := os.Stat(filepath.Join(storeDir, "requestID.json"))
+				So(os.IsNotExist(err), ShouldBeTrue)
+			})
+		})
+		Convey("When claim is called and the request has an expired pending key", func() {
+			expiredKeys, _ := json.Marshal([]pendingIdempotencyKey{{Key: "expiredKey", CreatedAt: time.Now().Add(-idempotencyKeyMaxAge)}})
+			So(ioutil.WriteFile(filepath.Join(storeDir, "requestID.json"), expiredKeys, 0600), ShouldBeNil)
+			key := store.claim("requestID")
+			Convey("Then a new key should be returned", func() {
+				So(key, ShouldNotEqual, "expiredKey")
+			})
+			Convey("And the expired key should be removed from the pending create marker", func() {
+				So(store.read("requestID"), ShouldHaveLength, 1)
+				So(store.read("requestID")[0].Key, ShouldEqual, key)
+			})
+		})
+		Convey("When the store has no directory configured", func() {
+			inMemoryStore := &idempotencyKeyStore{claimed: map[string]bool{}}
+			key := inMemoryStore.claim("requestID")
+			Convey("Then a key should be returned and nothing should be stored", func() {
+				So(key, ShouldNotBeEmpty)
+				So(inMemoryStore.read("requestID"), ShouldBeEmpty)
+			})
+		})
+	})
+}
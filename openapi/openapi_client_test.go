@@ -5,12 +5,15 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-openapi/spec"
 
 	"github.com/dikhan/http_goclient"
+	"github.com/pborman/uuid"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -895,6 +898,34 @@ func TestPerformRequest(t *testing.T) {
 				So(httpClient.In.(map[string]interface{})[expectedReqPayloadProperty1], ShouldEqual, expectedReqPayloadProperty1Value)
 			})
 		})
+		Convey("When performRequest POST method is called with a resource operation that expects an idempotency key", func() {
+			resourcePostOperation := &specResourceOperation{
+				HeaderParameters:     SpecHeaderParameters{},
+				responses:            specResponses{},
+				SecuritySchemes:      SpecSecuritySchemes{},
+				IdempotencyKeyHeader: idempotencyKeyHeader,
+			}
+			requestPayload := map[string]interface{}{"label": "some label"}
+			_, err := providerClient.performRequest("POST", "http://host.com/v1/resource", resourcePostOperation, requestPayload, nil)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And then client should have received the idempotency key header with a UUID value", func() {
+				So(httpClient.Headers, ShouldContainKey, idempotencyKeyHeader)
+				So(uuid.Parse(httpClient.Headers[idempotencyKeyHeader]), ShouldNotBeNil)
+			})
+			Convey("And then the idempotency key should be a random UUID", func() {
+				version, ok := uuid.Parse(httpClient.Headers[idempotencyKeyHeader]).Version()
+				So(ok, ShouldBeTrue)
+				So(version, ShouldEqual, uuid.Version(4))
+			})
+			Convey("And then a second request with the same payload should send a different idempotency key", func() {
+				idempotencyKey := httpClient.Headers[idempotencyKeyHeader]
+				_, err := providerClient.performRequest("POST", "http://host.com/v1/resource", resourcePostOperation, map[string]interface{}{"label": "some label"}, nil)
+				So(err, ShouldBeNil)
+				So(httpClient.Headers[idempotencyKeyHeader], ShouldNotEqual, idempotencyKey)
+			})
+		})
 		Convey("When performRequest with a method that is not supported", func() {
 			resourcePostOperation := &specResourceOperation{
 				HeaderParameters: SpecHeaderParameters{},
@@ -944,6 +975,121 @@ func TestPerformRequest(t *testing.T) {
 	})
 }
 
+// httpClientNoResponseStub is an HttpClientStub that fails the given number of POST requests without returning a response
+// and keeps track of the idempotency keys received
+type httpClientNoResponseStub struct {
+	http_goclient.HttpClientStub
+	failures        int
+	idempotencyKeys []string
+}
+
+func (c *httpClientNoResponseStub) PostJson(url string, headers map[string]string, in interface{}, out interface{}) (*http.Response, error) {
+	c.idempotencyKeys = append(c.idempotencyKeys, headers[idempotencyKeyHeader])
+	if len(c.idempotencyKeys) <= c.failures {
+		return nil, errors.New("connection refused")
+	}
+	return c.HttpClientStub.PostJson(url, headers, in, out)
+}
+
+func TestPerformIdempotentRequest(t *testing.T) {
+	defaultRetryBackoff := idempotentRequestRetryBackoff
+	idempotentRequestRetryBackoff = time.Millisecond
+	defer func() { idempotentRequestRetryBackoff = defaultRetryBackoff }()
+	Convey("Given a providerClient and a resource operation that expects an idempotency key", t, func() {
+		resourcePostOperation := &specResourceOperation{
+			HeaderParameters:     SpecHeaderParameters{},
+			responses:            specResponses{},
+			SecuritySchemes:      SpecSecuritySchemes{},
+			IdempotencyKeyHeader: idempotencyKeyHeader,
+		}
+		newProviderClient := func(httpClient http_goclient.HttpClientIface) *ProviderClient {
+			return &ProviderClient{
+				httpClient:       httpClient,
+				apiAuthenticator: &specStubAuthenticator{authContext: &authContext{headers: map[string]string{}}},
+			}
+		}
+		Convey("When performRequest is called and the first attempts do not receive a response", func() {
+			httpClient := &httpClientNoResponseStub{failures: 2, HttpClientStub: http_goclient.HttpClientStub{Response: &http.Response{StatusCode: http.StatusCreated}}}
+			resp, err := newProviderClient(httpClient).performRequest(httpPost, "http://host.com/v1/resource", resourcePostOperation, nil, nil)
+			Convey("Then the request should be retried till it succeeds", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+				So(httpClient.idempotencyKeys, ShouldHaveLength, 3)
+			})
+			Convey("And every attempt should send the same idempotency key", func() {
+				So(httpClient.idempotencyKeys[1], ShouldEqual, httpClient.idempotencyKeys[0])
+				So(httpClient.idempotencyKeys[2], ShouldEqual, httpClient.idempotencyKeys[0])
+			})
+		})
+		Convey("When performRequest is called and none of the attempts receive a response", func() {
+			httpClient := &httpClientNoResponseStub{failures: 5}
+			_, err := newProviderClient(httpClient).performRequest(httpPost, "http://host.com/v1/resource", resourcePostOperation, nil, nil)
+			Convey("Then the error returned should be the last attempt error", func() {
+				So(err.Error(), ShouldEqual, "connection refused")
+			})
+			Convey("And the request should be attempted up to the max retries", func() {
+				So(httpClient.idempotencyKeys, ShouldHaveLength, idempotentRequestMaxRetries+1)
+			})
+		})
+		Convey("When performRequest is called and the API responds with an error", func() {
+			httpClient := &httpClientNoResponseStub{HttpClientStub: http_goclient.HttpClientStub{Response: &http.Response{StatusCode: http.StatusInternalServerError}, Error: errors.New("some error")}}
+			_, err := newProviderClient(httpClient).performRequest(httpPost, "http://host.com/v1/resource", resourcePostOperation, nil, nil)
+			Convey("Then the error returned should be the API error", func() {
+				So(err.Error(), ShouldEqual, "some error")
+			})
+			Convey("And the request should not be retried", func() {
+				So(httpClient.idempotencyKeys, ShouldHaveLength, 1)
+			})
+		})
+		Convey("When performRequest is called with a payload that can not be serialised", func() {
+			httpClient := &httpClientNoResponseStub{failures: 5}
+			_, err := newProviderClient(httpClient).performRequest(httpPost, "http://host.com/v1/resource", resourcePostOperation, map[string]interface{}{"invalid": make(chan int)}, nil)
+			Convey("Then the error returned should describe the request could not be configured", func() {
+				So(err.Error(), ShouldStartWith, "failed to configure the API request for POST http://host.com/v1/resource:")
+			})
+			Convey("And the request should not be sent", func() {
+				So(httpClient.idempotencyKeys, ShouldBeEmpty)
+			})
+		})
+		Convey("When performRequest is called with an idempotency key store and none of the attempts receive a response", func() {
+			storeDir, err := ioutil.TempDir("", "idempotency-keys")
+			So(err, ShouldBeNil)
+			defer os.RemoveAll(storeDir)
+			httpClient := &httpClientNoResponseStub{failures: 5}
+			providerClient := newProviderClient(httpClient)
+			providerClient.idempotencyKeys = &idempotencyKeyStore{dir: storeDir, claimed: map[string]bool{}}
+			_, err = providerClient.performRequest(httpPost, "http://host.com/v1/resource", resourcePostOperation, map[string]interface{}{"name": "some name"}, nil)
+			So(err, ShouldNotBeNil)
+			Convey("And the create is applied again by a new plugin execution and it receives a response", func() {
+				retryHTTPClient := &httpClientNoResponseStub{HttpClientStub: http_goclient.HttpClientStub{Response: &http.Response{StatusCode: http.StatusCreated}}}
+				retryProviderClient := newProviderClient(retryHTTPClient)
+				retryProviderClient.idempotencyKeys = &idempotencyKeyStore{dir: storeDir, claimed: map[string]bool{}}
+				_, err := retryProviderClient.performRequest(httpPost, "http://host.com/v1/resource", resourcePostOperation, map[string]interface{}{"name": "some name"}, nil)
+				So(err, ShouldBeNil)
+				Convey("Then the request should be sent with the idempotency key of the pending create", func() {
+					So(retryHTTPClient.idempotencyKeys, ShouldResemble, []string{httpClient.idempotencyKeys[0]})
+				})
+				Convey("And the pending create marker should be removed", func() {
+					files, err := ioutil.ReadDir(storeDir)
+					So(err, ShouldBeNil)
+					So(files, ShouldBeEmpty)
+				})
+			})
+			Convey("And a different create is applied by a new plugin execution", func() {
+				otherHTTPClient := &httpClientNoResponseStub{HttpClientStub: http_goclient.HttpClientStub{Response: &http.Response{StatusCode: http.StatusCreated}}}
+				otherProviderClient := newProviderClient(otherHTTPClient)
+				otherProviderClient.idempotencyKeys = &idempotencyKeyStore{dir: storeDir, claimed: map[string]bool{}}
+				_, err := otherProviderClient.performRequest(httpPost, "http://host.com/v1/resource", resourcePostOperation, map[string]interface{}{"name": "some other name"}, nil)
+				So(err, ShouldBeNil)
+				Convey("Then the request should be sent with a new idempotency key", func() {
+					So(otherHTTPClient.idempotencyKeys, ShouldHaveLength, 1)
+					So(otherHTTPClient.idempotencyKeys[0], ShouldNotEqual, httpClient.idempotencyKeys[0])
+				})
+			})
+		})
+	})
+}
+
 func TestProviderClientPost(t *testing.T) {
 
 	Convey("Given a providerClient set up with stub auth that injects some headers to the request", t, func() {
@@ -46,11 +46,17 @@ const (
 // Currently only OpenAPI v2 version is supported but this constructor is ready to handle new implementations such as v3
 // when the time comes
 func CreateSpecAnalyser(specAnalyserVersion SpecAnalyserVersion, openAPIDocumentURL string) (SpecAnalyser, error) {
+	return CreateSpecAnalyserWithOverrides(specAnalyserVersion, openAPIDocumentURL, nil)
+}
+
+// CreateSpecAnalyserWithOverrides is a factory method that returns the appropriate implementation of SpecAnalyser depending
+// upon the openApiSpecAnalyserVersion passed in. The overrides provided are applied to the OpenAPI document before it is analysed
+func CreateSpecAnalyserWithOverrides(specAnalyserVersion SpecAnalyserVersion, openAPIDocumentURL string, overrides *ServiceOverridesV1) (SpecAnalyser, error) {
 	var err error
 	var specAnalyser SpecAnalyser
 	switch specAnalyserVersion {
 	case specAnalyserV2:
-		specAnalyser, err = newSpecAnalyserV2(openAPIDocumentURL)
+		specAnalyser, err = newSpecAnalyserV2WithOverrides(openAPIDocumentURL, overrides)
 	default:
 		return nil, fmt.Errorf("open api spec analyser version '%s' not supported, please choose a valid SpecAnalyser implementation [%s]", specAnalyserVersion, specAnalyserV2)
 	}
@@ -14,11 +14,13 @@ type specAuthenticator interface {
 	// with authentication details like access tokens, url with a query token, etc).
 	// The following parameters describe the operationId for which the authentication is being prepared, the url of
 	// the resource, the operation security schemes and the provider config containing the actual values like tokens,
-	// special headers, etc for each security schemes
-	prepareAuth(url string, operationSecuritySchemes SpecSecuritySchemes, providerConfig providerConfiguration) (*authContext, error)
+	// special headers, etc for each security schemes. The parent span (nil if tracing is disabled) is used to trace any
+	// calls performed to prepare the authentication (e,g: token refreshes)
+	prepareAuth(url string, operationSecuritySchemes SpecSecuritySchemes, providerConfig providerConfiguration, parentSpan *traceSpan) (*authContext, error)
 }
 
 type authContext struct {
-	headers map[string]string
-	url     string
+	headers    map[string]string
+	url        string
+	parentSpan *traceSpan
 }
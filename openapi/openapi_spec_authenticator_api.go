@@ -49,10 +49,11 @@ func (oa apiAuth) fetchRequiredAuthenticators(operationSecuritySchemes SpecSecur
 	return authenticators, nil
 }
 
-func (oa apiAuth) prepareAuth(url string, operationSecuritySchemes SpecSecuritySchemes, providerConfig providerConfiguration) (*authContext, error) {
+func (oa apiAuth) prepareAuth(url string, operationSecuritySchemes SpecSecuritySchemes, providerConfig providerConfiguration, parentSpan *traceSpan) (*authContext, error) {
 	authContext := &authContext{
-		headers: map[string]string{},
-		url:     url,
+		headers:    map[string]string{},
+		url:        url,
+		parentSpan: parentSpan,
 	}
 	if required, requiredSecuritySchemes := oa.authRequired(url, operationSecuritySchemes); required {
 		authenticators, err := oa.fetchRequiredAuthenticators(requiredSecuritySchemes, providerConfig)
@@ -342,7 +342,7 @@ func TestPrepareAuth(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		authContext, err := tc.apiAuthenticator.prepareAuth(tc.inputURL, tc.inputOperationSecuritySchemes, tc.inputProviderConfig)
+		authContext, err := tc.apiAuthenticator.prepareAuth(tc.inputURL, tc.inputOperationSecuritySchemes, tc.inputProviderConfig, nil)
 		assert.Equal(t, tc.expectedError, err, tc.name)
 		assert.Equal(t, tc.expectedHeaders, authContext.headers, tc.name)
 		assert.Equal(t, tc.expectedURL, authContext.url, tc.name)
@@ -38,12 +38,25 @@ func (a apiRefreshTokenAuthenticator) getType() authType {
 // prepareAuth will send a post request to the refreshTokenURL and get the access token from the response Authorization
 // header. Otherwise, it will fail.
 func (a apiRefreshTokenAuthenticator) prepareAuth(authContext *authContext) error {
+	span := authContext.parentSpan.startChildSpan("token refresh", spanKindClient)
+	span.setAttribute("http.method", http.MethodPost)
+	span.setAttribute("http.url", a.refreshTokenURL)
+	err := a.refreshToken(authContext, span)
+	span.end(err)
+	return err
+}
+
+func (a apiRefreshTokenAuthenticator) refreshToken(authContext *authContext, span *traceSpan) error {
 	apiKey := a.getContext().(apiKey)
 	headers := map[string]string{apiKey.name: apiKey.value}
+	if span != nil {
+		headers[traceparentHeader] = span.traceparent()
+	}
 	r, err := a.httpClient.PostJson(a.refreshTokenURL, headers, nil, nil)
 	if err != nil {
 		return err
 	}
+	span.setAttribute("http.status_code", r.StatusCode)
 	if r.StatusCode != http.StatusOK && r.StatusCode != http.StatusNoContent {
 		return fmt.Errorf("refresh token POST response '%s' status code '%d' not matching expected response status code [%d, %d]", a.refreshTokenURL, r.StatusCode, http.StatusOK, http.StatusNoContent)
 	}
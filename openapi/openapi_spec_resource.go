@@ -11,8 +11,13 @@ type SpecResource interface {
 	getResourcePath(parentIDs []string) (string, error)
 	GetResourceSchema() (*SpecSchemaDefinition, error)
 	ShouldIgnoreResource() bool
+	// GetDeprecationMessage returns the deprecation message if the resource is deprecated; empty string otherwise
+	GetDeprecationMessage() string
 	getResourceOperations() specResourceOperations
 	getTimeouts() (*specTimeouts, error)
+	// getStateMigrations returns the schema version and the state migrations of the resource; nil if the resource schema is
+	// not versioned
+	getStateMigrations() (*specStateMigrations, error)
 	// GetParentResourceInfo returns a struct populated with relevant ParentResourceInfo if the resource is considered
 	// a subresource; nil otherwise.
 	GetParentResourceInfo() *ParentResourceInfo
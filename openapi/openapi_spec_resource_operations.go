@@ -12,5 +12,8 @@ type specResourceOperations struct {
 type specResourceOperation struct {
 	SecuritySchemes  SpecSecuritySchemes
 	HeaderParameters SpecHeaderParameters
-	responses        specResponses
+	// IdempotencyKeyHeader contains the name of the header the idempotency key is sent in, empty if the operation does
+	// not expect an idempotency key
+	IdempotencyKeyHeader string
+	responses            specResponses
 }
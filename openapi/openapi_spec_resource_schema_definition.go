@@ -2,6 +2,7 @@ package openapi
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
@@ -34,6 +35,11 @@ func (s *SpecSchemaDefinition) convertToDataSourceSpecSchemaDefinitionProperty(s
 	specSchemaDefinitionProperty.Required = false
 	specSchemaDefinitionProperty.Computed = true
 	specSchemaDefinitionProperty.Default = nil
+	// data sources do not have configurable properties, hence the attribute relationships do not apply
+	specSchemaDefinitionProperty.ConflictsWith = nil
+	specSchemaDefinitionProperty.RequiredWith = nil
+	specSchemaDefinitionProperty.ExactlyOneOf = nil
+	specSchemaDefinitionProperty.AtLeastOneOf = nil
 	if specSchemaDefinitionProperty.SpecSchemaDefinition != nil {
 		dataSourceObjectSpecSchemaDefinition := &SpecSchemaDefinition{
 			Properties: SpecSchemaDefinitionProperties{},
@@ -186,3 +192,214 @@ func (s *SpecSchemaDefinition) getPropertyBasedOnTerraformName(terraformName str
 	}
 	return nil, fmt.Errorf("property with terraform name '%s' not existing in resource schema definition", terraformName)
 }
+
+// resolveAttributeRelationships resolves the sibling property names referenced by the attribute relationships of the
+// properties (conflicts with, required with, exactly one of and at least one of) into terraform compliant attribute paths.
+// The pathPrefix is the attribute path prefix of the object containing the properties (empty for the top level properties).
+// Properties that are part of an exactly one of or at least one of relationship are always included in it.
+func (s *SpecSchemaDefinition) resolveAttributeRelationships(pathPrefix string) error {
+	return s.resolveAttributeRelationshipsInObject(pathPrefix, false)
+}
+
+// resolveAttributeRelationshipsInObject resolves the attribute relationships of the properties of the object located at
+// pathPrefix. The nestedInArrayItem flag describes whether the object is (or is nested in) an item of an array of objects
+func (s *SpecSchemaDefinition) resolveAttributeRelationshipsInObject(pathPrefix string, nestedInArrayItem bool) error {
+	for _, property := range s.Properties {
+		property.nestedInArrayItem = nestedInArrayItem
+		var err error
+		if property.ConflictsWith, err = s.getTerraformAttributePaths(pathPrefix, property.ConflictsWith); err != nil {
+			return fmt.Errorf("failed to resolve the conflicts with relationship of property '%s': %s", property.Name, err)
+		}
+		if property.RequiredWith, err = s.getTerraformAttributePaths(pathPrefix, property.RequiredWith); err != nil {
+			return fmt.Errorf("failed to resolve the required with relationship of property '%s': %s", property.Name, err)
+		}
+		if len(property.ExactlyOneOf) > 0 {
+			if property.ExactlyOneOf, err = s.getTerraformAttributePaths(pathPrefix, append([]string{property.Name}, property.ExactlyOneOf...)); err != nil {
+				return fmt.Errorf("failed to resolve the exactly one of relationship of property '%s': %s", property.Name, err)
+			}
+		}
+		if len(property.AtLeastOneOf) > 0 {
+			if property.AtLeastOneOf, err = s.getTerraformAttributePaths(pathPrefix, append([]string{property.Name}, property.AtLeastOneOf...)); err != nil {
+				return fmt.Errorf("failed to resolve the at least one of relationship of property '%s': %s", property.Name, err)
+			}
+		}
+		if property.SpecSchemaDefinition != nil {
+			if err := property.SpecSchemaDefinition.resolveAttributeRelationshipsInObject(property.getNestedTerraformAttributePathPrefix(pathPrefix), nestedInArrayItem || property.isArrayOfObjectsProperty()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *SpecSchemaDefinition) getTerraformAttributePaths(pathPrefix string, propertyNames []string) ([]string, error) {
+	var attributePaths []string
+	for _, propertyName := range propertyNames {
+		property, err := s.getProperty(propertyName)
+		if err != nil {
+			return nil, err
+		}
+		attributePath := property.getTerraformAttributePath(pathPrefix)
+		if !s.containsString(attributePaths, attributePath) {
+			attributePaths = append(attributePaths, attributePath)
+		}
+	}
+	return attributePaths, nil
+}
+
+func (s *SpecSchemaDefinition) containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// validateAttributeRelationships validates the values of the resource diff against the required with, exactly one of and
+// at least one of relationships of the properties. Nested properties are only validated if the object containing them is
+// configured, and for blocks (lists of objects) the relationships are validated for each of the items configured. Note
+// conflicts with relationships are validated natively by terraform, except for the properties nested in arrays of objects.
+func (s *SpecSchemaDefinition) validateAttributeRelationships(diff *schema.ResourceDiff, pathPrefix string) error {
+	return s.validateAttributeRelationshipsWithPrefix(diff, pathPrefix, pathPrefix)
+}
+
+// validateAttributeRelationshipsWithPrefix validates the relationships of the properties contained in the object located at
+// pathPrefix. The relationships are resolved against the first item of the blocks (resolvedPathPrefix, e,g: 'rules.0.') so
+// they are translated to the item being validated (e,g: 'rules.1.')
+func (s *SpecSchemaDefinition) validateAttributeRelationshipsWithPrefix(diff *schema.ResourceDiff, resolvedPathPrefix, pathPrefix string) error {
+	for _, property := range s.Properties {
+		attributePath := property.getTerraformAttributePath(pathPrefix)
+		if property.nestedInArrayItem {
+			if err := s.validateConflictsWith(diff, attributePath, replaceAttributePathsPrefix(property.ConflictsWith, resolvedPathPrefix, pathPrefix)); err != nil {
+				return err
+			}
+		}
+		if err := s.validateRequiredWith(diff, attributePath, replaceAttributePathsPrefix(property.RequiredWith, resolvedPathPrefix, pathPrefix)); err != nil {
+			return err
+		}
+		if err := s.validateExactlyOneOf(diff, attributePath, replaceAttributePathsPrefix(property.ExactlyOneOf, resolvedPathPrefix, pathPrefix)); err != nil {
+			return err
+		}
+		if err := s.validateAtLeastOneOf(diff, attributePath, replaceAttributePathsPrefix(property.AtLeastOneOf, resolvedPathPrefix, pathPrefix)); err != nil {
+			return err
+		}
+		if property.SpecSchemaDefinition != nil && s.isAttributeConfigured(diff, attributePath) {
+			nestedResolvedPathPrefix := property.getNestedTerraformAttributePathPrefix(resolvedPathPrefix)
+			for _, nestedPathPrefix := range s.getNestedAttributePathPrefixes(diff, property, pathPrefix) {
+				if err := property.SpecSchemaDefinition.validateAttributeRelationshipsWithPrefix(diff, nestedResolvedPathPrefix, nestedPathPrefix); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// getNestedAttributePathPrefixes returns the attribute path prefixes of the objects nested in the property, one per item
+// configured in the case of blocks (e,g: 'rules.0.', 'rules.1.')
+func (s *SpecSchemaDefinition) getNestedAttributePathPrefixes(diff *schema.ResourceDiff, property *SpecSchemaDefinitionProperty, pathPrefix string) []string {
+	attributePath := property.getTerraformAttributePath(pathPrefix)
+	if !property.isArrayOfObjectsProperty() && !(property.isObjectProperty() && property.shouldUseLegacyTerraformSDKBlockApproachForComplexObjects()) {
+		return []string{property.getNestedTerraformAttributePathPrefix(pathPrefix)}
+	}
+	items, _ := diff.Get(attributePath).([]interface{})
+	var pathPrefixes []string
+	for i := range items {
+		pathPrefixes = append(pathPrefixes, fmt.Sprintf("%s.%d.", attributePath, i))
+	}
+	return pathPrefixes
+}
+
+// replaceAttributePathsPrefix replaces the oldPrefix of the attribute paths with the newPrefix
+func replaceAttributePathsPrefix(attributePaths []string, oldPrefix, newPrefix string) []string {
+	if oldPrefix == newPrefix {
+		return attributePaths
+	}
+	var replaced []string
+	for _, attributePath := range attributePaths {
+		replaced = append(replaced, newPrefix+strings.TrimPrefix(attributePath, oldPrefix))
+	}
+	return replaced
+}
+
+func (s *SpecSchemaDefinition) validateConflictsWith(diff *schema.ResourceDiff, attributePath string, conflictsWith []string) error {
+	if len(conflictsWith) == 0 || !s.isAttributeConfigured(diff, attributePath) {
+		return nil
+	}
+	for _, conflictingAttributePath := range conflictsWith {
+		if s.isAttributeConfigured(diff, conflictingAttributePath) {
+			return fmt.Errorf("%q: conflicts with %s", attributePath, conflictingAttributePath)
+		}
+	}
+	return nil
+}
+
+func (s *SpecSchemaDefinition) validateRequiredWith(diff *schema.ResourceDiff, attributePath string, requiredWith []string) error {
+	if len(requiredWith) == 0 || !s.isAttributeConfigured(diff, attributePath) {
+		return nil
+	}
+	for _, requiredAttributePath := range requiredWith {
+		if !s.isAttributeConfigured(diff, requiredAttributePath) {
+			return fmt.Errorf("%s: all of `%s` must be specified", attributePath, strings.Join(append([]string{attributePath}, requiredWith...), ","))
+		}
+	}
+	return nil
+}
+
+func (s *SpecSchemaDefinition) validateExactlyOneOf(diff *schema.ResourceDiff, attributePath string, exactlyOneOf []string) error {
+	if len(exactlyOneOf) == 0 || s.isAnyAttributeUnknown(diff, exactlyOneOf) {
+		return nil
+	}
+	configured := s.getConfiguredAttributes(diff, exactlyOneOf)
+	if len(configured) == 0 {
+		return fmt.Errorf("%s: one of `%s` must be specified", attributePath, strings.Join(exactlyOneOf, ","))
+	}
+	if len(configured) > 1 {
+		return fmt.Errorf("%s: only one of `%s` can be specified, but `%s` were specified", attributePath, strings.Join(exactlyOneOf, ","), strings.Join(configured, ","))
+	}
+	return nil
+}
+
+func (s *SpecSchemaDefinition) validateAtLeastOneOf(diff *schema.ResourceDiff, attributePath string, atLeastOneOf []string) error {
+	if len(atLeastOneOf) == 0 || s.isAnyAttributeUnknown(diff, atLeastOneOf) {
+		return nil
+	}
+	if len(s.getConfiguredAttributes(diff, atLeastOneOf)) == 0 {
+		return fmt.Errorf("%s: one of `%s` must be specified", attributePath, strings.Join(atLeastOneOf, ","))
+	}
+	return nil
+}
+
+func (s *SpecSchemaDefinition) getConfiguredAttributes(diff *schema.ResourceDiff, attributePaths []string) []string {
+	var configured []string
+	for _, attributePath := range attributePaths {
+		if s.isAttributeConfigured(diff, attributePath) {
+			configured = append(configured, attributePath)
+		}
+	}
+	return configured
+}
+
+func (s *SpecSchemaDefinition) isAnyAttributeUnknown(diff *schema.ResourceDiff, attributePaths []string) bool {
+	for _, attributePath := range attributePaths {
+		if !diff.NewValueKnown(attributePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAttributeConfigured returns true if the attribute is set in the configuration or if the value is not known yet (as it
+// may be set once known). The ResourceDiff of the terraform SDK version in use does not expose the raw configuration, so the
+// planned value is used instead. This is safe since the spec analyser makes sure the attributes with relationships are not
+// computed nor have default values, hence their planned value can only come from the configuration. Attributes removed from
+// the configuration are planned with their zero value (rather than being absent), thus zero values are considered not
+// configured.
+func (s *SpecSchemaDefinition) isAttributeConfigured(diff *schema.ResourceDiff, attributePath string) bool {
+	if !diff.NewValueKnown(attributePath) {
+		return true
+	}
+	_, configured := diff.GetOk(attributePath)
+	return configured
+}
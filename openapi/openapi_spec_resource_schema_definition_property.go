@@ -3,6 +3,7 @@ package openapi
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -58,13 +59,108 @@ type SpecSchemaDefinitionProperty struct {
 	// to support complex object types with the legacy SDK (objects that contain properties with different types and configurations
 	// like computed properties).
 	EnableLegacyComplexObjectBlockConfiguration bool
+	// WriteOnly properties are included in requests but never returned by the API, hence the value is kept from the configuration
+	WriteOnly bool
+	// Deprecated properties are still supported but users are warned when using them. DeprecationMessage contains the
+	// message displayed to the users (e,g: migration hints); if empty, a default message is used
+	Deprecated         bool
+	DeprecationMessage string
 	// Default field is only for informative purposes to know what the openapi spec for the property stated the default value is
-	// As per the openapi spec default attributes, the value is expected to be computed by the API
+	// As per the openapi spec default attributes, the value is expected to be computed by the API. If the service provider
+	// opted in to use the spec defaults, the value is used as the terraform default for optional primitive properties instead
 	Default interface{}
+	// Example contains the example value of the property as stated in the openapi spec ('example' attribute or 'x-example'
+	// extension). This field is only for informative purposes (e,g: documentation)
+	Example interface{}
+	// DiffSuppress contains the strategy used to suppress diffs between semantically equal values (e,g: case-insensitive)
+	DiffSuppress string
+	// ConflictsWith, RequiredWith, ExactlyOneOf and AtLeastOneOf describe the relationships between the property and its
+	// sibling properties. They are populated with the names of the sibling properties as defined in the OpenAPI document and
+	// resolved into terraform compliant attribute paths (e,g: 'listener.0.port') when the resource schema is built
+	ConflictsWith []string
+	RequiredWith  []string
+	ExactlyOneOf  []string
+	AtLeastOneOf  []string
+	// nestedInArrayItem is true if the property is nested in an array of objects, in which case the conflicts with
+	// relationship is validated for each of the items instead of natively by terraform
+	nestedInArrayItem bool
 	// only for object type properties or arrays type properties with array items of type object
 	SpecSchemaDefinition *SpecSchemaDefinition
 }
 
+// GetDeprecationMessage returns the deprecation message of the property if the property is deprecated; empty string otherwise
+func (s *SpecSchemaDefinitionProperty) GetDeprecationMessage() string {
+	if !s.Deprecated {
+		return ""
+	}
+	if s.DeprecationMessage != "" {
+		return s.DeprecationMessage
+	}
+	return fmt.Sprintf("property %s is deprecated", s.GetTerraformCompliantPropertyName())
+}
+
+// convertToPrimitiveValue converts the value into the go type expected by terraform for the given primitive property type
+// (e,g: the string "8080" into the integer 8080); an error is returned if the value can not be converted
+func convertToPrimitiveValue(propertyType schemaDefinitionPropertyType, value interface{}) (interface{}, error) {
+	switch propertyType {
+	case TypeString:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case bool, int, int32, int64, float32, float64:
+			return fmt.Sprintf("%v", v), nil
+		}
+	case TypeInt:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case int32:
+			return int(v), nil
+		case int64:
+			return int(v), nil
+		case float32:
+			if float32(int(v)) == v {
+				return int(v), nil
+			}
+		case float64:
+			if float64(int(v)) == v {
+				return int(v), nil
+			}
+		case string:
+			if i, err := strconv.Atoi(v); err == nil {
+				return i, nil
+			}
+		}
+	case TypeFloat:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case float32:
+			return float64(v), nil
+		case int:
+			return float64(v), nil
+		case int32:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f, nil
+			}
+		}
+	case TypeBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("value '%v' is not a valid %s", value, propertyType)
+}
+
 func (s *SpecSchemaDefinitionProperty) isPrimitiveProperty() bool {
 	if s.Type == TypeString || s.Type == TypeInt || s.Type == TypeFloat || s.Type == TypeBool {
 		return true
@@ -107,6 +203,22 @@ func (s *SpecSchemaDefinitionProperty) isPropertyWithNestedObjects() bool {
 	return false
 }
 
+// getTerraformAttributePath returns the terraform attribute path of the property given the attribute path prefix of the
+// object containing the property (empty for top level properties)
+func (s *SpecSchemaDefinitionProperty) getTerraformAttributePath(pathPrefix string) string {
+	return pathPrefix + s.GetTerraformCompliantPropertyName()
+}
+
+// getNestedTerraformAttributePathPrefix returns the attribute path prefix of the properties nested in the property. Nested
+// properties of blocks (lists of objects) are referenced through the first item of the block (e,g: 'listener.0.')
+func (s *SpecSchemaDefinitionProperty) getNestedTerraformAttributePathPrefix(pathPrefix string) string {
+	attributePath := s.getTerraformAttributePath(pathPrefix)
+	if s.isArrayOfObjectsProperty() || (s.isObjectProperty() && s.shouldUseLegacyTerraformSDKBlockApproachForComplexObjects()) {
+		return attributePath + ".0."
+	}
+	return attributePath + "."
+}
+
 func (s *SpecSchemaDefinitionProperty) isPropertyNamedID() bool {
 	return s.GetTerraformCompliantPropertyName() == idDefaultPropertyName
 }
@@ -258,6 +370,8 @@ func (s *SpecSchemaDefinitionProperty) terraformSchema() (*schema.Schema, error)
 
 	case TypeList:
 		if isListOfPrimitives, elemSchema := s.isTerraformListOfSimpleValues(); isListOfPrimitives {
+			// the diffs of the list items are computed using the elem schema
+			elemSchema.DiffSuppressFunc = createDiffSuppressFunc(s.DiffSuppress)
 			terraformSchema.Elem = elemSchema
 		} else {
 			objectSchema, err := s.terraformObjectSchema()
@@ -274,6 +388,17 @@ func (s *SpecSchemaDefinitionProperty) terraformSchema() (*schema.Schema, error)
 	//   - the property is not readOnly and default is nil (only possible when 'x-terraform-computed' extension is set)
 	terraformSchema.Computed = s.isComputed()
 
+	terraformSchema.Description = s.Description
+	// Deprecated properties will produce a warning when used in the configuration
+	terraformSchema.Deprecated = s.GetDeprecationMessage()
+
+	// Terraform validates natively that conflicting properties are not configured together. The rest of the attribute
+	// relationships, and the conflicts with of properties nested in arrays of objects (terraform only supports checking
+	// them against the first item), are validated when the resource diff is computed (more info in resourceFactory.customizeDiff)
+	if !s.nestedInArrayItem {
+		terraformSchema.ConflictsWith = s.ConflictsWith
+	}
+
 	// A sensitive property means that the expectedValue will not be disclosed in the state file, preventing secrets from
 	// being leaked
 	terraformSchema.Sensitive = s.Sensitive
@@ -291,6 +416,7 @@ func (s *SpecSchemaDefinitionProperty) terraformSchema() (*schema.Schema, error)
 	// ValidateFunc is not yet supported on lists or sets
 	if !s.isArrayProperty() && !s.isObjectProperty() {
 		terraformSchema.ValidateFunc = s.validateFunc()
+		terraformSchema.DiffSuppressFunc = createDiffSuppressFunc(s.DiffSuppress)
 	}
 
 	// Don't populate Default if property is readOnly as the property is expected to be computed by the API. Terraform does
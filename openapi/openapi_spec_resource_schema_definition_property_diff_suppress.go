@@ -0,0 +1,147 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// Diff suppress strategies supported by the x-terraform-diff-suppress extension
+const (
+	diffSuppressCaseInsensitive = "case-insensitive"
+	diffSuppressJSONEquivalent  = "json-equivalent"
+	diffSuppressTrimSpace       = "trim-space"
+	diffSuppressCIDR            = "cidr"
+	diffSuppressRFC3339         = "rfc3339"
+	diffSuppressURL             = "url"
+)
+
+// diffSuppressStrategies contains the functions that compare the old and new values of a property for each of the diff
+// suppress strategies. The functions return true if both values are semantically equal, in which case the diff is suppressed
+var diffSuppressStrategies = map[string]func(old, new string) bool{
+	diffSuppressCaseInsensitive: strings.EqualFold,
+	diffSuppressJSONEquivalent:  isJSONEquivalent,
+	diffSuppressTrimSpace:       isTrimSpaceEquivalent,
+	diffSuppressCIDR:            isCIDREquivalent,
+	diffSuppressRFC3339:         isRFC3339Equivalent,
+	diffSuppressURL:             isURLEquivalent,
+}
+
+// getDiffSuppressStrategies returns the sorted list of diff suppress strategies supported
+func getDiffSuppressStrategies() []string {
+	var strategies []string
+	for strategy := range diffSuppressStrategies {
+		strategies = append(strategies, strategy)
+	}
+	sort.Strings(strategies)
+	return strategies
+}
+
+// isDiffSuppressStrategySupported returns true if the strategy is one of the diff suppress strategies supported
+func isDiffSuppressStrategySupported(strategy string) bool {
+	_, supported := diffSuppressStrategies[strategy]
+	return supported
+}
+
+// createDiffSuppressFunc returns the schema.SchemaDiffSuppressFunc for the given strategy; nil is returned if the strategy
+// is not supported
+func createDiffSuppressFunc(strategy string) schema.SchemaDiffSuppressFunc {
+	isEquivalent, supported := diffSuppressStrategies[strategy]
+	if !supported {
+		return nil
+	}
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		return isEquivalent(old, new)
+	}
+}
+
+func isTrimSpaceEquivalent(old, new string) bool {
+	return strings.TrimSpace(old) == strings.TrimSpace(new)
+}
+
+// isJSONEquivalent returns true if both values are valid JSON documents with the same content regardless of formatting and
+// the order of the object keys
+func isJSONEquivalent(old, new string) bool {
+	var oldJSON, newJSON interface{}
+	if err := json.Unmarshal([]byte(old), &oldJSON); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &newJSON); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldJSON, newJSON)
+}
+
+// isCIDREquivalent returns true if both values are the same IP address or CIDR block regardless of the notation used (e,g:
+// 2001:db8::1 and 2001:0db8:0000:0000:0000:0000:0000:0001)
+func isCIDREquivalent(old, new string) bool {
+	oldIP, oldNetwork, oldErr := net.ParseCIDR(old)
+	newIP, newNetwork, newErr := net.ParseCIDR(new)
+	if oldErr == nil && newErr == nil {
+		return oldIP.Equal(newIP) && bytes.Equal(oldNetwork.Mask, newNetwork.Mask)
+	}
+	if oldErr == nil || newErr == nil {
+		return false
+	}
+	oldIP, newIP = net.ParseIP(old), net.ParseIP(new)
+	return oldIP != nil && newIP != nil && oldIP.Equal(newIP)
+}
+
+// isRFC3339Equivalent returns true if both values are RFC3339 timestamps representing the same instant (e,g: 2020-01-01T10:00:00Z
+// and 2020-01-01T11:00:00+01:00)
+func isRFC3339Equivalent(old, new string) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
+
+// isURLEquivalent returns true if both values are the same URL once normalised: scheme and host are case insensitive,
+// trailing dots in the host name and default ports are ignored and an empty path is the same as '/'
+func isURLEquivalent(old, new string) bool {
+	oldURL, err := normaliseURL(old)
+	if err != nil {
+		return false
+	}
+	newURL, err := normaliseURL(new)
+	if err != nil {
+		return false
+	}
+	return oldURL == newURL
+}
+
+func normaliseURL(value string) (string, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return "", err
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	port := u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port != "" {
+		host = host + ":" + port
+	}
+	u.Host = host
+	if u.Path == "" && u.Host != "" {
+		u.Path = "/"
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,56 @@
+package openapi
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDiffSuppressStrategies(t *testing.T) {
+	Convey("Given the diff suppress strategies supported", t, func() {
+		testCases := []struct {
+			strategy           string
+			old                string
+			new                string
+			expectedEquivalent bool
+		}{
+			{strategy: diffSuppressCaseInsensitive, old: "Value", new: "vALUE", expectedEquivalent: true},
+			{strategy: diffSuppressCaseInsensitive, old: "value", new: "other", expectedEquivalent: false},
+			{strategy: diffSuppressTrimSpace, old: "value\n", new: "  value", expectedEquivalent: true},
+			{strategy: diffSuppressTrimSpace, old: "value", new: "val ue", expectedEquivalent: false},
+			{strategy: diffSuppressJSONEquivalent, old: `{"a": 1, "b": {"c": [1, 2]}}`, new: `{"b":{"c":[1,2]},"a":1}`, expectedEquivalent: true},
+			{strategy: diffSuppressJSONEquivalent, old: `{"c": [1, 2]}`, new: `{"c": [2, 1]}`, expectedEquivalent: false},
+			{strategy: diffSuppressJSONEquivalent, old: `{"a": 1}`, new: `not json`, expectedEquivalent: false},
+			{strategy: diffSuppressCIDR, old: "2001:db8::/32", new: "2001:0DB8:0000::/32", expectedEquivalent: true},
+			{strategy: diffSuppressCIDR, old: "2001:db8::1", new: "2001:0db8:0000:0000:0000:0000:0000:0001", expectedEquivalent: true},
+			{strategy: diffSuppressCIDR, old: "10.0.0.0/16", new: "10.0.0.0/24", expectedEquivalent: false},
+			{strategy: diffSuppressCIDR, old: "10.0.0.1/24", new: "10.0.0.1", expectedEquivalent: false},
+			{strategy: diffSuppressCIDR, old: "not an ip", new: "not an ip ", expectedEquivalent: false},
+			{strategy: diffSuppressRFC3339, old: "2020-01-01T10:00:00Z", new: "2020-01-01T11:00:00+01:00", expectedEquivalent: true},
+			{strategy: diffSuppressRFC3339, old: "2020-01-01T10:00:00Z", new: "2020-01-01T10:00:00+01:00", expectedEquivalent: false},
+			{strategy: diffSuppressRFC3339, old: "2020-01-01", new: "2020-01-01T00:00:00Z", expectedEquivalent: false},
+			{strategy: diffSuppressURL, old: "HTTPS://Example.com.:443", new: "https://example.com/", expectedEquivalent: true},
+			{strategy: diffSuppressURL, old: "http://example.com:80/path?q=1", new: "http://EXAMPLE.com/path?q=1", expectedEquivalent: true},
+			{strategy: diffSuppressURL, old: "http://[2001:db8::1]:80/", new: "http://[2001:DB8::1]", expectedEquivalent: true},
+			{strategy: diffSuppressURL, old: "http://example.com:8080", new: "http://example.com", expectedEquivalent: false},
+			{strategy: diffSuppressURL, old: "http://example.com/Path", new: "http://example.com/path", expectedEquivalent: false},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When the diff suppress func for the strategy '%s' is called with old='%s' and new='%s'", tc.strategy, tc.old, tc.new), func() {
+				diffSuppressFunc := createDiffSuppressFunc(tc.strategy)
+				Convey("Then the result should be the expected one", func() {
+					So(diffSuppressFunc("property", tc.old, tc.new, nil), ShouldEqual, tc.expectedEquivalent)
+				})
+			})
+		}
+	})
+	Convey("Given a non supported diff suppress strategy", t, func() {
+		Convey("When createDiffSuppressFunc is called", func() {
+			diffSuppressFunc := createDiffSuppressFunc("non-supported")
+			Convey("Then the diff suppress func returned should be nil", func() {
+				So(diffSuppressFunc, ShouldBeNil)
+			})
+		})
+	})
+}
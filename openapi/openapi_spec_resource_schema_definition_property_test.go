@@ -912,6 +912,123 @@ func TestSpecSchemaDefinitionIsPropertyWithNestedObjects(t *testing.T) {
 
 }
 
+func TestTerraformSchemaDescriptionAndDeprecation(t *testing.T) {
+	Convey("Given a property with a description that is not deprecated", t, func() {
+		s := &SpecSchemaDefinitionProperty{Name: "string_property", Type: TypeString, Description: "some description"}
+		Convey("When terraformSchema method is called", func() {
+			tfPropSchema, err := s.terraformSchema()
+			Convey("Then the resulting tfPropSchema should have the description and should not be deprecated", func() {
+				So(err, ShouldBeNil)
+				So(tfPropSchema.Description, ShouldEqual, "some description")
+				So(tfPropSchema.Deprecated, ShouldBeEmpty)
+			})
+		})
+	})
+	Convey("Given a deprecated property with a deprecation message", t, func() {
+		s := &SpecSchemaDefinitionProperty{Name: "string_property", Type: TypeString, Deprecated: true, DeprecationMessage: "use new_property instead"}
+		Convey("When terraformSchema method is called", func() {
+			tfPropSchema, err := s.terraformSchema()
+			Convey("Then the resulting tfPropSchema should be deprecated with the deprecation message", func() {
+				So(err, ShouldBeNil)
+				So(tfPropSchema.Deprecated, ShouldEqual, "use new_property instead")
+			})
+		})
+	})
+	Convey("Given a deprecated property without a deprecation message", t, func() {
+		s := &SpecSchemaDefinitionProperty{Name: "stringProperty", Type: TypeString, Deprecated: true}
+		Convey("When terraformSchema method is called", func() {
+			tfPropSchema, err := s.terraformSchema()
+			Convey("Then the resulting tfPropSchema should be deprecated with the default deprecation message", func() {
+				So(err, ShouldBeNil)
+				So(tfPropSchema.Deprecated, ShouldEqual, "property string_property is deprecated")
+			})
+		})
+	})
+}
+
+func TestTerraformSchemaConflictsWith(t *testing.T) {
+	Convey("Given a property with a resolved conflicts with relationship", t, func() {
+		s := &SpecSchemaDefinitionProperty{Name: "certificate_arn", Type: TypeString, ConflictsWith: []string{"certificate_body"}}
+		Convey("When terraformSchema method is called", func() {
+			tfPropSchema, err := s.terraformSchema()
+			Convey("Then the resulting tfPropSchema should conflict with the referenced attribute", func() {
+				So(err, ShouldBeNil)
+				So(tfPropSchema.ConflictsWith, ShouldResemble, []string{"certificate_body"})
+			})
+		})
+	})
+}
+
+func TestGetNestedTerraformAttributePathPrefix(t *testing.T) {
+	objectSchemaDefinition := &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{&SpecSchemaDefinitionProperty{Name: "port", Type: TypeInt}}}
+	testCases := []struct {
+		name               string
+		property           *SpecSchemaDefinitionProperty
+		pathPrefix         string
+		expectedPathPrefix string
+	}{
+		{name: "object property", property: &SpecSchemaDefinitionProperty{Name: "tags", Type: TypeObject, SpecSchemaDefinition: objectSchemaDefinition}, pathPrefix: "", expectedPathPrefix: "tags."},
+		{name: "legacy complex object property", property: &SpecSchemaDefinitionProperty{Name: "listener", Type: TypeObject, EnableLegacyComplexObjectBlockConfiguration: true, SpecSchemaDefinition: objectSchemaDefinition}, pathPrefix: "", expectedPathPrefix: "listener.0."},
+		{name: "array of objects property nested in a block", property: &SpecSchemaDefinitionProperty{Name: "rules", Type: TypeList, ArrayItemsType: TypeObject, SpecSchemaDefinition: objectSchemaDefinition}, pathPrefix: "listener.0.", expectedPathPrefix: "listener.0.rules.0."},
+	}
+	for _, tc := range testCases {
+		pathPrefix := tc.property.getNestedTerraformAttributePathPrefix(tc.pathPrefix)
+		assert.Equal(t, tc.expectedPathPrefix, pathPrefix, tc.name)
+	}
+}
+
+func TestTerraformSchemaDiffSuppress(t *testing.T) {
+	Convey("Given a string property with the case-insensitive diff suppress strategy", t, func() {
+		s := &SpecSchemaDefinitionProperty{Name: "string_property", Type: TypeString, DiffSuppress: diffSuppressCaseInsensitive}
+		Convey("When terraformSchema method is called", func() {
+			tfPropSchema, err := s.terraformSchema()
+			Convey("Then the resulting tfPropSchema should suppress the diffs between semantically equal values", func() {
+				So(err, ShouldBeNil)
+				So(tfPropSchema.DiffSuppressFunc("string_property", "VALUE", "value", nil), ShouldBeTrue)
+				So(tfPropSchema.DiffSuppressFunc("string_property", "value", "otherValue", nil), ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a list of strings property with the trim-space diff suppress strategy", t, func() {
+		s := &SpecSchemaDefinitionProperty{Name: "list_property", Type: TypeList, ArrayItemsType: TypeString, DiffSuppress: diffSuppressTrimSpace}
+		Convey("When terraformSchema method is called", func() {
+			tfPropSchema, err := s.terraformSchema()
+			Convey("Then the resulting tfPropSchema items should suppress the diffs between semantically equal values", func() {
+				So(err, ShouldBeNil)
+				So(tfPropSchema.Elem.(*schema.Schema).DiffSuppressFunc("list_property.0", "value ", "value", nil), ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given an object property with a nested property configured with the json-equivalent diff suppress strategy", t, func() {
+		s := &SpecSchemaDefinitionProperty{
+			Name: "object_property",
+			Type: TypeObject,
+			SpecSchemaDefinition: &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					&SpecSchemaDefinitionProperty{Name: "policy", Type: TypeString, DiffSuppress: diffSuppressJSONEquivalent},
+				},
+			},
+		}
+		Convey("When terraformSchema method is called", func() {
+			tfPropSchema, err := s.terraformSchema()
+			Convey("Then the resulting nested property schema should suppress the diffs between semantically equal values", func() {
+				So(err, ShouldBeNil)
+				So(tfPropSchema.Elem.(*schema.Resource).Schema["policy"].DiffSuppressFunc("object_property.policy", `{"a":1,"b":[1,2]}`, `{ "b": [1, 2], "a": 1 }`, nil), ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a string property without diff suppress strategy", t, func() {
+		s := &SpecSchemaDefinitionProperty{Name: "string_property", Type: TypeString}
+		Convey("When terraformSchema method is called", func() {
+			tfPropSchema, err := s.terraformSchema()
+			Convey("Then the resulting tfPropSchema should not have a DiffSuppressFunc", func() {
+				So(err, ShouldBeNil)
+				So(tfPropSchema.DiffSuppressFunc, ShouldBeNil)
+			})
+		})
+	})
+}
+
 func TestTerraformSchema(t *testing.T) {
 	Convey("Given a swagger schema definition that has two nested properties - one being a simple object and the other one a primitive", t, func() {
 		expectedNestedObjectPropertyName := "nested_object1"
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/stretchr/testify/assert"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -881,3 +882,211 @@ func TestGetPropertyBasedOnTerraformName(t *testing.T) {
 	assert.EqualError(t, err, "property with terraform name 'badTerraformPropertyName' not existing in resource schema definition")
 
 }
+
+func TestResolveAttributeRelationships(t *testing.T) {
+	Convey("Given a SpecSchemaDefinition containing properties with attribute relationships, including properties of an array of objects and an object", t, func() {
+		s := &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				&SpecSchemaDefinitionProperty{Name: "certificateArn", Type: TypeString, ConflictsWith: []string{"certificateBody"}, AtLeastOneOf: []string{"certificateBody"}},
+				&SpecSchemaDefinitionProperty{Name: "certificateBody", Type: TypeString, PreferredName: "body"},
+				&SpecSchemaDefinitionProperty{
+					Name:           "rules",
+					Type:           TypeList,
+					ArrayItemsType: TypeObject,
+					SpecSchemaDefinition: &SpecSchemaDefinition{
+						Properties: SpecSchemaDefinitionProperties{
+							&SpecSchemaDefinitionProperty{Name: "port", Type: TypeInt, RequiredWith: []string{"protocol"}},
+							&SpecSchemaDefinitionProperty{Name: "protocol", Type: TypeString},
+						},
+					},
+				},
+				&SpecSchemaDefinitionProperty{
+					Name: "tags",
+					Type: TypeObject,
+					SpecSchemaDefinition: &SpecSchemaDefinition{
+						Properties: SpecSchemaDefinitionProperties{
+							&SpecSchemaDefinitionProperty{Name: "owner", Type: TypeString, ExactlyOneOf: []string{"team", "owner"}},
+							&SpecSchemaDefinitionProperty{Name: "team", Type: TypeString},
+						},
+					},
+				},
+			},
+		}
+		Convey("When resolveAttributeRelationships is called", func() {
+			err := s.resolveAttributeRelationships("")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the top level relationships should be resolved into the terraform compliant names including the property itself for at least one of relationships", func() {
+				So(s.Properties[0].ConflictsWith, ShouldResemble, []string{"body"})
+				So(s.Properties[0].AtLeastOneOf, ShouldResemble, []string{"certificate_arn", "body"})
+			})
+			Convey("And the relationships of the array of objects properties should be resolved using the first item of the block", func() {
+				So(s.Properties[2].SpecSchemaDefinition.Properties[0].RequiredWith, ShouldResemble, []string{"rules.0.protocol"})
+			})
+			Convey("And the relationships of the object properties should be resolved without duplicates", func() {
+				So(s.Properties[3].SpecSchemaDefinition.Properties[0].ExactlyOneOf, ShouldResemble, []string{"tags.owner", "tags.team"})
+			})
+			Convey("And only the properties of the array of objects should be flagged as nested in an array item", func() {
+				So(s.Properties[0].nestedInArrayItem, ShouldBeFalse)
+				So(s.Properties[2].nestedInArrayItem, ShouldBeFalse)
+				So(s.Properties[2].SpecSchemaDefinition.Properties[0].nestedInArrayItem, ShouldBeTrue)
+				So(s.Properties[3].SpecSchemaDefinition.Properties[0].nestedInArrayItem, ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a SpecSchemaDefinition containing a property with a conflicts with relationship referencing a non existing property", t, func() {
+		s := &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				&SpecSchemaDefinitionProperty{Name: "certificate_arn", Type: TypeString, ConflictsWith: []string{"certificate_body"}},
+			},
+		}
+		Convey("When resolveAttributeRelationships is called", func() {
+			err := s.resolveAttributeRelationships("")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "failed to resolve the conflicts with relationship of property 'certificate_arn': property with name 'certificate_body' not existing in resource schema definition")
+			})
+		})
+	})
+}
+
+func TestValidateAttributeRelationships(t *testing.T) {
+	// value used by terraform to represent values that are not known at plan time (e,g: interpolated from other resources)
+	const unknownVariableValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+	Convey("Given a terraform resource created from a SpecSchemaDefinition containing properties with required with, exactly one of and at least one of relationships", t, func() {
+		s := &SpecSchemaDefinition{
+			Properties: SpecSchemaDefinitionProperties{
+				&SpecSchemaDefinitionProperty{Name: "certificate_arn", Type: TypeString, ExactlyOneOf: []string{"certificate_body"}},
+				&SpecSchemaDefinitionProperty{Name: "certificate_body", Type: TypeString},
+				&SpecSchemaDefinitionProperty{Name: "port", Type: TypeInt, RequiredWith: []string{"protocol"}},
+				&SpecSchemaDefinitionProperty{Name: "protocol", Type: TypeString},
+				&SpecSchemaDefinitionProperty{
+					Name:           "rules",
+					Type:           TypeList,
+					ArrayItemsType: TypeObject,
+					SpecSchemaDefinition: &SpecSchemaDefinition{
+						Properties: SpecSchemaDefinitionProperties{
+							&SpecSchemaDefinitionProperty{Name: "path", Type: TypeString, AtLeastOneOf: []string{"host"}},
+							&SpecSchemaDefinitionProperty{Name: "host", Type: TypeString},
+							&SpecSchemaDefinitionProperty{Name: "target", Type: TypeString, ConflictsWith: []string{"host"}},
+						},
+					},
+				},
+			},
+		}
+		So(s.resolveAttributeRelationships(""), ShouldBeNil)
+		resourceSchema, err := s.createResourceSchema()
+		So(err, ShouldBeNil)
+		Convey("Then the conflicts with relationship of the block properties should not be validated natively by terraform", func() {
+			So(resourceSchema["rules"].Elem.(*schema.Resource).Schema["target"].ConflictsWith, ShouldBeEmpty)
+		})
+		resource := &schema.Resource{
+			Schema: resourceSchema,
+			CustomizeDiff: func(diff *schema.ResourceDiff, i interface{}) error {
+				return s.validateAttributeRelationships(diff, "")
+			},
+		}
+		Convey("When the diff is computed for a configuration that meets all the relationships", func() {
+			_, err := resource.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+				"certificate_arn": "arn",
+				"port":            8080,
+				"protocol":        "https",
+				"rules":           []interface{}{map[string]interface{}{"host": "example.com"}},
+			}), nil)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When the diff is computed for a configuration that has none of the exactly one of properties", func() {
+			_, err := resource.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{}), nil)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "certificate_arn: one of `certificate_arn,certificate_body` must be specified")
+			})
+		})
+		Convey("When the diff is computed for a configuration that has more than one of the exactly one of properties", func() {
+			_, err := resource.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+				"certificate_arn":  "arn",
+				"certificate_body": "body",
+			}), nil)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "certificate_arn: only one of `certificate_arn,certificate_body` can be specified, but `certificate_arn,certificate_body` were specified")
+			})
+		})
+		Convey("When the diff is computed for a configuration that is missing a required with property", func() {
+			_, err := resource.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+				"certificate_arn": "arn",
+				"port":            8080,
+			}), nil)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "port: all of `port,protocol` must be specified")
+			})
+		})
+		Convey("When the diff is computed for a configuration that has a block missing all the at least one of properties", func() {
+			_, err := resource.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+				"certificate_arn": "arn",
+				"rules":           []interface{}{map[string]interface{}{}},
+			}), nil)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "rules.0.path: one of `rules.0.path,rules.0.host` must be specified")
+			})
+		})
+		Convey("When the diff is computed for a configuration that has a block where an item other than the first one is missing all the at least one of properties", func() {
+			_, err := resource.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+				"certificate_arn": "arn",
+				"rules":           []interface{}{map[string]interface{}{"host": "example.com"}, map[string]interface{}{}},
+			}), nil)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "rules.1.path: one of `rules.1.path,rules.1.host` must be specified")
+			})
+		})
+		Convey("When the diff is computed for a configuration that has a block where the conflicting properties are configured in different items", func() {
+			_, err := resource.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+				"certificate_arn": "arn",
+				"rules":           []interface{}{map[string]interface{}{"host": "example.com"}, map[string]interface{}{"path": "/", "target": "backend"}},
+			}), nil)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When the diff is computed for a configuration that has a block where an item other than the first one has conflicting properties", func() {
+			_, err := resource.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+				"certificate_arn": "arn",
+				"rules":           []interface{}{map[string]interface{}{"path": "/"}, map[string]interface{}{"host": "example.com", "target": "backend"}},
+			}), nil)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, `"rules.1.target": conflicts with rules.1.host`)
+			})
+		})
+		Convey("When the diff is computed for a configuration that replaces an exactly one of property stored in the state with another one", func() {
+			state := &terraform.InstanceState{ID: "id", Attributes: map[string]string{"id": "id", "certificate_arn": "arn"}}
+			_, err := resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+				"certificate_body": "body",
+			}), nil)
+			Convey("Then the error returned should be nil since the property removed from the configuration is not considered configured", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When the diff is computed for a configuration that removes a required with property stored in the state", func() {
+			state := &terraform.InstanceState{ID: "id", Attributes: map[string]string{"id": "id", "certificate_arn": "arn", "port": "8080", "protocol": "https"}}
+			_, err := resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+				"certificate_arn": "arn",
+				"port":            8080,
+			}), nil)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "port: all of `port,protocol` must be specified")
+			})
+		})
+		Convey("When the diff is computed for a configuration where the values of the relationships are not known yet", func() {
+			_, err := resource.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+				"certificate_arn":  "arn",
+				"certificate_body": unknownVariableValue,
+				"port":             8080,
+				"protocol":         unknownVariableValue,
+			}), nil)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,206 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// State migration actions supported by the x-terraform-state-migrations extension
+const (
+	stateMigrationRename        = "rename"
+	stateMigrationConvertType   = "convert-type"
+	stateMigrationMoveIntoBlock = "move-into-block"
+)
+
+// specStateMigrations defines the current schema version of a resource and the migrations needed to upgrade the states
+// created with previous versions of the schema
+type specStateMigrations struct {
+	SchemaVersion int
+	Migrations    []*specStateMigration
+}
+
+// specStateMigration defines a migration of a top level attribute of the state from the schema Version to Version+1
+type specStateMigration struct {
+	Version   int
+	Action    string
+	Attribute string
+	// To contains the new name of the attribute (rename), the new type of the attribute (convert-type) or the name of the
+	// block the attribute is moved into (move-into-block)
+	To string
+	// From contains the type of the attribute in the previous version of the schema (convert-type)
+	From string
+}
+
+func (s *specStateMigrations) validate() error {
+	if s.SchemaVersion < 0 {
+		return fmt.Errorf("schema version %d must not be negative", s.SchemaVersion)
+	}
+	for _, migration := range s.Migrations {
+		if err := migration.validate(s.SchemaVersion); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *specStateMigration) validate(schemaVersion int) error {
+	if m.Version < 0 || m.Version >= schemaVersion {
+		return fmt.Errorf("state migration version %d must be lower than the schema version %d", m.Version, schemaVersion)
+	}
+	if m.Attribute == "" || m.To == "" {
+		return fmt.Errorf("state migration version %d is missing the attribute or the to field", m.Version)
+	}
+	switch m.Action {
+	case stateMigrationRename, stateMigrationMoveIntoBlock:
+		return nil
+	case stateMigrationConvertType:
+		if !m.isPrimitiveType(m.From) || !m.isPrimitiveType(m.To) {
+			return fmt.Errorf("state migration version %d can only convert attributes between the types %s, %s, %s and %s", m.Version, TypeString, TypeInt, TypeFloat, TypeBool)
+		}
+		return nil
+	}
+	return fmt.Errorf("state migration version %d action '%s' not supported, please use one of the following: %s, %s, %s", m.Version, m.Action, stateMigrationRename, stateMigrationConvertType, stateMigrationMoveIntoBlock)
+}
+
+func (m *specStateMigration) isPrimitiveType(propertyType string) bool {
+	property := SpecSchemaDefinitionProperty{Type: schemaDefinitionPropertyType(propertyType)}
+	return property.isPrimitiveProperty()
+}
+
+// createStateUpgraders returns one state upgrader per previous version of the schema (versions without migrations simply
+// keep the state as is). The current type is the type of the state with the current schema version and it is used to
+// work out the type of the state for each of the previous versions by reverting the migrations
+func (s *specStateMigrations) createStateUpgraders(currentType cty.Type) ([]schema.StateUpgrader, error) {
+	stateUpgraders := make([]schema.StateUpgrader, s.SchemaVersion)
+	versionType := currentType
+	for version := s.SchemaVersion - 1; version >= 0; version-- {
+		migrations := s.getMigrations(version)
+		for i := len(migrations) - 1; i >= 0; i-- {
+			var err error
+			if versionType, err = migrations[i].revertType(versionType); err != nil {
+				return nil, fmt.Errorf("failed to create the state upgrader for version %d: %s", version, err)
+			}
+		}
+		stateUpgraders[version] = schema.StateUpgrader{
+			Version: version,
+			Type:    versionType,
+			Upgrade: s.createStateUpgradeFunc(migrations),
+		}
+	}
+	return stateUpgraders, nil
+}
+
+func (s *specStateMigrations) getMigrations(version int) []*specStateMigration {
+	var migrations []*specStateMigration
+	for _, migration := range s.Migrations {
+		if migration.Version == version {
+			migrations = append(migrations, migration)
+		}
+	}
+	return migrations
+}
+
+func (s *specStateMigrations) createStateUpgradeFunc(migrations []*specStateMigration) schema.StateUpgradeFunc {
+	return func(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		for _, migration := range migrations {
+			if err := migration.upgrade(rawState); err != nil {
+				return nil, fmt.Errorf("failed to upgrade the state from version %d: %s", migration.Version, err)
+			}
+		}
+		return rawState, nil
+	}
+}
+
+// upgrade applies the migration to the raw state (JSON representation of the state)
+func (m *specStateMigration) upgrade(rawState map[string]interface{}) error {
+	value, exists := rawState[m.Attribute]
+	if !exists {
+		return nil
+	}
+	switch m.Action {
+	case stateMigrationRename:
+		rawState[m.To] = value
+	case stateMigrationConvertType:
+		if value == nil {
+			return nil
+		}
+		convertedValue, err := convertToPrimitiveValue(schemaDefinitionPropertyType(m.To), value)
+		if err != nil {
+			return fmt.Errorf("attribute '%s' %s", m.Attribute, err)
+		}
+		rawState[m.Attribute] = convertedValue
+		return nil
+	case stateMigrationMoveIntoBlock:
+		block, _ := rawState[m.To].([]interface{})
+		if len(block) == 0 {
+			block = []interface{}{map[string]interface{}{}}
+		}
+		blockItem, isObject := block[0].(map[string]interface{})
+		if !isObject {
+			return fmt.Errorf("attribute '%s' is not a block", m.To)
+		}
+		blockItem[m.Attribute] = value
+		rawState[m.To] = block
+	}
+	delete(rawState, m.Attribute)
+	return nil
+}
+
+// revertType returns the type of the state before the migration was applied given the type of the state after the migration
+func (m *specStateMigration) revertType(stateType cty.Type) (cty.Type, error) {
+	attributeTypes := stateType.AttributeTypes()
+	switch m.Action {
+	case stateMigrationRename:
+		attributeType, exists := attributeTypes[m.To]
+		if !exists {
+			return cty.NilType, fmt.Errorf("renamed attribute '%s' not found", m.To)
+		}
+		return m.replaceAttribute(attributeTypes, m.To, m.Attribute, attributeType), nil
+	case stateMigrationConvertType:
+		if _, exists := attributeTypes[m.Attribute]; !exists {
+			return cty.NilType, fmt.Errorf("converted attribute '%s' not found", m.Attribute)
+		}
+		return m.replaceAttribute(attributeTypes, m.Attribute, m.Attribute, m.getPrimitiveType(m.From)), nil
+	case stateMigrationMoveIntoBlock:
+		blockType, exists := attributeTypes[m.To]
+		if !exists || !blockType.IsListType() || !blockType.ElementType().IsObjectType() {
+			return cty.NilType, fmt.Errorf("block '%s' not found", m.To)
+		}
+		blockAttributeTypes := blockType.ElementType().AttributeTypes()
+		attributeType, exists := blockAttributeTypes[m.Attribute]
+		if !exists {
+			return cty.NilType, fmt.Errorf("attribute '%s' not found in block '%s'", m.Attribute, m.To)
+		}
+		previousBlockType := cty.List(m.replaceAttribute(blockAttributeTypes, m.Attribute, "", cty.NilType))
+		previousStateType := m.replaceAttribute(attributeTypes, m.To, m.To, previousBlockType)
+		return m.replaceAttribute(previousStateType.AttributeTypes(), m.Attribute, m.Attribute, attributeType), nil
+	}
+	return cty.NilType, fmt.Errorf("action '%s' not supported", m.Action)
+}
+
+// replaceAttribute returns an object type with the same attributes as the ones provided except for the old attribute
+// which is replaced with the new attribute and type. If the new attribute is empty, the old attribute is just removed
+func (m *specStateMigration) replaceAttribute(attributeTypes map[string]cty.Type, oldAttribute, newAttribute string, newAttributeType cty.Type) cty.Type {
+	newAttributeTypes := map[string]cty.Type{}
+	for attribute, attributeType := range attributeTypes {
+		if attribute != oldAttribute {
+			newAttributeTypes[attribute] = attributeType
+		}
+	}
+	if newAttribute != "" {
+		newAttributeTypes[newAttribute] = newAttributeType
+	}
+	return cty.Object(newAttributeTypes)
+}
+
+func (m *specStateMigration) getPrimitiveType(propertyType string) cty.Type {
+	switch schemaDefinitionPropertyType(propertyType) {
+	case TypeInt, TypeFloat:
+		return cty.Number
+	case TypeBool:
+		return cty.Bool
+	}
+	return cty.String
+}
@@ -0,0 +1,161 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStateMigrationsValidate(t *testing.T) {
+	testCases := []struct {
+		name            string
+		stateMigrations specStateMigrations
+		expectedError   string
+	}{
+		{name: "schema version without migrations", stateMigrations: specStateMigrations{SchemaVersion: 1}},
+		{name: "valid migrations", stateMigrations: specStateMigrations{SchemaVersion: 2, Migrations: []*specStateMigration{{Version: 0, Action: stateMigrationRename, Attribute: "name", To: "label"}, {Version: 1, Action: stateMigrationConvertType, Attribute: "port", From: "string", To: "integer"}}}},
+		{name: "negative schema version", stateMigrations: specStateMigrations{SchemaVersion: -1}, expectedError: "schema version -1 must not be negative"},
+		{name: "migration version not lower than the schema version", stateMigrations: specStateMigrations{SchemaVersion: 1, Migrations: []*specStateMigration{{Version: 1, Action: stateMigrationRename, Attribute: "name", To: "label"}}}, expectedError: "state migration version 1 must be lower than the schema version 1"},
+		{name: "migration missing the to field", stateMigrations: specStateMigrations{SchemaVersion: 1, Migrations: []*specStateMigration{{Version: 0, Action: stateMigrationRename, Attribute: "name"}}}, expectedError: "state migration version 0 is missing the attribute or the to field"},
+		{name: "conversion to a non primitive type", stateMigrations: specStateMigrations{SchemaVersion: 1, Migrations: []*specStateMigration{{Version: 0, Action: stateMigrationConvertType, Attribute: "port", From: "string", To: "object"}}}, expectedError: "state migration version 0 can only convert attributes between the types string, integer, number and boolean"},
+		{name: "non supported action", stateMigrations: specStateMigrations{SchemaVersion: 1, Migrations: []*specStateMigration{{Version: 0, Action: "delete", Attribute: "name", To: "label"}}}, expectedError: "state migration version 0 action 'delete' not supported, please use one of the following: rename, convert-type, move-into-block"},
+	}
+	for _, tc := range testCases {
+		err := tc.stateMigrations.validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
+
+func TestStateMigrationUpgrade(t *testing.T) {
+	testCases := []struct {
+		name          string
+		migration     specStateMigration
+		rawState      map[string]interface{}
+		expectedState map[string]interface{}
+		expectedError string
+	}{
+		{
+			name:          "rename attribute",
+			migration:     specStateMigration{Action: stateMigrationRename, Attribute: "name", To: "label"},
+			rawState:      map[string]interface{}{"id": "1234", "name": "some name"},
+			expectedState: map[string]interface{}{"id": "1234", "label": "some name"},
+		},
+		{
+			name:          "convert attribute type",
+			migration:     specStateMigration{Action: stateMigrationConvertType, Attribute: "port", From: "string", To: "integer"},
+			rawState:      map[string]interface{}{"port": "8080"},
+			expectedState: map[string]interface{}{"port": 8080},
+		},
+		{
+			name:          "convert attribute type with a value that can not be converted",
+			migration:     specStateMigration{Action: stateMigrationConvertType, Attribute: "port", From: "string", To: "integer"},
+			rawState:      map[string]interface{}{"port": "http"},
+			expectedError: "attribute 'port' value 'http' is not a valid integer",
+		},
+		{
+			name:          "move attribute into an empty block",
+			migration:     specStateMigration{Action: stateMigrationMoveIntoBlock, Attribute: "protocol", To: "listener"},
+			rawState:      map[string]interface{}{"protocol": "http", "listener": []interface{}{}},
+			expectedState: map[string]interface{}{"listener": []interface{}{map[string]interface{}{"protocol": "http"}}},
+		},
+		{
+			name:          "move attribute into an existing block",
+			migration:     specStateMigration{Action: stateMigrationMoveIntoBlock, Attribute: "protocol", To: "listener"},
+			rawState:      map[string]interface{}{"protocol": "http", "listener": []interface{}{map[string]interface{}{"port": 80}}},
+			expectedState: map[string]interface{}{"listener": []interface{}{map[string]interface{}{"port": 80, "protocol": "http"}}},
+		},
+		{
+			name:          "attribute not present in the state",
+			migration:     specStateMigration{Action: stateMigrationRename, Attribute: "name", To: "label"},
+			rawState:      map[string]interface{}{"id": "1234"},
+			expectedState: map[string]interface{}{"id": "1234"},
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.migration.upgrade(tc.rawState)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedState, tc.rawState, tc.name)
+	}
+}
+
+func TestCreateStateUpgraders(t *testing.T) {
+	Convey("Given a resource schema in version 3 where version 0 renamed an attribute, version 1 did not change the state and version 2 converted the type of an attribute and moved an attribute into a block", t, func() {
+		stateMigrations := &specStateMigrations{
+			SchemaVersion: 3,
+			Migrations: []*specStateMigration{
+				{Version: 0, Action: stateMigrationRename, Attribute: "name", To: "label"},
+				{Version: 2, Action: stateMigrationConvertType, Attribute: "port", From: "string", To: "integer"},
+				{Version: 2, Action: stateMigrationMoveIntoBlock, Attribute: "protocol", To: "listener"},
+			},
+		}
+		currentType := cty.Object(map[string]cty.Type{
+			"id":       cty.String,
+			"label":    cty.String,
+			"port":     cty.Number,
+			"listener": cty.List(cty.Object(map[string]cty.Type{"protocol": cty.String})),
+		})
+		Convey("When createStateUpgraders is called", func() {
+			stateUpgraders, err := stateMigrations.createStateUpgraders(currentType)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And there should be one state upgrader per previous version in ascending order", func() {
+				So(len(stateUpgraders), ShouldEqual, 3)
+				So(stateUpgraders[0].Version, ShouldEqual, 0)
+				So(stateUpgraders[1].Version, ShouldEqual, 1)
+				So(stateUpgraders[2].Version, ShouldEqual, 2)
+			})
+			Convey("And the type of each state upgrader should be the type of the state in the corresponding version", func() {
+				version1Type := cty.Object(map[string]cty.Type{
+					"id":       cty.String,
+					"label":    cty.String,
+					"port":     cty.String,
+					"protocol": cty.String,
+					"listener": cty.List(cty.EmptyObject),
+				})
+				So(stateUpgraders[2].Type.Equals(version1Type), ShouldBeTrue)
+				So(stateUpgraders[1].Type.Equals(version1Type), ShouldBeTrue)
+				version0Type := cty.Object(map[string]cty.Type{
+					"id":       cty.String,
+					"name":     cty.String,
+					"port":     cty.String,
+					"protocol": cty.String,
+					"listener": cty.List(cty.EmptyObject),
+				})
+				So(stateUpgraders[0].Type.Equals(version0Type), ShouldBeTrue)
+			})
+			Convey("And applying the state upgraders in order should migrate a state created with the version 0 to the current version", func() {
+				rawState := map[string]interface{}{"id": "1234", "name": "some name", "port": "8080", "protocol": "http", "listener": []interface{}{}}
+				for _, stateUpgrader := range stateUpgraders {
+					rawState, err = stateUpgrader.Upgrade(rawState, nil)
+					So(err, ShouldBeNil)
+				}
+				So(rawState, ShouldResemble, map[string]interface{}{"id": "1234", "label": "some name", "port": 8080, "listener": []interface{}{map[string]interface{}{"protocol": "http"}}})
+			})
+		})
+	})
+
+	Convey("Given a resource schema with a state migration that renames an attribute that does not exist in the current schema", t, func() {
+		stateMigrations := &specStateMigrations{
+			SchemaVersion: 1,
+			Migrations:    []*specStateMigration{{Version: 0, Action: stateMigrationRename, Attribute: "name", To: "label"}},
+		}
+		Convey("When createStateUpgraders is called", func() {
+			_, err := stateMigrations.createStateUpgraders(cty.Object(map[string]cty.Type{"id": cty.String}))
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "failed to create the state upgrader for version 0: renamed attribute 'label' not found")
+			})
+		})
+	})
+}
@@ -6,6 +6,7 @@ type specStubResource struct {
 	host                    string
 	path                    string
 	shouldIgnore            bool
+	deprecationMessage      string
 	schemaDefinition        *SpecSchemaDefinition
 	resourceGetOperation    *specResourceOperation
 	resourcePostOperation   *specResourceOperation
@@ -13,6 +14,7 @@ type specStubResource struct {
 	resourcePutOperation    *specResourceOperation
 	resourceDeleteOperation *specResourceOperation
 	timeouts                *specTimeouts
+	stateMigrations         *specStateMigrations
 
 	parentResourceNames    []string
 	fullParentResourceName string
@@ -61,6 +63,8 @@ func (s *specStubResource) GetResourceSchema() (*SpecSchemaDefinition, error) {
 
 func (s *specStubResource) ShouldIgnoreResource() bool { return s.shouldIgnore }
 
+func (s *specStubResource) GetDeprecationMessage() string { return s.deprecationMessage }
+
 func (s *specStubResource) getResourceOperations() specResourceOperations {
 	return specResourceOperations{
 		List:   s.resourceListOperation,
@@ -75,6 +79,10 @@ func (s *specStubResource) getTimeouts() (*specTimeouts, error) {
 	return s.timeouts, nil
 }
 
+func (s *specStubResource) getStateMigrations() (*specStateMigrations, error) {
+	return s.stateMigrations, nil
+}
+
 func (s *specStubResource) getHost() (string, error) {
 	return s.host, nil
 }
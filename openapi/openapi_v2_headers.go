@@ -3,10 +3,19 @@ package openapi
 import (
 	"github.com/go-openapi/spec"
 	"log"
+	"strings"
 )
 
 const extTfHeader = "x-terraform-header"
 
+// extTfIdempotencyKey is an operation level extension that enables sending an idempotency key with the requests. The
+// value can either be true (the key is sent in the default idempotencyKeyHeader) or the name of the header to use
+const extTfIdempotencyKey = "x-terraform-idempotency-key"
+
+// idempotencyKeyHeader is the default header used to send the idempotency key. Operations that document a header
+// parameter with this name will also get the idempotency key populated by the provider
+const idempotencyKeyHeader = "Idempotency-Key"
+
 type parameterGroups [][]spec.Parameter
 
 // getHeaderConfigurations gets all the header configurations for a specific
@@ -72,7 +81,47 @@ func getAllHeaderParameters(paths map[string]spec.PathItem) SpecHeaderParameters
 // appends its parameters to the parametersGroups
 func appendOperationParametersIfPresent(parametersGroups parameterGroups, operation *spec.Operation) parameterGroups {
 	if operation != nil {
-		parametersGroups = append(parametersGroups, operation.Parameters)
+		parametersGroups = append(parametersGroups, getOperationParametersWithoutIdempotencyKey(operation))
 	}
 	return parametersGroups
 }
+
+// getIdempotencyKeyHeaderName returns the name of the header the idempotency key should be sent in for the given operation.
+// An empty string is returned if the operation does not expect an idempotency key
+func getIdempotencyKeyHeaderName(operation *spec.Operation) string {
+	if operation == nil {
+		return ""
+	}
+	if enabled, exists := operation.Extensions.GetBool(extTfIdempotencyKey); exists {
+		if enabled {
+			return idempotencyKeyHeader
+		}
+		return ""
+	}
+	if headerName, exists := operation.Extensions.GetString(extTfIdempotencyKey); exists && headerName != "" {
+		return headerName
+	}
+	for _, parameter := range operation.Parameters {
+		if parameter.In == "header" && strings.EqualFold(parameter.Name, idempotencyKeyHeader) {
+			return parameter.Name
+		}
+	}
+	return ""
+}
+
+// getOperationParametersWithoutIdempotencyKey returns the operation parameters excluding the idempotency key header (if
+// any) since its value is populated by the provider and therefore it should not be configurable by the user
+func getOperationParametersWithoutIdempotencyKey(operation *spec.Operation) []spec.Parameter {
+	headerName := getIdempotencyKeyHeaderName(operation)
+	if headerName == "" {
+		return operation.Parameters
+	}
+	var parameters []spec.Parameter
+	for _, parameter := range operation.Parameters {
+		if parameter.In == "header" && strings.EqualFold(parameter.Name, headerName) {
+			continue
+		}
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
@@ -4,6 +4,7 @@ import "github.com/go-openapi/spec"
 
 import (
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
@@ -547,3 +548,52 @@ func TestAppendOperationParametersIfPresent(t *testing.T) {
 		})
 	})
 }
+
+func TestGetIdempotencyKeyHeaderName(t *testing.T) {
+	idempotencyKeyParameter := spec.Parameter{ParamProps: spec.ParamProps{Name: "idempotency-key", In: "header"}}
+	testCases := []struct {
+		name               string
+		operation          *spec.Operation
+		expectedHeaderName string
+	}{
+		{name: "nil operation", operation: nil, expectedHeaderName: ""},
+		{name: "operation without idempotency key", operation: &spec.Operation{}, expectedHeaderName: ""},
+		{name: "extension set to true", operation: &spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfIdempotencyKey: true}}}, expectedHeaderName: "Idempotency-Key"},
+		{name: "extension set to false", operation: &spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfIdempotencyKey: false}}}, expectedHeaderName: ""},
+		{name: "extension set to a custom header name", operation: &spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfIdempotencyKey: "X-Request-Key"}}}, expectedHeaderName: "X-Request-Key"},
+		{name: "idempotency key header parameter", operation: &spec.Operation{OperationProps: spec.OperationProps{Parameters: []spec.Parameter{idempotencyKeyParameter}}}, expectedHeaderName: "idempotency-key"},
+		{name: "extension set to false with an idempotency key header parameter", operation: &spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfIdempotencyKey: false}}, OperationProps: spec.OperationProps{Parameters: []spec.Parameter{idempotencyKeyParameter}}}, expectedHeaderName: ""},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedHeaderName, getIdempotencyKeyHeaderName(tc.operation), tc.name)
+	}
+}
+
+func TestGetOperationParametersWithoutIdempotencyKey(t *testing.T) {
+	Convey("Given an operation with the 'x-terraform-idempotency-key' extension and a parameter for the idempotency key header", t, func() {
+		operation := &spec.Operation{
+			VendorExtensible: spec.VendorExtensible{
+				Extensions: spec.Extensions{extTfIdempotencyKey: "X-Request-Key"},
+			},
+			OperationProps: spec.OperationProps{
+				Parameters: []spec.Parameter{
+					{ParamProps: spec.ParamProps{Name: "X-Request-ID", In: "header", Required: true}},
+					{ParamProps: spec.ParamProps{Name: "X-Request-Key", In: "header", Required: true}},
+				},
+			},
+		}
+		Convey("When getOperationParametersWithoutIdempotencyKey method is called", func() {
+			parameters := getOperationParametersWithoutIdempotencyKey(operation)
+			Convey("Then the parameters returned should not contain the idempotency key header", func() {
+				So(len(parameters), ShouldEqual, 1)
+				So(parameters[0].Name, ShouldEqual, "X-Request-ID")
+			})
+		})
+		Convey("When appendOperationParametersIfPresent method is called", func() {
+			headerConfigProps := getHeaderConfigurationsForParameterGroups(appendOperationParametersIfPresent(parameterGroups{}, operation))
+			Convey("Then the header configs returned should not contain the idempotency key header as it is populated by the provider", func() {
+				So(headerConfigProps, ShouldResemble, SpecHeaderParameters{SpecHeaderParam{Name: "X-Request-ID", IsRequired: true}})
+			})
+		})
+	})
+}
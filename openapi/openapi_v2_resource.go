@@ -58,7 +58,17 @@ const extTfID = "x-terraform-id"
 const extTfComputed = "x-terraform-computed"
 const extTfComplexObjectType = "x-terraform-complex-object-legacy-config"
 const extTfIgnoreOrder = "x-terraform-ignore-order"
+const extTfDiffSuppress = "x-terraform-diff-suppress"
+const extTfWriteOnly = "x-terraform-write-only"
+const extTfDeprecationMessage = "x-terraform-deprecation-message"
+const extTfConflictsWith = "x-terraform-conflicts-with"
+const extTfRequiredWith = "x-terraform-required-with"
+const extTfExactlyOneOf = "x-terraform-exactly-one-of"
+const extTfAtLeastOneOf = "x-terraform-at-least-one-of"
+const extTfSchemaVersion = "x-terraform-schema-version"
+const extTfStateMigrations = "x-terraform-state-migrations"
 const extIgnoreOrder = "x-ignore-order"
+const extExample = "x-example"
 
 // Operation level extensions
 const extTfResourceTimeout = "x-terraform-resource-timeout"
@@ -87,6 +97,10 @@ type SpecV2Resource struct {
 	SchemaDefinitions map[string]spec.Schema
 
 	Paths map[string]spec.PathItem
+
+	// UseSpecDefaults defines whether the default values documented in the OpenAPI document for optional primitive properties
+	// should be used as the terraform default values (enabled via the root level 'x-terraform-provider-use-spec-defaults' extension)
+	UseSpecDefaults bool
 }
 
 // newSpecV2Resource creates a SpecV2Resource with no region and default host
@@ -280,6 +294,29 @@ func (o *SpecV2Resource) ShouldIgnoreResource() bool {
 	return false
 }
 
+// GetDeprecationMessage returns the deprecation message if any of the operations of the resource (POST, GET, PUT or DELETE)
+// is marked as deprecated; empty string is returned otherwise. The message can be customised via the 'x-terraform-deprecation-message'
+// extension (e,g: to provide migration hints), otherwise a default message is returned
+func (o *SpecV2Resource) GetDeprecationMessage() string {
+	deprecated := false
+	for _, operation := range []*spec.Operation{o.RootPathItem.Post, o.InstancePathItem.Get, o.InstancePathItem.Put, o.InstancePathItem.Delete} {
+		if operation == nil || !operation.Deprecated {
+			continue
+		}
+		deprecated = true
+		if message := o.getExtensionStringValue(operation.Extensions, extTfDeprecationMessage); message != "" {
+			return message
+		}
+	}
+	if !deprecated {
+		return ""
+	}
+	if message := o.getExtensionStringValue(o.RootPathItem.Extensions, extTfDeprecationMessage); message != "" {
+		return message
+	}
+	return fmt.Sprintf("resource %s is deprecated", o.GetResourceName())
+}
+
 // GetParentResourceInfo returns the information about the parent resources
 func (o *SpecV2Resource) GetParentResourceInfo() *ParentResourceInfo {
 	resourceParentRegex, _ := regexp.Compile(resourceParentNameRegex)
@@ -335,7 +372,16 @@ func (o *SpecV2Resource) GetParentResourceInfo() *ParentResourceInfo {
 
 // GetResourceSchema returns the resource schema
 func (o *SpecV2Resource) GetResourceSchema() (*SpecSchemaDefinition, error) {
-	return o.getSchemaDefinitionWithOptions(&o.SchemaDefinition, true)
+	schemaDefinition, err := o.getSchemaDefinitionWithOptions(&o.SchemaDefinition, true)
+	if err != nil {
+		return nil, err
+	}
+	// the attribute relationships can only be resolved once the whole resource schema is known since they reference
+	// sibling properties
+	if err := schemaDefinition.resolveAttributeRelationships(""); err != nil {
+		return nil, err
+	}
+	return schemaDefinition, nil
 }
 
 func (o *SpecV2Resource) getSchemaDefinition(schema *spec.Schema) (*SpecSchemaDefinition, error) {
@@ -469,6 +515,22 @@ func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, pro
 		schemaDefinitionProperty.Sensitive = true
 	}
 
+	// A write-only property is the one that is sent to the API but never returned (e,g: passwords), hence the value is kept
+	// from the configuration and it's always considered sensitive
+	if o.isWriteOnlyProperty(property) {
+		if property.ReadOnly {
+			return nil, fmt.Errorf("failed to process property '%s': a readOnly property cannot be writeOnly too", propertyName)
+		}
+		schemaDefinitionProperty.WriteOnly = true
+		schemaDefinitionProperty.Sensitive = true
+	}
+
+	// Deprecated properties are still supported but users will be warned when using them
+	if o.isDeprecatedProperty(property) {
+		schemaDefinitionProperty.Deprecated = true
+		schemaDefinitionProperty.DeprecationMessage = o.getExtensionStringValue(property.Extensions, extTfDeprecationMessage)
+	}
+
 	// field with extTfID metadata takes preference over 'id' fields as the service provider is the one acknowledging
 	// the fact that this field should be used as identifier of the resource
 	if o.isBoolExtensionEnabled(property.Extensions, extTfID) {
@@ -487,14 +549,67 @@ func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, pro
 		schemaDefinitionProperty.EnableLegacyComplexObjectBlockConfiguration = true
 	}
 
+	// Semantically equal values (e,g: same value in different case) returned by the API will not produce diffs
+	if diffSuppress, exists := property.Extensions.GetString(extTfDiffSuppress); exists {
+		if !isDiffSuppressStrategySupported(diffSuppress) {
+			return nil, fmt.Errorf("failed to process property '%s': %s value '%s' not supported, please use one of the following: %s", propertyName, extTfDiffSuppress, diffSuppress, strings.Join(getDiffSuppressStrategies(), ", "))
+		}
+		schemaDefinitionProperty.DiffSuppress = diffSuppress
+	}
+
+	// Attribute relationships reference sibling properties by the name used in the OpenAPI document (e,g: a property that
+	// conflicts with another one)
+	schemaDefinitionProperty.ConflictsWith = getExtensionStringSliceValue(property.Extensions, extTfConflictsWith)
+	schemaDefinitionProperty.RequiredWith = getExtensionStringSliceValue(property.Extensions, extTfRequiredWith)
+	schemaDefinitionProperty.ExactlyOneOf = getExtensionStringSliceValue(property.Extensions, extTfExactlyOneOf)
+	schemaDefinitionProperty.AtLeastOneOf = getExtensionStringSliceValue(property.Extensions, extTfAtLeastOneOf)
+
 	// Use the default keyword in the parameter schema to specify the default value for an optional parameter. The default
 	// value is the one that the server uses if the client does not supply the parameter value in the request.
 	// Link: https://swagger.io/docs/specification/describing-parameters#default
 	schemaDefinitionProperty.Default = property.Default
 
+	// If the service provider opted in, the default value of optional primitive properties is used as the terraform default
+	// value so removing the property from the configuration resets it to the default value (rather than the property being
+	// optional-computed)
+	if o.UseSpecDefaults && property.Default != nil && !schemaDefinitionProperty.Required && !property.ReadOnly && schemaDefinitionProperty.isPrimitiveProperty() {
+		defaultValue, err := o.getTerraformDefaultValue(schemaDefinitionProperty.Type, property.Default)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process property '%s': %s", propertyName, err)
+		}
+		schemaDefinitionProperty.Default = defaultValue
+		schemaDefinitionProperty.Computed = false
+	}
+
+	schemaDefinitionProperty.Example = o.getPropertyExample(property)
+
 	return schemaDefinitionProperty, nil
 }
 
+// getPropertyExample returns the example value of the property. The 'example' attribute takes preference over the 'x-example'
+// extension; nil is returned if the property does not have an example
+func (o *SpecV2Resource) getPropertyExample(property spec.Schema) interface{} {
+	if property.Example != nil {
+		return property.Example
+	}
+	if example, exists := property.Extensions[extExample]; exists {
+		return example
+	}
+	return nil
+}
+
+// isWriteOnlyProperty returns true if the property has the 'x-terraform-write-only' extension enabled or the 'writeOnly'
+// attribute (OpenAPI v3) set to true
+func (o *SpecV2Resource) isWriteOnlyProperty(property spec.Schema) bool {
+	if o.isBoolExtensionEnabled(property.Extensions, extTfWriteOnly) {
+		return true
+	}
+	if writeOnly, ok := property.ExtraProps["writeOnly"].(bool); ok && writeOnly {
+		return true
+	}
+	return false
+}
+
 func (o *SpecV2Resource) isBoolExtensionEnabled(extensions spec.Extensions, extension string) bool {
 	if extensions != nil {
 		if enabled, ok := extensions.GetBool(extension); ok && enabled {
@@ -504,6 +619,15 @@ func (o *SpecV2Resource) isBoolExtensionEnabled(extensions spec.Extensions, exte
 	return false
 }
 
+// isDeprecatedProperty returns true if the property has the 'deprecated' attribute set to true. Note the attribute is
+// not part of the OpenAPI v2 schema object, hence the value is read from the extra properties
+func (o *SpecV2Resource) isDeprecatedProperty(property spec.Schema) bool {
+	if deprecated, ok := property.ExtraProps["deprecated"].(bool); ok && deprecated {
+		return true
+	}
+	return false
+}
+
 func (o *SpecV2Resource) isOptionalComputedProperty(propertyName string, property spec.Schema, requiredProperties []string) (bool, error) {
 	required := o.isRequired(propertyName, requiredProperties)
 	if required {
@@ -567,6 +691,17 @@ func (o *SpecV2Resource) isOptionalComputed(propertyName string, property spec.S
 	return false, nil
 }
 
+// getTerraformDefaultValue converts the default value documented in the OpenAPI document to the go type expected by the
+// terraform schema for the given property type. For instance, integer default values are unmarshalled as float64 and must
+// be converted to int
+func (o *SpecV2Resource) getTerraformDefaultValue(propertyType schemaDefinitionPropertyType, value interface{}) (interface{}, error) {
+	defaultValue, err := convertToPrimitiveValue(propertyType, value)
+	if err != nil {
+		return nil, fmt.Errorf("default %s", err)
+	}
+	return defaultValue, nil
+}
+
 func (o *SpecV2Resource) isArrayItemPrimitiveType(propertyType schemaDefinitionPropertyType) bool {
 	return propertyType == TypeString || propertyType == TypeInt || propertyType == TypeFloat || propertyType == TypeBool
 }
@@ -693,12 +828,13 @@ func (o *SpecV2Resource) createResourceOperation(operation *spec.Operation) *spe
 	if operation == nil {
 		return nil
 	}
-	headerParameters := getHeaderConfigurations(operation.Parameters)
+	headerParameters := getHeaderConfigurations(getOperationParametersWithoutIdempotencyKey(operation))
 	securitySchemes := createSecuritySchemes(operation.Security)
 	return &specResourceOperation{
-		HeaderParameters: headerParameters,
-		SecuritySchemes:  securitySchemes,
-		responses:        o.createResponses(operation),
+		HeaderParameters:     headerParameters,
+		SecuritySchemes:      securitySchemes,
+		IdempotencyKeyHeader: getIdempotencyKeyHeaderName(operation),
+		responses:            o.createResponses(operation),
 	}
 }
 
@@ -767,6 +903,69 @@ func (o *SpecV2Resource) getTimeouts() (*specTimeouts, error) {
 	}, nil
 }
 
+// getStateMigrations returns the schema version of the resource and the migrations needed to upgrade the states created
+// with previous versions of the schema as defined in the 'x-terraform-schema-version' and 'x-terraform-state-migrations'
+// extensions of the resource schema definition. Nil is returned if the resource schema definition is not versioned
+func (o *SpecV2Resource) getStateMigrations() (*specStateMigrations, error) {
+	schemaVersion, exists := o.SchemaDefinition.Extensions[extTfSchemaVersion]
+	if !exists {
+		return nil, nil
+	}
+	stateMigrations := &specStateMigrations{}
+	var isInt bool
+	if stateMigrations.SchemaVersion, isInt = o.getIntValue(schemaVersion); !isInt {
+		return nil, fmt.Errorf("%s value '%v' is not a valid integer", extTfSchemaVersion, schemaVersion)
+	}
+	if migrations, exists := o.SchemaDefinition.Extensions[extTfStateMigrations]; exists {
+		migrationsList, isList := migrations.([]interface{})
+		if !isList {
+			return nil, fmt.Errorf("%s must be a list of migrations", extTfStateMigrations)
+		}
+		for _, m := range migrationsList {
+			migration, err := o.createStateMigration(m)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %s", extTfStateMigrations, err)
+			}
+			stateMigrations.Migrations = append(stateMigrations.Migrations, migration)
+		}
+	}
+	if err := stateMigrations.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", extTfStateMigrations, err)
+	}
+	return stateMigrations, nil
+}
+
+func (o *SpecV2Resource) createStateMigration(m interface{}) (*specStateMigration, error) {
+	migration, isObject := m.(map[string]interface{})
+	if !isObject {
+		return nil, fmt.Errorf("migration '%v' is not an object", m)
+	}
+	version, isInt := o.getIntValue(migration["version"])
+	if !isInt {
+		return nil, fmt.Errorf("migration version '%v' is not a valid integer", migration["version"])
+	}
+	stateMigration := &specStateMigration{Version: version}
+	stateMigration.Action, _ = migration["action"].(string)
+	stateMigration.Attribute, _ = migration["attribute"].(string)
+	stateMigration.To, _ = migration["to"].(string)
+	stateMigration.From, _ = migration["from"].(string)
+	return stateMigration, nil
+}
+
+// getIntValue returns the value as an int if the value is a whole number. Note numbers in the OpenAPI document extensions
+// are decoded as float64
+func (o *SpecV2Resource) getIntValue(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		if float64(int(v)) == v {
+			return int(v), true
+		}
+	}
+	return 0, false
+}
+
 func (o *SpecV2Resource) getResourceTimeout(operation *spec.Operation) (*time.Duration, error) {
 	if operation == nil {
 		return nil, nil
@@ -804,3 +1003,18 @@ func getResourceOverrideHost(rootPathItemPost *spec.Operation) string {
 	}
 	return ""
 }
+
+// getExtensionStringSliceValue returns the values of the given extension which can be either a list of strings or a string
+// containing comma separated values; nil is returned if the extension is not present
+func getExtensionStringSliceValue(extensions spec.Extensions, key string) []string {
+	if values, exists := extensions.GetStringSlice(key); exists {
+		return values
+	}
+	var values []string
+	if value, exists := extensions.GetString(key); exists && value != "" {
+		for _, v := range strings.Split(value, ",") {
+			values = append(values, strings.TrimSpace(v))
+		}
+	}
+	return values
+}
@@ -374,6 +374,117 @@ func TestShouldIgnoreResource(t *testing.T) {
 	})
 }
 
+func TestGetDeprecationMessage(t *testing.T) {
+	Convey("Given a SpecV2Resource where none of the operations are deprecated", t, func() {
+		r := SpecV2Resource{
+			Path: "/v1/cdns",
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{},
+				},
+			},
+			InstancePathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Get: &spec.Operation{},
+				},
+			},
+		}
+		Convey("When GetDeprecationMessage is called", func() {
+			deprecationMessage := r.GetDeprecationMessage()
+			Convey("Then the deprecation message should be empty", func() {
+				So(deprecationMessage, ShouldBeEmpty)
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource where one of the operations is deprecated", t, func() {
+		r := SpecV2Resource{
+			Name: "cdns_v1",
+			Path: "/v1/cdns",
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{},
+				},
+			},
+			InstancePathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Delete: &spec.Operation{OperationProps: spec.OperationProps{Deprecated: true}},
+				},
+			},
+		}
+		Convey("When GetDeprecationMessage is called", func() {
+			deprecationMessage := r.GetDeprecationMessage()
+			Convey("Then the deprecation message should be the default one", func() {
+				So(deprecationMessage, ShouldEqual, "resource cdns_v1 is deprecated")
+			})
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource where the post operation is deprecated and has the %s extension", extTfDeprecationMessage), t, func() {
+		r := SpecV2Resource{
+			Path: "/v1/cdns",
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						OperationProps: spec.OperationProps{Deprecated: true},
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfDeprecationMessage: "use cdns_v2 instead",
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When GetDeprecationMessage is called", func() {
+			deprecationMessage := r.GetDeprecationMessage()
+			Convey("Then the deprecation message should be the one in the extension", func() {
+				So(deprecationMessage, ShouldEqual, "use cdns_v2 instead")
+			})
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource where the post operation is deprecated and the root path has the %s extension", extTfDeprecationMessage), t, func() {
+		r := SpecV2Resource{
+			Path: "/v1/cdns",
+			RootPathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfDeprecationMessage: "use cdns_v2 instead",
+					},
+				},
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{OperationProps: spec.OperationProps{Deprecated: true}},
+				},
+			},
+		}
+		Convey("When GetDeprecationMessage is called", func() {
+			deprecationMessage := r.GetDeprecationMessage()
+			Convey("Then the deprecation message should be the one in the extension", func() {
+				So(deprecationMessage, ShouldEqual, "use cdns_v2 instead")
+			})
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource where none of the operations are deprecated but the root path has the %s extension", extTfDeprecationMessage), t, func() {
+		r := SpecV2Resource{
+			Path: "/v1/cdns",
+			RootPathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfDeprecationMessage: "use cdns_v2 instead",
+					},
+				},
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{},
+				},
+			},
+		}
+		Convey("When GetDeprecationMessage is called", func() {
+			deprecationMessage := r.GetDeprecationMessage()
+			Convey("Then the deprecation message should be empty since the resource is not deprecated", func() {
+				So(deprecationMessage, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
 func TestBuildResourceName(t *testing.T) {
 
 	testCases := []struct {
@@ -1506,6 +1617,110 @@ func TestGetResourceSchema(t *testing.T) {
 	})
 }
 
+func TestGetResourceSchemaAttributeRelationships(t *testing.T) {
+	Convey("Given a SpecV2Resource containing properties with attribute relationships, including nested block properties", t, func() {
+		r := &SpecV2Resource{
+			Path: "/listeners",
+			SchemaDefinition: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"certificateArn": {
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"string"},
+							},
+							VendorExtensible: spec.VendorExtensible{
+								Extensions: spec.Extensions{
+									extTfConflictsWith: "certificate_body",
+									extTfExactlyOneOf:  "certificate_body",
+								},
+							},
+						},
+						"certificate_body": {
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"string"},
+							},
+						},
+						"rule": {
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"object"},
+								Properties: map[string]spec.Schema{
+									"port": {
+										SchemaProps: spec.SchemaProps{
+											Type: spec.StringOrArray{"integer"},
+										},
+										VendorExtensible: spec.VendorExtensible{
+											Extensions: spec.Extensions{
+												extTfRequiredWith: "protocol",
+											},
+										},
+									},
+									"protocol": {
+										SchemaProps: spec.SchemaProps{
+											Type: spec.StringOrArray{"string"},
+										},
+										VendorExtensible: spec.VendorExtensible{
+											Extensions: spec.Extensions{
+												extTfFieldName: "rule_protocol",
+											},
+										},
+									},
+								},
+							},
+							VendorExtensible: spec.VendorExtensible{
+								Extensions: spec.Extensions{
+									extTfComplexObjectType: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When GetResourceSchema is called", func() {
+			specSchemaDefinition, err := r.GetResourceSchema()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the attribute relationships should be resolved into terraform compliant attribute paths", func() {
+				certificateArn, _ := specSchemaDefinition.getProperty("certificateArn")
+				So(certificateArn.ConflictsWith, ShouldResemble, []string{"certificate_body"})
+				So(certificateArn.ExactlyOneOf, ShouldResemble, []string{"certificate_arn", "certificate_body"})
+				rule, _ := specSchemaDefinition.getProperty("rule")
+				port, _ := rule.SpecSchemaDefinition.getProperty("port")
+				So(port.RequiredWith, ShouldResemble, []string{"rule.0.rule_protocol"})
+			})
+		})
+	})
+
+	Convey("Given a SpecV2Resource containing a property with an attribute relationship referencing a non existing property", t, func() {
+		r := &SpecV2Resource{
+			Path: "/listeners",
+			SchemaDefinition: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"port": {
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"integer"},
+							},
+							VendorExtensible: spec.VendorExtensible{
+								Extensions: spec.Extensions{
+									extTfRequiredWith: "protocol",
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When GetResourceSchema is called", func() {
+			_, err := r.GetResourceSchema()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "failed to resolve the required with relationship of property 'port': property with name 'protocol' not existing in resource schema definition")
+			})
+		})
+	})
+}
+
 func TestGetSchemaDefinition(t *testing.T) {
 
 	Convey("Given a SpecV2Resource containing a root path", t, func() {
@@ -2388,6 +2603,215 @@ func TestCreateSchemaDefinitionProperty(t *testing.T) {
 			})
 		})
 
+		Convey("When createSchemaDefinitionProperty is called with an optional integer property that has a default value and the resource is configured to use the spec defaults", func() {
+			rWithSpecDefaults := SpecV2Resource{UseSpecDefaults: true}
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type:    spec.StringOrArray{"integer"},
+					Default: float64(8080),
+				},
+			}
+			schemaDefinitionProperty, err := rWithSpecDefaults.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the schemaDefinitionProperty should have the default value converted to int and should not be computed", func() {
+				So(schemaDefinitionProperty.Default, ShouldEqual, 8080)
+				So(schemaDefinitionProperty.Default, ShouldHaveSameTypeAs, 0)
+				So(schemaDefinitionProperty.Computed, ShouldBeFalse)
+				So(schemaDefinitionProperty.IsOptionalComputed(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a readOnly property that has a default value and the resource is configured to use the spec defaults", func() {
+			rWithSpecDefaults := SpecV2Resource{UseSpecDefaults: true}
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type:    spec.StringOrArray{"string"},
+					Default: "some value",
+				},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: true,
+				},
+			}
+			schemaDefinitionProperty, err := rWithSpecDefaults.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the schemaDefinitionProperty should still be computed since the value is computed by the API", func() {
+				So(schemaDefinitionProperty.Computed, ShouldBeTrue)
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with an optional integer property that has a default value that is not a valid integer and the resource is configured to use the spec defaults", func() {
+			rWithSpecDefaults := SpecV2Resource{UseSpecDefaults: true}
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type:    spec.StringOrArray{"integer"},
+					Default: 12.5,
+				},
+			}
+			_, err := rWithSpecDefaults.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "failed to process property 'propertyName': default value '12.5' is not a valid integer")
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that is deprecated and has the 'x-terraform-deprecation-message' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type:        spec.StringOrArray{"string"},
+					Description: "some description",
+				},
+				ExtraProps: map[string]interface{}{
+					"deprecated": true,
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfDeprecationMessage: "use new_property instead",
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schemaDefinitionProperty should be deprecated with the deprecation message", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.Description, ShouldEqual, "some description")
+				So(schemaDefinitionProperty.Deprecated, ShouldBeTrue)
+				So(schemaDefinitionProperty.DeprecationMessage, ShouldEqual, "use new_property instead")
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that is not deprecated", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				ExtraProps: map[string]interface{}{
+					"deprecated": false,
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schemaDefinitionProperty should not be deprecated", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.Deprecated, ShouldBeFalse)
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-write-only' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfWriteOnly: true,
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schemaDefinitionProperty should be write-only and sensitive", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.WriteOnly, ShouldBeTrue)
+				So(schemaDefinitionProperty.Sensitive, ShouldBeTrue)
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'writeOnly' attribute", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				ExtraProps: map[string]interface{}{
+					"writeOnly": true,
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schemaDefinitionProperty should be write-only and sensitive", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.WriteOnly, ShouldBeTrue)
+				So(schemaDefinitionProperty.Sensitive, ShouldBeTrue)
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that is readOnly and has the 'x-terraform-write-only' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: true,
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfWriteOnly: true,
+					},
+				},
+			}
+			_, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "failed to process property 'propertyName': a readOnly property cannot be writeOnly too")
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-diff-suppress' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfDiffSuppress: diffSuppressCaseInsensitive,
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schemaDefinitionProperty should be configured as expected", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.DiffSuppress, ShouldEqual, diffSuppressCaseInsensitive)
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-diff-suppress' extension with a non supported strategy", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfDiffSuppress: "non-supported",
+					},
+				},
+			}
+			_, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "failed to process property 'propertyName': x-terraform-diff-suppress value 'non-supported' not supported, please use one of the following: case-insensitive, cidr, json-equivalent, rfc3339, trim-space, url")
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the attribute relationship extensions", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfConflictsWith: []interface{}{"certificate_body", "certificate_chain"},
+						extTfRequiredWith:  "protocol",
+						extTfExactlyOneOf:  "certificate_body, certificate_chain",
+						extTfAtLeastOneOf:  []interface{}{"certificate_body"},
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schemaDefinitionProperty should be configured with the referenced properties", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.ConflictsWith, ShouldResemble, []string{"certificate_body", "certificate_chain"})
+				So(schemaDefinitionProperty.RequiredWith, ShouldResemble, []string{"protocol"})
+				So(schemaDefinitionProperty.ExactlyOneOf, ShouldResemble, []string{"certificate_body", "certificate_chain"})
+				So(schemaDefinitionProperty.AtLeastOneOf, ShouldResemble, []string{"certificate_body"})
+			})
+		})
+
 		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-field-status' extension", func() {
 			expectedIsStatusFieldValue := true
 			propertySchema := spec.Schema{
@@ -2408,6 +2832,45 @@ func TestCreateSchemaDefinitionProperty(t *testing.T) {
 			})
 		})
 
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has an example", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					Example: "some example",
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extExample: "some other example",
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schemaDefinitionProperty example should be the one from the example attribute", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.Example, ShouldEqual, "some example")
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-example' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"integer"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extExample: 1234,
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schemaDefinitionProperty example should be the one from the extension", func() {
+				So(err, ShouldBeNil)
+				So(schemaDefinitionProperty.Example, ShouldEqual, 1234)
+			})
+		})
+
 		Convey(fmt.Sprintf("When createSchemaDefinitionProperty is called with an optional property schema that has the %s extension (this means the property is optional-computed, and the value computed is not known at runtime)", extTfComputed), func() {
 			propertySchema := spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -2797,6 +3260,55 @@ func TestIsOptionalComputed(t *testing.T) {
 	})
 }
 
+func TestGetTerraformDefaultValue(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := &SpecV2Resource{}
+		Convey("When getTerraformDefaultValue method is called with default values that can be converted to the property type", func() {
+			testCases := []struct {
+				propertyType  schemaDefinitionPropertyType
+				value         interface{}
+				expectedValue interface{}
+			}{
+				{propertyType: TypeString, value: "some value", expectedValue: "some value"},
+				{propertyType: TypeString, value: float64(12), expectedValue: "12"},
+				{propertyType: TypeInt, value: float64(12), expectedValue: 12},
+				{propertyType: TypeInt, value: int64(12), expectedValue: 12},
+				{propertyType: TypeInt, value: "12", expectedValue: 12},
+				{propertyType: TypeFloat, value: 12.5, expectedValue: 12.5},
+				{propertyType: TypeFloat, value: 12, expectedValue: float64(12)},
+				{propertyType: TypeBool, value: true, expectedValue: true},
+				{propertyType: TypeBool, value: "false", expectedValue: false},
+			}
+			Convey("Then the values returned should be the expected ones", func() {
+				for _, tc := range testCases {
+					value, err := r.getTerraformDefaultValue(tc.propertyType, tc.value)
+					So(err, ShouldBeNil)
+					So(value, ShouldEqual, tc.expectedValue)
+					So(value, ShouldHaveSameTypeAs, tc.expectedValue)
+				}
+			})
+		})
+		Convey("When getTerraformDefaultValue method is called with default values that can not be converted to the property type", func() {
+			testCases := []struct {
+				propertyType  schemaDefinitionPropertyType
+				value         interface{}
+				expectedError string
+			}{
+				{propertyType: TypeInt, value: 12.5, expectedError: "default value '12.5' is not a valid integer"},
+				{propertyType: TypeFloat, value: "abc", expectedError: "default value 'abc' is not a valid number"},
+				{propertyType: TypeBool, value: float64(1), expectedError: "default value '1' is not a valid boolean"},
+				{propertyType: TypeString, value: []interface{}{"a"}, expectedError: "default value '[a]' is not a valid string"},
+			}
+			Convey("Then the errors returned should be the expected ones", func() {
+				for _, tc := range testCases {
+					_, err := r.getTerraformDefaultValue(tc.propertyType, tc.value)
+					So(err.Error(), ShouldEqual, tc.expectedError)
+				}
+			})
+		})
+	})
+}
+
 func TestIsArrayItemPrimitiveType(t *testing.T) {
 	Convey("Given a SpecV2Resource", t, func() {
 		r := &SpecV2Resource{}
@@ -3916,6 +4428,84 @@ func TestGetTimeouts(t *testing.T) {
 	})
 }
 
+func TestGetStateMigrations(t *testing.T) {
+	Convey("Given a SpecV2Resource with a schema definition that is not versioned", t, func() {
+		r := SpecV2Resource{}
+		Convey("When getStateMigrations method is called", func() {
+			stateMigrations, err := r.getStateMigrations()
+			Convey("Then the error returned should be nil and the state migrations should be nil", func() {
+				So(err, ShouldBeNil)
+				So(stateMigrations, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource with a schema definition that has the schema version and state migrations extensions", t, func() {
+		r := SpecV2Resource{
+			SchemaDefinition: spec.Schema{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfSchemaVersion: float64(2),
+						extTfStateMigrations: []interface{}{
+							map[string]interface{}{"version": float64(0), "action": "rename", "attribute": "name", "to": "label"},
+							map[string]interface{}{"version": float64(1), "action": "convert-type", "attribute": "port", "from": "string", "to": "integer"},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getStateMigrations method is called", func() {
+			stateMigrations, err := r.getStateMigrations()
+			Convey("Then the error returned should be nil and the state migrations should be the expected ones", func() {
+				So(err, ShouldBeNil)
+				So(stateMigrations, ShouldResemble, &specStateMigrations{
+					SchemaVersion: 2,
+					Migrations: []*specStateMigration{
+						{Version: 0, Action: stateMigrationRename, Attribute: "name", To: "label"},
+						{Version: 1, Action: stateMigrationConvertType, Attribute: "port", From: "string", To: "integer"},
+					},
+				})
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource with a schema definition that has a schema version that is not an integer", t, func() {
+		r := SpecV2Resource{
+			SchemaDefinition: spec.Schema{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfSchemaVersion: "two",
+					},
+				},
+			},
+		}
+		Convey("When getStateMigrations method is called", func() {
+			_, err := r.getStateMigrations()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "x-terraform-schema-version value 'two' is not a valid integer")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource with a schema definition that has a state migration for a version that is not lower than the schema version", t, func() {
+		r := SpecV2Resource{
+			SchemaDefinition: spec.Schema{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfSchemaVersion: 1,
+						extTfStateMigrations: []interface{}{
+							map[string]interface{}{"version": 1, "action": "rename", "attribute": "name", "to": "label"},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getStateMigrations method is called", func() {
+			_, err := r.getStateMigrations()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "invalid x-terraform-state-migrations: state migration version 1 must be lower than the schema version 1")
+			})
+		})
+	})
+}
+
 func TestGetResourceTimeout(t *testing.T) {
 	Convey("Given a SpecV2Resource", t, func() {
 		r := SpecV2Resource{}
@@ -4256,3 +4846,48 @@ func TestGetResourceOverrideHost(t *testing.T) {
 		})
 	})
 }
+
+func TestGetExtensionStringSliceValue(t *testing.T) {
+	Convey("Given a set of extensions", t, func() {
+		extensions := spec.Extensions{}
+		extensions.Add(extTfConflictsWith, []interface{}{"certificate_body", "certificate_chain"})
+		extensions.Add(extTfRequiredWith, "protocol, port")
+		Convey("When getExtensionStringSliceValue is called with an extension containing a list of strings", func() {
+			values := getExtensionStringSliceValue(extensions, extTfConflictsWith)
+			Convey("Then the values returned should match the list items", func() {
+				So(values, ShouldResemble, []string{"certificate_body", "certificate_chain"})
+			})
+		})
+		Convey("When getExtensionStringSliceValue is called with an extension containing comma separated values", func() {
+			values := getExtensionStringSliceValue(extensions, extTfRequiredWith)
+			Convey("Then the values returned should match the comma separated values without spaces", func() {
+				So(values, ShouldResemble, []string{"protocol", "port"})
+			})
+		})
+		Convey("When getExtensionStringSliceValue is called with a NON existing extension", func() {
+			values := getExtensionStringSliceValue(extensions, extTfExactlyOneOf)
+			Convey("Then the values returned should be nil", func() {
+				So(values, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestCreateResourceOperationIdempotencyKey(t *testing.T) {
+	operation := &spec.Operation{
+		VendorExtensible: spec.VendorExtensible{
+			Extensions: spec.Extensions{extTfIdempotencyKey: true},
+		},
+		OperationProps: spec.OperationProps{
+			Parameters: []spec.Parameter{
+				{ParamProps: spec.ParamProps{Name: "X-Request-ID", In: "header"}},
+				{ParamProps: spec.ParamProps{Name: "Idempotency-Key", In: "header", Required: true}},
+			},
+			Responses: &spec.Responses{},
+		},
+	}
+	r := SpecV2Resource{}
+	resourceOperation := r.createResourceOperation(operation)
+	assert.Equal(t, "Idempotency-Key", resourceOperation.IdempotencyKeyHeader)
+	assert.Equal(t, SpecHeaderParameters{SpecHeaderParam{Name: "X-Request-ID"}}, resourceOperation.HeaderParameters)
+}
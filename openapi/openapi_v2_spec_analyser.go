@@ -15,6 +15,7 @@ import (
 )
 
 const extTfResourceRegionsFmt = "x-terraform-resource-regions-%s"
+const extTfProviderUseSpecDefaults = "x-terraform-provider-use-spec-defaults"
 
 // specV2Analyser defines an SpecAnalyser implementation for OpenAPI v2 specification
 // Forcing creation of this object via constructor so proper input validation is performed before creating the struct
@@ -27,6 +28,12 @@ type specV2Analyser struct {
 // newSpecAnalyserV2 creates an instance of specV2Analyser which implements the SpecAnalyser interface
 // This implementation provides an analyser that understands an OpenAPI v2 document
 func newSpecAnalyserV2(openAPIDocumentFilename string) (*specV2Analyser, error) {
+	return newSpecAnalyserV2WithOverrides(openAPIDocumentFilename, nil)
+}
+
+// newSpecAnalyserV2WithOverrides loads the OpenAPI document and applies the overrides provided (if any) before returning
+// the specV2Analyser
+func newSpecAnalyserV2WithOverrides(openAPIDocumentFilename string, overrides *ServiceOverridesV1) (*specV2Analyser, error) {
 	if openAPIDocumentFilename == "" {
 		return nil, errors.New("open api document filename argument empty, please provide the url of the OpenAPI document")
 	}
@@ -38,10 +45,14 @@ func newSpecAnalyserV2(openAPIDocumentFilename string) (*specV2Analyser, error)
 	if err != nil {
 		return nil, fmt.Errorf("failed to expand the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
 	}
-	return &specV2Analyser{
+	specAnalyser := &specV2Analyser{
 		d:                  apiSpec,
 		openAPIDocumentURL: openAPIDocumentFilename,
-	}, nil
+	}
+	if err := specAnalyser.applyOverrides(overrides); err != nil {
+		return nil, fmt.Errorf("failed to apply the overrides to the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
+	}
+	return specAnalyser, nil
 }
 
 func (specAnalyser *specV2Analyser) createMultiRegionResources(regions []string, resourceRootPath string, resourceRoot, pathItem spec.PathItem, resourcePayloadSchemaDef *spec.Schema) ([]SpecResource, error) {
@@ -51,6 +62,7 @@ func (specAnalyser *specV2Analyser) createMultiRegionResources(regions []string,
 		if err != nil {
 			return nil, fmt.Errorf("failed to create a resource with region: %s", err)
 		}
+		r.UseSpecDefaults = specAnalyser.useSpecDefaults()
 		log.Printf("[INFO] multi region resource name = %s, region = '%s'", r.GetResourceName(), regionName)
 		resources = append(resources, r)
 	}
@@ -114,6 +126,8 @@ func (specAnalyser *specV2Analyser) GetTerraformCompliantResources() ([]SpecReso
 			continue
 		}
 
+		r.UseSpecDefaults = specAnalyser.useSpecDefaults()
+
 		err = specAnalyser.validateSubResourceTerraformCompliance(*r)
 		if err != nil {
 			log.Printf("[WARN] ignoring subresource name='%s' with rootPath='%s' due to not meeting validation requirements: %s", r.GetResourceName(), resourceRootPath, err)
@@ -127,6 +141,15 @@ func (specAnalyser *specV2Analyser) GetTerraformCompliantResources() ([]SpecReso
 	return resources, nil
 }
 
+// useSpecDefaults returns true if the OpenAPI document has the root level 'x-terraform-provider-use-spec-defaults' extension
+// enabled, meaning that the default values documented for the properties should be used as the terraform default values
+func (specAnalyser *specV2Analyser) useSpecDefaults() bool {
+	if enabled, ok := specAnalyser.d.Spec().Extensions.GetBool(extTfProviderUseSpecDefaults); ok && enabled {
+		return true
+	}
+	return false
+}
+
 func (specAnalyser *specV2Analyser) validateSubResourceTerraformCompliance(r SpecV2Resource) error {
 	parentResourceInfo := r.GetParentResourceInfo()
 	if parentResourceInfo != nil {
@@ -403,7 +426,69 @@ func (specAnalyser *specV2Analyser) validateResourceSchemaDefWithOptions(schema
 }
 
 func (specAnalyser *specV2Analyser) validateResourceSchemaDefinition(schema *spec.Schema) error {
-	return specAnalyser.validateResourceSchemaDefWithOptions(schema, false)
+	if err := specAnalyser.validateResourceSchemaDefWithOptions(schema, false); err != nil {
+		return err
+	}
+	return specAnalyser.validateAttributeRelationships(schema)
+}
+
+// validateAttributeRelationships validates that the properties referenced by the attribute relationship extensions
+// (e,g: 'x-terraform-conflicts-with') are existing sibling properties that can only be set by the user. The validation
+// is also performed for the properties of nested objects
+func (specAnalyser *specV2Analyser) validateAttributeRelationships(schema *spec.Schema) error {
+	for propertyName, property := range schema.Properties {
+		for _, extension := range []string{extTfConflictsWith, extTfRequiredWith, extTfExactlyOneOf, extTfAtLeastOneOf} {
+			references := getExtensionStringSliceValue(property.Extensions, extension)
+			if len(references) == 0 {
+				continue
+			}
+			if !specAnalyser.isUserConfigurableOnlyProperty(schema, propertyName, property) {
+				return fmt.Errorf("property '%s' has the '%s' extension but only optional properties that are not readOnly, computed nor have a default value can have relationships with other properties", propertyName, extension)
+			}
+			for _, reference := range references {
+				referencedProperty, exists := schema.Properties[reference]
+				if !exists {
+					return fmt.Errorf("property '%s' has the '%s' extension referencing a non existing property '%s'", propertyName, extension, reference)
+				}
+				if !specAnalyser.isUserConfigurableOnlyProperty(schema, reference, referencedProperty) {
+					return fmt.Errorf("property '%s' has the '%s' extension referencing the property '%s' which is either required, readOnly, computed or has a default value", propertyName, extension, reference)
+				}
+			}
+		}
+		if len(property.Properties) > 0 {
+			if err := specAnalyser.validateAttributeRelationships(&property); err != nil {
+				return err
+			}
+		}
+		if property.Items != nil && property.Items.Schema != nil {
+			if err := specAnalyser.validateAttributeRelationships(property.Items.Schema); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isUserConfigurableOnlyProperty returns true if the property is optional and its value can only come from the user
+// configuration; that is, the property is not readOnly, computed (x-terraform-computed) and does not have a default value.
+// Otherwise, the value in the plan may come from the state or the default and the relationships could not be validated
+func (specAnalyser *specV2Analyser) isUserConfigurableOnlyProperty(schema *spec.Schema, propertyName string, property spec.Schema) bool {
+	if property.ReadOnly || property.Default != nil || specAnalyser.isRequiredProperty(schema, propertyName) {
+		return false
+	}
+	if computed, ok := property.Extensions.GetBool(extTfComputed); ok && computed {
+		return false
+	}
+	return true
+}
+
+func (specAnalyser *specV2Analyser) isRequiredProperty(schema *spec.Schema, propertyName string) bool {
+	for _, requiredProperty := range schema.Required {
+		if requiredProperty == propertyName {
+			return true
+		}
+	}
+	return false
 }
 
 // postIsPresent checks if the given resource has a POST implementation returning true if the path is found
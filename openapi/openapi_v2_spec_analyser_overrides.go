@@ -0,0 +1,206 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// overridableResource contains the paths of a resource found in the OpenAPI document that overrides can be applied to
+type overridableResource struct {
+	name         string
+	rootPath     string
+	instancePath string
+}
+
+// applyOverrides applies the overrides to the loaded OpenAPI document by adding the corresponding 'x-terraform-*' extensions.
+// This must be called before the document is analysed so all the analyser code paths see the overrides. Overrides that do
+// not match any resource or property in the document are logged and ignored
+func (specAnalyser *specV2Analyser) applyOverrides(overrides *ServiceOverridesV1) error {
+	if overrides == nil || len(overrides.Resources) == 0 {
+		return nil
+	}
+	if err := overrides.Validate(); err != nil {
+		return err
+	}
+	resources := specAnalyser.getOverridableResources()
+	for _, resourceOverride := range overrides.Resources {
+		r := findOverridableResource(resources, resourceOverride)
+		if r == nil {
+			log.Printf("[WARN] ignoring override for resource [path='%s', resource='%s']: resource not found in the OpenAPI document", resourceOverride.Path, resourceOverride.Resource)
+			continue
+		}
+		if err := specAnalyser.applyResourceOverride(*r, resourceOverride); err != nil {
+			return fmt.Errorf("failed to apply override for resource '%s': %s", r.rootPath, err)
+		}
+		log.Printf("[INFO] applied override for resource [name='%s', rootPath='%s']", r.name, r.rootPath)
+	}
+	return nil
+}
+
+// getOverridableResources returns the resources found in the OpenAPI document, sorted by root path
+func (specAnalyser *specV2Analyser) getOverridableResources() []overridableResource {
+	var resources []overridableResource
+	paths := specAnalyser.d.Spec().Paths.Paths
+	for resourcePath, pathItem := range paths {
+		resourceRootPath, resourceRoot, resourcePayloadSchemaDef, err := specAnalyser.isEndPointFullyTerraformResourceCompliant(resourcePath)
+		if err != nil {
+			continue
+		}
+		r, err := newSpecV2Resource(resourceRootPath, *resourcePayloadSchemaDef, *resourceRoot, pathItem, specAnalyser.d.Spec().Definitions, paths)
+		if err != nil {
+			continue
+		}
+		resources = append(resources, overridableResource{name: r.GetResourceName(), rootPath: resourceRootPath, instancePath: resourcePath})
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].rootPath < resources[j].rootPath })
+	return resources
+}
+
+func findOverridableResource(resources []overridableResource, resourceOverride ServiceResourceOverrideV1) *overridableResource {
+	for _, r := range resources {
+		if resourceOverride.Path != "" && strings.TrimSuffix(r.rootPath, "/") == strings.TrimSuffix(resourceOverride.Path, "/") {
+			return &r
+		}
+		if resourceOverride.Resource != "" && r.name == resourceOverride.Resource {
+			return &r
+		}
+	}
+	return nil
+}
+
+func (specAnalyser *specV2Analyser) applyResourceOverride(r overridableResource, resourceOverride ServiceResourceOverrideV1) error {
+	paths := specAnalyser.d.Spec().Paths.Paths
+	rootPathItem := paths[r.rootPath]
+	instancePathItem := paths[r.instancePath]
+
+	if resourceOverride.Name != "" {
+		rootPathItem.AddExtension(extTfResourceName, resourceOverride.Name)
+	}
+	if resourceOverride.Exclude {
+		rootPathItem.Post.AddExtension(extTfExcludeResource, true)
+	}
+
+	operations := map[string]*spec.Operation{
+		overrideOperationCreate: rootPathItem.Post,
+		overrideOperationRead:   instancePathItem.Get,
+		overrideOperationUpdate: instancePathItem.Put,
+		overrideOperationDelete: instancePathItem.Delete,
+	}
+	for operationName, timeout := range resourceOverride.Timeouts {
+		operation := operations[operationName]
+		if operation == nil {
+			return fmt.Errorf("timeout configured for operation '%s' but the resource does not support it", operationName)
+		}
+		operation.AddExtension(extTfResourceTimeout, timeout)
+	}
+	for operationName, polling := range resourceOverride.Polling {
+		operation := operations[operationName]
+		if operation == nil {
+			return fmt.Errorf("polling configured for operation '%s' but the resource does not support it", operationName)
+		}
+		if err := applyPollingOverride(operation, polling); err != nil {
+			return fmt.Errorf("polling configured for the response code %d of operation '%s' but %s", polling.ResponseCode, operationName, err)
+		}
+	}
+
+	for propertyName, propertyOverride := range resourceOverride.Properties {
+		found := false
+		for _, schema := range getOverridableResourceSchemas(rootPathItem, instancePathItem) {
+			if applyPropertyOverride(schema, strings.Split(propertyName, "."), propertyOverride) {
+				found = true
+			}
+		}
+		if !found {
+			log.Printf("[WARN] ignoring override for property '%s' of resource '%s': property not found", propertyName, r.rootPath)
+		}
+	}
+
+	// PathItem values are stored by value so the updated copies need to be stored back
+	paths[r.rootPath] = rootPathItem
+	paths[r.instancePath] = instancePathItem
+	return nil
+}
+
+// applyPollingOverride enables polling on the operation's response matching the override response code. The response must
+// be documented by the operation, otherwise the provider would not know the schema of the response received while polling
+func applyPollingOverride(operation *spec.Operation, polling ServiceResourcePollingOverrideV1) error {
+	if operation.Responses == nil {
+		return fmt.Errorf("the operation does not document it")
+	}
+	response, exists := operation.Responses.StatusCodeResponses[polling.ResponseCode]
+	if !exists {
+		return fmt.Errorf("the operation does not document it")
+	}
+	response.AddExtension(extTfResourcePollEnabled, true)
+	response.AddExtension(extTfResourcePollTargetStatuses, strings.Join(polling.TargetStatuses, ","))
+	if len(polling.PendingStatuses) > 0 {
+		response.AddExtension(extTfResourcePollPendingStatuses, strings.Join(polling.PendingStatuses, ","))
+	}
+	operation.Responses.StatusCodeResponses[polling.ResponseCode] = response
+	return nil
+}
+
+// getOverridableResourceSchemas returns the schemas describing the resource: the request body schemas and the successful
+// response schemas (including the items of list responses) of the resource operations
+func getOverridableResourceSchemas(rootPathItem, instancePathItem spec.PathItem) []*spec.Schema {
+	var schemas []*spec.Schema
+	for _, operation := range []*spec.Operation{rootPathItem.Post, rootPathItem.Get, instancePathItem.Get, instancePathItem.Put} {
+		if operation == nil {
+			continue
+		}
+		for _, parameter := range operation.Parameters {
+			if parameter.In == "body" && parameter.Schema != nil {
+				schemas = append(schemas, parameter.Schema)
+			}
+		}
+		if operation.Responses == nil {
+			continue
+		}
+		for _, statusCode := range []int{http.StatusOK, http.StatusCreated, http.StatusAccepted} {
+			if response, exists := operation.Responses.StatusCodeResponses[statusCode]; exists && response.Schema != nil {
+				schemas = append(schemas, response.Schema)
+				if response.Schema.Items != nil && response.Schema.Items.Schema != nil {
+					schemas = append(schemas, response.Schema.Items.Schema)
+				}
+			}
+		}
+	}
+	return schemas
+}
+
+// applyPropertyOverride adds the extensions defined in the property override to the property found following the path
+// within the schema. Nested properties in arrays of objects are looked up in the items schema. Returns true if the property was found
+func applyPropertyOverride(schema *spec.Schema, propertyPath []string, propertyOverride ServicePropertyOverrideV1) bool {
+	if schema == nil {
+		return false
+	}
+	property, exists := schema.Properties[propertyPath[0]]
+	if !exists {
+		return false
+	}
+	found := true
+	if len(propertyPath) == 1 {
+		addBoolExtensionIfSet(&property, extTfImmutable, propertyOverride.Immutable)
+		addBoolExtensionIfSet(&property, extTfSensitive, propertyOverride.Sensitive)
+		addBoolExtensionIfSet(&property, extTfComputed, propertyOverride.Computed)
+		addBoolExtensionIfSet(&property, extTfForceNew, propertyOverride.ForceNew)
+	} else if property.Items != nil && property.Items.Schema != nil {
+		found = applyPropertyOverride(property.Items.Schema, propertyPath[1:], propertyOverride)
+	} else {
+		found = applyPropertyOverride(&property, propertyPath[1:], propertyOverride)
+	}
+	// Properties are stored by value so the updated copy needs to be stored back
+	schema.Properties[propertyPath[0]] = property
+	return found
+}
+
+func addBoolExtensionIfSet(schema *spec.Schema, extension string, value *bool) {
+	if value != nil {
+		schema.AddExtension(extension, *value)
+	}
+}
@@ -0,0 +1,234 @@
+package openapi
+
+import (
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const overridesSwaggerContent = `swagger: "2.0"
+host: 127.0.0.1
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+        202:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+    put:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+    delete:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        204:
+          description: "successful operation, no content is returned"
+definitions:
+  ContentDeliveryNetworkV1:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"
+      password:
+        type: "string"
+      settings:
+        type: "object"
+        properties:
+          mode:
+            type: "string"`
+
+func TestNewSpecAnalyserV2WithOverrides(t *testing.T) {
+	trueValue := true
+	Convey("Given a swagger file and overrides for the cdn resource referred to by its path", t, func() {
+		swaggerFile := initAPISpecFile(overridesSwaggerContent)
+		defer os.Remove(swaggerFile.Name())
+		overrides := &ServiceOverridesV1{
+			Resources: []ServiceResourceOverrideV1{
+				{
+					Path: "/v1/cdns",
+					Name: "content_delivery_network",
+					Timeouts: map[string]string{
+						overrideOperationCreate: "10m",
+						overrideOperationDelete: "5m",
+					},
+					Polling: map[string]ServiceResourcePollingOverrideV1{
+						overrideOperationCreate: {ResponseCode: http.StatusAccepted, TargetStatuses: []string{"deployed"}, PendingStatuses: []string{"deploying", "pending"}},
+						overrideOperationDelete: {ResponseCode: http.StatusNoContent, TargetStatuses: []string{"destroyed"}},
+					},
+					Properties: map[string]ServicePropertyOverrideV1{
+						"label":         {Immutable: &trueValue, ForceNew: &trueValue},
+						"password":      {Sensitive: &trueValue},
+						"settings.mode": {Computed: &trueValue},
+						"nonExisting":   {Sensitive: &trueValue},
+					},
+				},
+			},
+		}
+		Convey("When newSpecAnalyserV2WithOverrides is called and the resources are retrieved", func() {
+			specAnalyser, err := newSpecAnalyserV2WithOverrides(swaggerFile.Name(), overrides)
+			So(err, ShouldBeNil)
+			resources, err := specAnalyser.GetTerraformCompliantResources()
+			So(err, ShouldBeNil)
+			So(resources, ShouldHaveLength, 1)
+			resource := resources[0]
+			Convey("Then the resource should have the overridden name (including the version)", func() {
+				So(resource.GetResourceName(), ShouldEqual, "content_delivery_network_v1")
+				So(resource.ShouldIgnoreResource(), ShouldBeFalse)
+			})
+			Convey("And the resource should have the overridden timeouts", func() {
+				timeouts, err := resource.getTimeouts()
+				So(err, ShouldBeNil)
+				So(*timeouts.Post, ShouldEqual, 10*time.Minute)
+				So(*timeouts.Delete, ShouldEqual, 5*time.Minute)
+				So(timeouts.Get, ShouldBeNil)
+				So(timeouts.Put, ShouldBeNil)
+			})
+			Convey("And the resource operations should have polling enabled for the overridden responses", func() {
+				operations := resource.getResourceOperations()
+				postResponse := operations.Post.responses.getResponse(http.StatusAccepted)
+				So(postResponse.isPollingEnabled, ShouldBeTrue)
+				So(postResponse.pollTargetStatuses, ShouldResemble, []string{"deployed"})
+				So(postResponse.pollPendingStatuses, ShouldResemble, []string{"deploying", "pending"})
+				deleteResponse := operations.Delete.responses.getResponse(http.StatusNoContent)
+				So(deleteResponse.isPollingEnabled, ShouldBeTrue)
+				So(deleteResponse.pollTargetStatuses, ShouldResemble, []string{"destroyed"})
+			})
+			Convey("And the resource schema properties should have the overridden configuration", func() {
+				resourceSchema, err := resource.GetResourceSchema()
+				So(err, ShouldBeNil)
+				label, _ := resourceSchema.getProperty("label")
+				So(label.Immutable, ShouldBeTrue)
+				So(label.ForceNew, ShouldBeTrue)
+				password, _ := resourceSchema.getProperty("password")
+				So(password.Sensitive, ShouldBeTrue)
+				So(password.Immutable, ShouldBeFalse)
+				settings, _ := resourceSchema.getProperty("settings")
+				mode, _ := settings.SpecSchemaDefinition.getProperty("mode")
+				So(mode.Computed, ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a swagger file and overrides excluding the cdn resource referred to by its name", t, func() {
+		swaggerFile := initAPISpecFile(overridesSwaggerContent)
+		defer os.Remove(swaggerFile.Name())
+		overrides := &ServiceOverridesV1{
+			Resources: []ServiceResourceOverrideV1{
+				{Resource: "cdns_v1", Exclude: true},
+				{Resource: "nonExisting", Exclude: true},
+			},
+		}
+		Convey("When newSpecAnalyserV2WithOverrides is called and the resources are retrieved", func() {
+			specAnalyser, err := newSpecAnalyserV2WithOverrides(swaggerFile.Name(), overrides)
+			So(err, ShouldBeNil)
+			resources, err := specAnalyser.GetTerraformCompliantResources()
+			So(err, ShouldBeNil)
+			Convey("Then the resource should be ignored", func() {
+				So(resources, ShouldHaveLength, 1)
+				So(resources[0].ShouldIgnoreResource(), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a swagger file and a list of overrides that are not valid", t, func() {
+		swaggerFile := initAPISpecFile(overridesSwaggerContent)
+		defer os.Remove(swaggerFile.Name())
+		testCases := []struct {
+			name          string
+			override      ServiceResourceOverrideV1
+			expectedError string
+		}{
+			{
+				name:          "override missing the resource path and name",
+				override:      ServiceResourceOverrideV1{Exclude: true},
+				expectedError: "resource override 0 not valid: either path or resource must be provided",
+			},
+			{
+				name:          "override with both resource path and name",
+				override:      ServiceResourceOverrideV1{Path: "/v1/cdns", Resource: "cdns_v1"},
+				expectedError: "resource override 0 not valid: path and resource are mutually exclusive",
+			},
+			{
+				name:          "override with a timeout for a non supported operation",
+				override:      ServiceResourceOverrideV1{Path: "/v1/cdns", Timeouts: map[string]string{"list": "1m"}},
+				expectedError: "resource override 0 not valid: timeout operation 'list' not supported, supported operations are: create, read, update, delete",
+			},
+			{
+				name:          "override with a wrong timeout",
+				override:      ServiceResourceOverrideV1{Path: "/v1/cdns", Timeouts: map[string]string{"create": "1 minute"}},
+				expectedError: "resource override 0 not valid: timeout '1 minute' for operation 'create' not valid: the value must be formatted either in seconds (s), minutes (m) or hours (h)",
+			},
+			{
+				name:          "override with polling for a non supported operation",
+				override:      ServiceResourceOverrideV1{Path: "/v1/cdns", Polling: map[string]ServiceResourcePollingOverrideV1{"read": {ResponseCode: 202, TargetStatuses: []string{"deployed"}}}},
+				expectedError: "resource override 0 not valid: polling operation 'read' not supported, supported operations are: create, update, delete",
+			},
+			{
+				name:          "override with polling missing the response code",
+				override:      ServiceResourceOverrideV1{Path: "/v1/cdns", Polling: map[string]ServiceResourcePollingOverrideV1{"create": {TargetStatuses: []string{"deployed"}}}},
+				expectedError: "resource override 0 not valid: polling for operation 'create' is missing the response_code",
+			},
+			{
+				name:          "override with polling missing the target statuses",
+				override:      ServiceResourceOverrideV1{Path: "/v1/cdns", Polling: map[string]ServiceResourcePollingOverrideV1{"create": {ResponseCode: 202}}},
+				expectedError: "resource override 0 not valid: polling for operation 'create' is missing the target_statuses",
+			},
+			{
+				name:          "override with polling for a response code not documented by the operation",
+				override:      ServiceResourceOverrideV1{Path: "/v1/cdns", Polling: map[string]ServiceResourcePollingOverrideV1{"update": {ResponseCode: 202, TargetStatuses: []string{"deployed"}}}},
+				expectedError: "failed to apply override for resource '/v1/cdns': polling configured for the response code 202 of operation 'update' but the operation does not document it",
+			},
+		}
+		for _, tc := range testCases {
+			Convey("When newSpecAnalyserV2WithOverrides is called with an "+tc.name, func() {
+				_, err := newSpecAnalyserV2WithOverrides(swaggerFile.Name(), &ServiceOverridesV1{Resources: []ServiceResourceOverrideV1{tc.override}})
+				Convey("Then the error returned should be the expected one", func() {
+					So(err, ShouldNotBeNil)
+					So(err.Error(), ShouldEqual, "failed to apply the overrides to the OpenAPI document from '"+swaggerFile.Name()+"' - error = "+tc.expectedError)
+				})
+			})
+		}
+	})
+}
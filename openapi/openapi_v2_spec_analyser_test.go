@@ -1061,6 +1061,36 @@ func TestGetAPIBackendConfiguration(t *testing.T) {
 	})
 }
 
+func TestUseSpecDefaults(t *testing.T) {
+	Convey("Given a specV2Analyser loaded with a swagger file that has the root level 'x-terraform-provider-use-spec-defaults' extension enabled", t, func() {
+		var swaggerJSON = `
+{
+   "swagger":"2.0",
+   "x-terraform-provider-use-spec-defaults": true
+}`
+		a := initAPISpecAnalyser(swaggerJSON)
+		Convey("When useSpecDefaults method is called", func() {
+			useSpecDefaults := a.useSpecDefaults()
+			Convey("Then the result returned should be true", func() {
+				So(useSpecDefaults, ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a specV2Analyser loaded with a swagger file that does not have the root level 'x-terraform-provider-use-spec-defaults' extension", t, func() {
+		var swaggerJSON = `
+{
+   "swagger":"2.0"
+}`
+		a := initAPISpecAnalyser(swaggerJSON)
+		Convey("When useSpecDefaults method is called", func() {
+			useSpecDefaults := a.useSpecDefaults()
+			Convey("Then the result returned should be false", func() {
+				So(useSpecDefaults, ShouldBeFalse)
+			})
+		})
+	})
+}
+
 func TestIsMultiRegionResource(t *testing.T) {
 	Convey("Given a specV2Analyser and a resource root has a POST operation containing the x-terraform-resource-host with a parametrized host containing region variable", t, func() {
 		serviceProviderName := "serviceProviderName"
@@ -1682,6 +1712,83 @@ func TestValidateResourceSchemaDefWithOptions(t *testing.T) {
 	})
 }
 
+func TestValidateAttributeRelationships_SpecAnalyser(t *testing.T) {
+	relationshipSchema := func(extension string, references interface{}, required ...string) *spec.Schema {
+		return &spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Required: required,
+				Properties: map[string]spec.Schema{
+					"certificate_arn": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extension: references,
+							},
+						},
+					},
+					"certificate_body": {},
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Default: 443,
+						},
+					},
+					"region": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfComputed: true,
+							},
+						},
+					},
+					"status": {
+						SwaggerSchemaProps: spec.SwaggerSchemaProps{
+							ReadOnly: true,
+						},
+					},
+				},
+			},
+		}
+	}
+	testCases := []struct {
+		name          string
+		schema        *spec.Schema
+		expectedError string
+	}{
+		{name: "conflicts with an existing optional property", schema: relationshipSchema(extTfConflictsWith, "certificate_body")},
+		{name: "exactly one of existing optional properties", schema: relationshipSchema(extTfExactlyOneOf, []interface{}{"certificate_body"})},
+		{name: "required with a non existing property", schema: relationshipSchema(extTfRequiredWith, "protocol"), expectedError: "property 'certificate_arn' has the 'x-terraform-required-with' extension referencing a non existing property 'protocol'"},
+		{name: "at least one of a readOnly property", schema: relationshipSchema(extTfAtLeastOneOf, "status"), expectedError: "property 'certificate_arn' has the 'x-terraform-at-least-one-of' extension referencing the property 'status' which is either required, readOnly, computed or has a default value"},
+		{name: "required with a property with a default value", schema: relationshipSchema(extTfRequiredWith, "port"), expectedError: "property 'certificate_arn' has the 'x-terraform-required-with' extension referencing the property 'port' which is either required, readOnly, computed or has a default value"},
+		{name: "exactly one of a computed property", schema: relationshipSchema(extTfExactlyOneOf, "region"), expectedError: "property 'certificate_arn' has the 'x-terraform-exactly-one-of' extension referencing the property 'region' which is either required, readOnly, computed or has a default value"},
+		{name: "conflicts with a required property", schema: relationshipSchema(extTfConflictsWith, "certificate_body", "certificate_body"), expectedError: "property 'certificate_arn' has the 'x-terraform-conflicts-with' extension referencing the property 'certificate_body' which is either required, readOnly, computed or has a default value"},
+		{name: "required property with relationships", schema: relationshipSchema(extTfConflictsWith, "certificate_body", "certificate_arn"), expectedError: "property 'certificate_arn' has the 'x-terraform-conflicts-with' extension but only optional properties that are not readOnly, computed nor have a default value can have relationships with other properties"},
+		{
+			name: "nested object property conflicting with a non existing property",
+			schema: &spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"listeners": {
+							SchemaProps: spec.SchemaProps{
+								Items: &spec.SchemaOrArray{
+									Schema: relationshipSchema(extTfConflictsWith, "certificate_chain"),
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedError: "property 'certificate_arn' has the 'x-terraform-conflicts-with' extension referencing a non existing property 'certificate_chain'",
+		},
+	}
+	for _, tc := range testCases {
+		a := specV2Analyser{}
+		err := a.validateAttributeRelationships(tc.schema)
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
+
 func TestValidateRootPath(t *testing.T) {
 	Convey("Given an specV2Analyser with a terraform compliant root path (and the schema has already been expanded)", t, func() {
 		swaggerContent := `swagger: "2.0"
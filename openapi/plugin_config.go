@@ -2,6 +2,7 @@ package openapi
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
 	"github.com/dikhan/terraform-provider-openapi/openapi/version"
@@ -32,6 +33,8 @@ type PluginConfiguration struct {
 	// the former takes preference. This allows the user to override the url specified in the configuration file with
 	// the value provided in the OTF_VAR_<provider_name>_SWAGGER_URL
 	Configuration io.Reader
+	// remote is true if the Configuration was fetched from a remote URL, in which case file interpolations are not allowed
+	remote bool
 }
 
 // NewPluginConfiguration creates a new PluginConfiguration
@@ -41,7 +44,19 @@ func NewPluginConfiguration(providerName string) (*PluginConfiguration, error) {
 	if err != nil {
 		return nil, err
 	}
-	if _, err := os.Stat(configurationFilePath); os.IsNotExist(err) {
+	remote := isPluginConfigurationRemoteURL(configurationFilePath)
+	if remote {
+		log.Printf("[INFO] fetching open api plugin configuration from %s", configurationFilePath)
+		remoteLoader, err := newPluginConfigurationRemoteLoader(providerName, configurationFilePath)
+		if err != nil {
+			return nil, err
+		}
+		content, err := remoteLoader.load()
+		if err != nil {
+			return nil, err
+		}
+		configurationFile = bytes.NewReader(content)
+	} else if _, err := os.Stat(configurationFilePath); os.IsNotExist(err) {
 		log.Printf("[INFO] open api plugin configuration not present at %s", configurationFilePath)
 	} else {
 		log.Printf("[INFO] found open api plugin configuration at %s", configurationFilePath)
@@ -54,6 +69,7 @@ func NewPluginConfiguration(providerName string) (*PluginConfiguration, error) {
 	return &PluginConfiguration{
 		ProviderName:  providerName,
 		Configuration: configurationFile,
+		remote:        remote,
 	}, nil
 }
 
@@ -124,6 +140,13 @@ func (p *PluginConfiguration) getServiceConfiguration() (ServiceConfiguration, e
 			if err != nil {
 				return nil, fmt.Errorf("error occurred when getting service configuration from plugin configuration file %s - error = %s", OpenAPIPluginConfigurationFileName, err)
 			}
+			// only the configuration of the service in use is interpolated so the environment variables and files
+			// referenced by other services are never read
+			interpolator := newPluginConfigInterpolator()
+			interpolator.fileInterpolationDisabled = p.remote
+			if err = interpolator.interpolateServiceConfiguration(p.ProviderName, serviceConfig); err != nil {
+				return nil, fmt.Errorf("failed to resolve the interpolations in %s configuration file - error = %s", OpenAPIPluginConfigurationFileName, err)
+			}
 		}
 	}
 
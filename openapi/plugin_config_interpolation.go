@@ -0,0 +1,172 @@
+package openapi
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// pluginConfigInterpolationRegex matches the interpolation expressions supported in the plugin configuration string values:
+// - ${env:VAR}: replaced with the value of the environment variable VAR. An error is returned if VAR is not defined
+// - ${env:VAR:-default}: replaced with the value of the environment variable VAR or 'default' if VAR is not defined
+// - ${file:path}: replaced with the contents of the file located at path (trailing new lines are removed). Paths starting with ~ are expanded
+// Literal '${' sequences can be escaped using '$${'
+const pluginConfigInterpolationRegex = `\$?\$\{(env|file):([^}]*)\}`
+
+const pluginConfigInterpolationEnv = "env"
+const pluginConfigInterpolationFile = "file"
+const pluginConfigInterpolationEnvDefaultSeparator = ":-"
+
+// pluginConfigInterpolator resolves the interpolation expressions present in the string fields of the service configuration
+type pluginConfigInterpolator struct {
+	lookupEnv      func(key string) (string, bool)
+	getFileContent func(filePath string) (string, error)
+	// fileInterpolationDisabled is set for plugin configurations fetched from a remote URL, so the content of the remote
+	// configuration can not read local files (e,g: credentials) and send them to the URLs configured in it
+	fileInterpolationDisabled bool
+}
+
+func newPluginConfigInterpolator() pluginConfigInterpolator {
+	return pluginConfigInterpolator{
+		lookupEnv:      os.LookupEnv,
+		getFileContent: getFileContent,
+	}
+}
+
+// interpolateServiceConfiguration walks through all the string fields (including string slices and map values) of the given
+// service configuration, replacing the interpolation expressions found with their resolved values. The error returned
+// contains the yaml path of the field where the interpolation failed, starting from the service (services.<serviceName>)
+func (i pluginConfigInterpolator) interpolateServiceConfiguration(serviceName string, serviceConfiguration ServiceConfiguration) error {
+	v := reflect.ValueOf(serviceConfiguration)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("plugin configuration interpolation expects a non nil pointer, got '%s'", v.Kind())
+	}
+	return i.interpolateValue(v, fmt.Sprintf(".services.%s", serviceName))
+}
+
+func (i pluginConfigInterpolator) interpolateValue(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return i.interpolateValue(v.Elem(), path)
+	case reflect.Struct:
+		t := v.Type()
+		for idx := 0; idx < v.NumField(); idx++ {
+			field := t.Field(idx)
+			if field.PkgPath != "" { // unexported fields are not populated from the yaml document
+				continue
+			}
+			if err := i.interpolateValue(v.Field(idx), i.buildFieldPath(path, field)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for idx := 0; idx < v.Len(); idx++ {
+			if err := i.interpolateValue(v.Index(idx), fmt.Sprintf("%s[%d]", path, idx)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			elemPath := fmt.Sprintf("%s.%v", path, key.Interface())
+			elem := v.MapIndex(key)
+			// map values are not addressable, hence a copy is interpolated and then stored back into the map
+			elemCopy := reflect.New(elem.Type()).Elem()
+			elemCopy.Set(elem)
+			if err := i.interpolateValue(elemCopy, elemPath); err != nil {
+				return err
+			}
+			v.SetMapIndex(key, elemCopy)
+		}
+	case reflect.String:
+		if !v.CanSet() {
+			return nil
+		}
+		resolved, err := i.interpolateString(v.String())
+		if err != nil {
+			return fmt.Errorf("failed to interpolate '%s': %s", strings.TrimPrefix(path, "."), err)
+		}
+		v.SetString(resolved)
+	}
+	return nil
+}
+
+func (i pluginConfigInterpolator) buildFieldPath(path string, field reflect.StructField) string {
+	name := field.Name
+	if tag, ok := field.Tag.Lookup("yaml"); ok {
+		if tagName := strings.Split(tag, ",")[0]; tagName != "" {
+			name = tagName
+		}
+	}
+	return fmt.Sprintf("%s.%s", path, name)
+}
+
+func (i pluginConfigInterpolator) interpolateString(value string) (string, error) {
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+	var interpolationErr error
+	r := regexp.MustCompile(pluginConfigInterpolationRegex)
+	resolved := r.ReplaceAllStringFunc(value, func(match string) string {
+		if interpolationErr != nil {
+			return match
+		}
+		// escaped expression, the leading '$' is removed and the expression is kept as is
+		if strings.HasPrefix(match, "$$") {
+			return match[1:]
+		}
+		submatches := r.FindStringSubmatch(match)
+		source, reference := submatches[1], submatches[2]
+		var resolvedValue string
+		switch source {
+		case pluginConfigInterpolationEnv:
+			resolvedValue, interpolationErr = i.resolveEnv(reference)
+		case pluginConfigInterpolationFile:
+			resolvedValue, interpolationErr = i.resolveFile(reference)
+		}
+		return resolvedValue
+	})
+	if interpolationErr != nil {
+		return "", interpolationErr
+	}
+	return resolved, nil
+}
+
+func (i pluginConfigInterpolator) resolveEnv(reference string) (string, error) {
+	name := reference
+	defaultValue := ""
+	hasDefault := false
+	if idx := strings.Index(reference, pluginConfigInterpolationEnvDefaultSeparator); idx >= 0 {
+		name = reference[:idx]
+		defaultValue = reference[idx+len(pluginConfigInterpolationEnvDefaultSeparator):]
+		hasDefault = true
+	}
+	if name == "" {
+		return "", fmt.Errorf("environment variable interpolation '${env:%s}' is missing the variable name", reference)
+	}
+	if value, exists := i.lookupEnv(name); exists {
+		return value, nil
+	}
+	if hasDefault {
+		return defaultValue, nil
+	}
+	return "", fmt.Errorf("environment variable '%s' is not defined. Please export the variable or provide a default value using the following syntax: ${env:%s:-default}", name, name)
+}
+
+func (i pluginConfigInterpolator) resolveFile(reference string) (string, error) {
+	if i.fileInterpolationDisabled {
+		return "", fmt.Errorf("file interpolation '${file:%s}' is not allowed in plugin configurations fetched from a remote URL", reference)
+	}
+	if reference == "" {
+		return "", fmt.Errorf("file interpolation '${file:}' is missing the file path")
+	}
+	content, err := i.getFileContent(reference)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file '%s': %s", reference, err)
+	}
+	return strings.TrimRight(content, "\r\n"), nil
+}
@@ -0,0 +1,250 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func newPluginConfigInterpolatorStub(env map[string]string, files map[string]string) pluginConfigInterpolator {
+	return pluginConfigInterpolator{
+		lookupEnv: func(key string) (string, bool) {
+			value, exists := env[key]
+			return value, exists
+		},
+		getFileContent: func(filePath string) (string, error) {
+			content, exists := files[filePath]
+			if !exists {
+				return "", errors.New("no such file or directory")
+			}
+			return content, nil
+		},
+	}
+}
+
+func TestPluginConfigInterpolatorInterpolateString(t *testing.T) {
+	Convey("Given a pluginConfigInterpolator with environment variables and files available", t, func() {
+		interpolator := newPluginConfigInterpolatorStub(map[string]string{"ENVIRONMENT": "staging", "EMPTY": ""}, map[string]string{"/path/to/token": "superSecret\n"})
+		testCases := []struct {
+			name          string
+			input         string
+			expected      string
+			expectedError string
+		}{
+			{name: "value without interpolations", input: "http://api.com/swagger.yaml", expected: "http://api.com/swagger.yaml"},
+			{name: "env interpolation", input: "https://api.${env:ENVIRONMENT}.com/swagger.yaml", expected: "https://api.staging.com/swagger.yaml"},
+			{name: "env interpolation of an empty variable ignoring the default", input: "${env:EMPTY:-other}", expected: ""},
+			{name: "env interpolation with default for an undefined variable", input: "https://api.${env:REGION:-us-west1}.com", expected: "https://api.us-west1.com"},
+			{name: "env interpolation with an empty default for an undefined variable", input: "${env:REGION:-}", expected: ""},
+			{name: "file interpolation trimming trailing new lines", input: "${file:/path/to/token}", expected: "superSecret"},
+			{name: "multiple interpolations", input: "${env:ENVIRONMENT}-${file:/path/to/token}", expected: "staging-superSecret"},
+			{name: "escaped interpolation", input: "$${env:ENVIRONMENT}", expected: "${env:ENVIRONMENT}"},
+			{name: "non supported interpolation source is kept as is", input: "${vault:secret}", expected: "${vault:secret}"},
+			{name: "undefined env variable", input: "${env:REGION}", expectedError: "environment variable 'REGION' is not defined. Please export the variable or provide a default value using the following syntax: ${env:REGION:-default}"},
+			{name: "env interpolation missing the variable name", input: "${env:}", expectedError: "environment variable interpolation '${env:}' is missing the variable name"},
+			{name: "non existing file", input: "${file:/path/to/missing}", expectedError: "failed to read file '/path/to/missing': no such file or directory"},
+			{name: "file interpolation missing the path", input: "${file:}", expectedError: "file interpolation '${file:}' is missing the file path"},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When interpolateString is called with a %s: %s", tc.name, tc.input), func() {
+				value, err := interpolator.interpolateString(tc.input)
+				if tc.expectedError != "" {
+					Convey("Then the error returned should match the expected one", func() {
+						So(err, ShouldNotBeNil)
+						So(err.Error(), ShouldEqual, tc.expectedError)
+					})
+				} else {
+					Convey("Then the value returned should match the expected one", func() {
+						So(err, ShouldBeNil)
+						So(value, ShouldEqual, tc.expected)
+					})
+				}
+			})
+		}
+	})
+}
+
+func TestPluginConfigInterpolatorInterpolate(t *testing.T) {
+	Convey("Given a pluginConfigInterpolator and a PluginConfigSchemaV1 containing interpolations in several string fields", t, func() {
+		interpolator := newPluginConfigInterpolatorStub(map[string]string{"ENVIRONMENT": "staging", "GRAPHITE_HOST": "graphite.com"}, map[string]string{"/path/to/token": "superSecret"})
+		pluginConfig := &PluginConfigSchemaV1{
+			Version: "1",
+			Services: map[string]*ServiceConfigV1{
+				"test": {
+					SwaggerURL: "https://api.${env:ENVIRONMENT}.com/swagger.yaml",
+					SchemaConfigurationV1: []ServiceSchemaPropertyConfigurationV1{
+						{
+							SchemaPropertyName: "apikey_auth",
+							DefaultValue:       "${file:/path/to/token}",
+							Command:            []string{"refresh", "--env=${env:ENVIRONMENT}"},
+							ExternalConfiguration: ServiceSchemaPropertyExternalConfigurationV1{
+								File: "/path/${env:ENVIRONMENT}/credentials.json",
+							},
+						},
+					},
+					TelemetryConfig: &TelemetryConfig{
+						Graphite:     &TelemetryProviderGraphite{Host: "${env:GRAPHITE_HOST}", Port: 8125},
+						HTTPEndpoint: &TelemetryProviderHTTPEndpoint{URL: "https://metrics.${env:ENVIRONMENT}.com", ProviderSchemaProperties: []string{"${env:HEADER:-billing_id}"}},
+					},
+				},
+			},
+		}
+		Convey("When interpolateServiceConfiguration is called", func() {
+			err := interpolator.interpolateServiceConfiguration("test", pluginConfig.Services["test"])
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And all the string fields should be resolved", func() {
+				serviceConfig := pluginConfig.Services["test"]
+				So(serviceConfig.SwaggerURL, ShouldEqual, "https://api.staging.com/swagger.yaml")
+				So(serviceConfig.SchemaConfigurationV1[0].SchemaPropertyName, ShouldEqual, "apikey_auth")
+				So(serviceConfig.SchemaConfigurationV1[0].DefaultValue, ShouldEqual, "superSecret")
+				So(serviceConfig.SchemaConfigurationV1[0].Command, ShouldResemble, []string{"refresh", "--env=staging"})
+				So(serviceConfig.SchemaConfigurationV1[0].ExternalConfiguration.File, ShouldEqual, "/path/staging/credentials.json")
+				So(serviceConfig.TelemetryConfig.Graphite.Host, ShouldEqual, "graphite.com")
+				So(serviceConfig.TelemetryConfig.Graphite.Port, ShouldEqual, 8125)
+				So(serviceConfig.TelemetryConfig.HTTPEndpoint.URL, ShouldEqual, "https://metrics.staging.com")
+				So(serviceConfig.TelemetryConfig.HTTPEndpoint.ProviderSchemaProperties, ShouldResemble, []string{"billing_id"})
+			})
+		})
+	})
+
+	Convey("Given a pluginConfigInterpolator and a PluginConfigSchemaV1 containing an interpolation with an undefined env variable", t, func() {
+		interpolator := newPluginConfigInterpolatorStub(map[string]string{}, map[string]string{})
+		pluginConfig := &PluginConfigSchemaV1{
+			Version: "1",
+			Services: map[string]*ServiceConfigV1{
+				"test": {
+					SwaggerURL: "https://api.com/swagger.yaml",
+					SchemaConfigurationV1: []ServiceSchemaPropertyConfigurationV1{
+						{SchemaPropertyName: "apikey_auth", DefaultValue: "${env:API_TOKEN}"},
+					},
+				},
+			},
+		}
+		Convey("When interpolateServiceConfiguration is called", func() {
+			err := interpolator.interpolateServiceConfiguration("test", pluginConfig.Services["test"])
+			Convey("Then the error returned should contain the path of the field that failed to be interpolated", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "failed to interpolate 'services.test.schema_configuration[0].default_value': environment variable 'API_TOKEN' is not defined. Please export the variable or provide a default value using the following syntax: ${env:API_TOKEN:-default}")
+			})
+		})
+	})
+
+	Convey("Given a pluginConfigInterpolator", t, func() {
+		interpolator := newPluginConfigInterpolator()
+		Convey("When interpolateServiceConfiguration is called with a nil service configuration", func() {
+			err := interpolator.interpolateServiceConfiguration("test", (*ServiceConfigV1)(nil))
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "plugin configuration interpolation expects a non nil pointer, got 'ptr'")
+			})
+		})
+	})
+}
+
+func TestGetServiceConfigurationWithInterpolations(t *testing.T) {
+	Convey("Given a PluginConfiguration for 'test' provider and a plugin configuration file containing env and file interpolations", t, func() {
+		file, err := ioutil.TempFile("", "token")
+		So(err, ShouldBeNil)
+		defer os.Remove(file.Name())
+		_, err = file.WriteString("superSecret\n")
+		So(err, ShouldBeNil)
+
+		os.Setenv("OTF_TEST_INTERPOLATION_HOST", "host.com")
+		defer os.Unsetenv("OTF_TEST_INTERPOLATION_HOST")
+
+		pluginConfig := fmt.Sprintf(`version: '1'
+services:
+    %s:
+      swagger-url: http://${env:OTF_TEST_INTERPOLATION_HOST}/swagger.yaml
+      schema_configuration:
+      - schema_property_name: "apikey_auth"
+        default_value: ${file:%s}`, providerName, file.Name())
+		pluginConfiguration := PluginConfiguration{
+			ProviderName:  providerName,
+			Configuration: strings.NewReader(pluginConfig),
+		}
+		Convey("When getServiceConfiguration is called", func() {
+			serviceConfiguration, err := pluginConfiguration.getServiceConfiguration()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the serviceConfiguration returned should contain the interpolated values", func() {
+				So(serviceConfiguration.GetSwaggerURL(), ShouldEqual, "http://host.com/swagger.yaml")
+				defaultValue, err := serviceConfiguration.GetSchemaPropertyConfiguration("apikey_auth").GetDefaultValue()
+				So(err, ShouldBeNil)
+				So(defaultValue, ShouldEqual, "superSecret")
+			})
+		})
+	})
+
+	Convey("Given a PluginConfiguration for 'test' provider and a plugin configuration file containing an interpolation of an undefined env variable", t, func() {
+		pluginConfig := fmt.Sprintf(`version: '1'
+services:
+    %s:
+      swagger-url: http://${env:OTF_TEST_INTERPOLATION_UNDEFINED}/swagger.yaml`, providerName)
+		pluginConfiguration := PluginConfiguration{
+			ProviderName:  providerName,
+			Configuration: strings.NewReader(pluginConfig),
+		}
+		Convey("When getServiceConfiguration is called", func() {
+			_, err := pluginConfiguration.getServiceConfiguration()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "failed to resolve the interpolations in terraform-provider-openapi.yaml configuration file - error = failed to interpolate 'services.test.swagger-url': environment variable 'OTF_TEST_INTERPOLATION_UNDEFINED' is not defined. Please export the variable or provide a default value using the following syntax: ${env:OTF_TEST_INTERPOLATION_UNDEFINED:-default}")
+			})
+		})
+	})
+
+	Convey("Given a PluginConfiguration for 'test' provider and a plugin configuration file where another service contains interpolations that can not be resolved", t, func() {
+		pluginConfig := fmt.Sprintf(`version: '1'
+services:
+    %s:
+      swagger-url: http://host.com/swagger.yaml
+    other:
+      swagger-url: http://${env:OTF_TEST_INTERPOLATION_UNDEFINED}/swagger.yaml
+      schema_configuration:
+      - schema_property_name: "apikey_auth"
+        default_value: ${file:/non/existing/file}`, providerName)
+		pluginConfiguration := PluginConfiguration{
+			ProviderName:  providerName,
+			Configuration: strings.NewReader(pluginConfig),
+		}
+		Convey("When getServiceConfiguration is called", func() {
+			serviceConfiguration, err := pluginConfiguration.getServiceConfiguration()
+			Convey("Then the error returned should be nil since only the service in use is interpolated", func() {
+				So(err, ShouldBeNil)
+				So(serviceConfiguration.GetSwaggerURL(), ShouldEqual, "http://host.com/swagger.yaml")
+			})
+		})
+	})
+
+	Convey("Given a PluginConfiguration for 'test' provider fetched from a remote URL containing a file interpolation", t, func() {
+		file, err := ioutil.TempFile("", "token")
+		So(err, ShouldBeNil)
+		defer os.Remove(file.Name())
+		pluginConfig := fmt.Sprintf(`version: '1'
+services:
+    %s:
+      swagger-url: http://host.com/swagger.yaml
+      schema_configuration:
+      - schema_property_name: "apikey_auth"
+        default_value: ${file:%s}`, providerName, file.Name())
+		pluginConfiguration := PluginConfiguration{
+			ProviderName:  providerName,
+			Configuration: strings.NewReader(pluginConfig),
+			remote:        true,
+		}
+		Convey("When getServiceConfiguration is called", func() {
+			_, err := pluginConfiguration.getServiceConfiguration()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, fmt.Sprintf("failed to resolve the interpolations in terraform-provider-openapi.yaml configuration file - error = failed to interpolate 'services.test.schema_configuration[0].default_value': file interpolation '${file:%s}' is not allowed in plugin configurations fetched from a remote URL", file.Name()))
+			})
+		})
+	})
+}
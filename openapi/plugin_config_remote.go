@@ -0,0 +1,191 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/version"
+)
+
+// otfVarCACertFile defines the environment variable that can be used to provide the path to a PEM encoded CA certificate bundle
+// that will be trusted (in addition to the system cert pool) when fetching the plugin configuration from a remote URL
+const otfVarCACertFile = "OTF_CA_CERT_FILE"
+
+// pluginConfigurationCacheDirName defines the name of the folder (inside the user's cache directory) where the remote plugin
+// configurations are cached
+const pluginConfigurationCacheDirName = "terraform-provider-openapi"
+
+const pluginConfigurationRemoteTimeout = 30 * time.Second
+
+// pluginConfigurationRemoteLoader is responsible for fetching the plugin configuration from a remote URL. Every successful
+// fetch is cached locally (per URL) so the plugin can still be initialised using the last known configuration if the
+// remote URL is not reachable (e,g: offline)
+type pluginConfigurationRemoteLoader struct {
+	providerName string
+	url          string
+	httpClient   *http.Client
+	cacheDir     string
+}
+
+func newPluginConfigurationRemoteLoader(providerName, url string) (*pluginConfigurationRemoteLoader, error) {
+	httpClient, err := newPluginConfigurationHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		log.Printf("[WARN] failed to resolve the user's cache directory, the remote plugin configuration will not be cached: %s", err)
+		cacheDir = ""
+	}
+	if cacheDir != "" {
+		cacheDir = filepath.Join(cacheDir, pluginConfigurationCacheDirName)
+	}
+	return &pluginConfigurationRemoteLoader{
+		providerName: providerName,
+		url:          url,
+		httpClient:   httpClient,
+		cacheDir:     cacheDir,
+	}, nil
+}
+
+// isPluginConfigurationRemoteURL returns true if the plugin configuration location provided is an http(s) URL
+func isPluginConfigurationRemoteURL(location string) bool {
+	u, err := url.Parse(location)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// newPluginConfigurationHTTPClient returns an http client that honours the OTF_INSECURE_SKIP_VERIFY and OTF_CA_CERT_FILE
+// environment variables
+func newPluginConfigurationHTTPClient() (*http.Client, error) {
+	tlsConfig := &tls.Config{}
+	if skipVerify, _ := strconv.ParseBool(os.Getenv(otfVarInsecureSkipVerify)); skipVerify {
+		log.Printf("[WARN] %s is enabled, the server certificate will not be verified when fetching the plugin configuration", otfVarInsecureSkipVerify)
+		tlsConfig.InsecureSkipVerify = true // #nosec G402
+	}
+	if caCertFile := os.Getenv(otfVarCACertFile); caCertFile != "" {
+		caCert, err := getFileContent(caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CA certificate file '%s' configured in %s: %s", caCertFile, otfVarCACertFile, err)
+		}
+		certPool, err := x509.SystemCertPool()
+		if err != nil || certPool == nil {
+			certPool = x509.NewCertPool()
+		}
+		if !certPool.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, fmt.Errorf("the CA certificate file '%s' configured in %s does not contain any valid PEM encoded certificate", caCertFile, otfVarCACertFile)
+		}
+		tlsConfig.RootCAs = certPool
+	}
+	return &http.Client{
+		Timeout: pluginConfigurationRemoteTimeout,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+	}, nil
+}
+
+// pluginConfigurationTransportError is returned when the remote server could not be reached, which is the only failure
+// where falling back to the cached plugin configuration is safe. Any response received from the server (e,g: 404, 401)
+// means the configuration might have been moved or revoked and the cached one must not be used
+type pluginConfigurationTransportError struct {
+	err error
+}
+
+func (e *pluginConfigurationTransportError) Error() string {
+	return e.err.Error()
+}
+
+// load fetches the plugin configuration from the remote URL and caches it locally. If the remote server can not be
+// reached, the cached configuration (if present) will be returned instead
+func (l *pluginConfigurationRemoteLoader) load() ([]byte, error) {
+	content, err := l.fetch()
+	if err != nil {
+		if _, isTransportError := err.(*pluginConfigurationTransportError); !isTransportError {
+			return nil, fmt.Errorf("failed to fetch the plugin configuration from '%s': %s", l.url, err)
+		}
+		cachedContent, cacheAge, cacheErr := l.readCache()
+		if cacheErr != nil {
+			return nil, fmt.Errorf("failed to fetch the plugin configuration from '%s' and no cached configuration is available: %s", l.url, err)
+		}
+		log.Printf("[WARN] failed to fetch the plugin configuration from '%s', falling back to the cached configuration at '%s' (%s old): %s", l.url, l.getCacheFilePath(), cacheAge, err)
+		return cachedContent, nil
+	}
+	if err := l.writeCache(content); err != nil {
+		log.Printf("[WARN] failed to cache the plugin configuration fetched from '%s': %s", l.url, err)
+	}
+	return content, nil
+}
+
+func (l *pluginConfigurationRemoteLoader) fetch() ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, l.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(userAgentHeader, version.BuildUserAgent(runtime.GOOS, runtime.GOARCH))
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return nil, &pluginConfigurationTransportError{err: err}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET '%s' returned a non expected status code %d", l.url, resp.StatusCode)
+	}
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &pluginConfigurationTransportError{err: err}
+	}
+	log.Printf("[INFO] plugin configuration successfully fetched from %s", l.url)
+	return content, nil
+}
+
+// getCacheFilePath returns the path of the cache file for the loader's URL. The cache is keyed by a hash of the URL so
+// changing the URL never serves the content cached for a different one
+func (l *pluginConfigurationRemoteLoader) getCacheFilePath() string {
+	if l.cacheDir == "" {
+		return ""
+	}
+	urlHash := fmt.Sprintf("%x", sha256.Sum256([]byte(l.url)))
+	return filepath.Join(l.cacheDir, l.providerName, urlHash, OpenAPIPluginConfigurationFileName)
+}
+
+// readCache returns the cached content along with the age of the cache
+func (l *pluginConfigurationRemoteLoader) readCache() ([]byte, time.Duration, error) {
+	cacheFilePath := l.getCacheFilePath()
+	if cacheFilePath == "" {
+		return nil, 0, fmt.Errorf("cache directory not available")
+	}
+	fileInfo, err := os.Stat(cacheFilePath)
+	if err != nil {
+		return nil, 0, err
+	}
+	content, err := ioutil.ReadFile(cacheFilePath) // #nosec G304
+	if err != nil {
+		return nil, 0, err
+	}
+	return content, time.Since(fileInfo.ModTime()).Round(time.Second), nil
+}
+
+// writeCache stores the content in the cache file. The file permissions are restricted to the user since the plugin
+// configuration may contain sensitive information
+func (l *pluginConfigurationRemoteLoader) writeCache(content []byte) error {
+	cacheFilePath := l.getCacheFilePath()
+	if cacheFilePath == "" {
+		return fmt.Errorf("cache directory not available")
+	}
+	if err := os.MkdirAll(filepath.Dir(cacheFilePath), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(cacheFilePath, content, 0600)
+}
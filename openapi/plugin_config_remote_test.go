@@ -0,0 +1,259 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/pem"
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const remotePluginConfiguration = `version: '1'
+services:
+    test:
+        swagger-url: http://host.com/swagger.yaml`
+
+func TestIsPluginConfigurationRemoteURL(t *testing.T) {
+	Convey("Given a list of plugin configuration locations", t, func() {
+		testCases := []struct {
+			location string
+			expected bool
+		}{
+			{location: "http://host.com/terraform-provider-openapi.yaml", expected: true},
+			{location: "https://host.com/terraform-provider-openapi.yaml", expected: true},
+			{location: "/some/path/terraform-provider-openapi.yaml", expected: false},
+			{location: "~/terraform-provider-openapi.yaml", expected: false},
+			{location: "ftp://host.com/terraform-provider-openapi.yaml", expected: false},
+			{location: "https:///terraform-provider-openapi.yaml", expected: false},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When isPluginConfigurationRemoteURL is called with '%s'", tc.location), func() {
+				isRemote := isPluginConfigurationRemoteURL(tc.location)
+				Convey(fmt.Sprintf("Then the result returned should be %t", tc.expected), func() {
+					So(isRemote, ShouldEqual, tc.expected)
+				})
+			})
+		}
+	})
+}
+
+func TestPluginConfigurationRemoteLoaderLoad(t *testing.T) {
+	Convey("Given a pluginConfigurationRemoteLoader pointing at a server that serves the plugin configuration", t, func() {
+		var userAgentReceived string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userAgentReceived = r.Header.Get(userAgentHeader)
+			w.Write([]byte(remotePluginConfiguration))
+		}))
+		defer server.Close()
+		cacheDir, _ := ioutil.TempDir("", "plugin_config_cache")
+		defer os.RemoveAll(cacheDir)
+		loader := pluginConfigurationRemoteLoader{providerName: providerName, url: server.URL, httpClient: &http.Client{}, cacheDir: cacheDir}
+		Convey("When load is called", func() {
+			content, err := loader.load()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the content returned should be the remote plugin configuration", func() {
+				So(string(content), ShouldEqual, remotePluginConfiguration)
+			})
+			Convey("And the request should identify the OpenAPI provider in the user agent", func() {
+				So(userAgentReceived, ShouldStartWith, "OpenAPI Terraform Provider")
+			})
+			Convey("And the plugin configuration should be cached with permissions restricted to the user", func() {
+				cacheFilePath := filepath.Join(cacheDir, providerName, fmt.Sprintf("%x", sha256.Sum256([]byte(server.URL))), OpenAPIPluginConfigurationFileName)
+				cachedContent, err := ioutil.ReadFile(cacheFilePath)
+				So(err, ShouldBeNil)
+				So(string(cachedContent), ShouldEqual, remotePluginConfiguration)
+				fileInfo, _ := os.Stat(cacheFilePath)
+				So(fileInfo.Mode().Perm(), ShouldEqual, os.FileMode(0600))
+			})
+		})
+	})
+
+	Convey("Given a pluginConfigurationRemoteLoader pointing at a server that is not reachable and a cached plugin configuration", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.Close()
+		cacheDir, _ := ioutil.TempDir("", "plugin_config_cache")
+		defer os.RemoveAll(cacheDir)
+		loader := pluginConfigurationRemoteLoader{providerName: providerName, url: server.URL, httpClient: &http.Client{}, cacheDir: cacheDir}
+		So(loader.writeCache([]byte(remotePluginConfiguration)), ShouldBeNil)
+		Convey("When load is called", func() {
+			content, err := loader.load()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the content returned should be the cached plugin configuration", func() {
+				So(string(content), ShouldEqual, remotePluginConfiguration)
+			})
+		})
+	})
+
+	Convey("Given a pluginConfigurationRemoteLoader pointing at a server that responds with an error and a cached plugin configuration", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+		cacheDir, _ := ioutil.TempDir("", "plugin_config_cache")
+		defer os.RemoveAll(cacheDir)
+		loader := pluginConfigurationRemoteLoader{providerName: providerName, url: server.URL, httpClient: &http.Client{}, cacheDir: cacheDir}
+		So(loader.writeCache([]byte(remotePluginConfiguration)), ShouldBeNil)
+		Convey("When load is called", func() {
+			_, err := loader.load()
+			Convey("Then the error returned should be the server error and the cached configuration should not be used", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, fmt.Sprintf("failed to fetch the plugin configuration from '%s': GET '%s' returned a non expected status code 401", server.URL, server.URL))
+			})
+		})
+	})
+
+	Convey("Given a pluginConfigurationRemoteLoader pointing at a server that is not reachable and a plugin configuration cached for a different URL", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.Close()
+		cacheDir, _ := ioutil.TempDir("", "plugin_config_cache")
+		defer os.RemoveAll(cacheDir)
+		otherLoader := pluginConfigurationRemoteLoader{providerName: providerName, url: "http://other-host.com/terraform-provider-openapi.yaml", cacheDir: cacheDir}
+		So(otherLoader.writeCache([]byte(remotePluginConfiguration)), ShouldBeNil)
+		loader := pluginConfigurationRemoteLoader{providerName: providerName, url: server.URL, httpClient: &http.Client{}, cacheDir: cacheDir}
+		Convey("When load is called", func() {
+			_, err := loader.load()
+			Convey("Then the error returned should say there is no cached configuration available", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldStartWith, fmt.Sprintf("failed to fetch the plugin configuration from '%s' and no cached configuration is available", server.URL))
+			})
+		})
+	})
+
+	Convey("Given a pluginConfigurationRemoteLoader pointing at a server that responds with not found and no cached plugin configuration", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+		cacheDir, _ := ioutil.TempDir("", "plugin_config_cache")
+		defer os.RemoveAll(cacheDir)
+		loader := pluginConfigurationRemoteLoader{providerName: providerName, url: server.URL, httpClient: &http.Client{}, cacheDir: cacheDir}
+		Convey("When load is called", func() {
+			_, err := loader.load()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, fmt.Sprintf("failed to fetch the plugin configuration from '%s': GET '%s' returned a non expected status code 404", server.URL, server.URL))
+			})
+		})
+	})
+
+	Convey("Given a pluginConfigurationRemoteLoader with no cache directory available pointing at a server that serves the plugin configuration", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(remotePluginConfiguration))
+		}))
+		defer server.Close()
+		loader := pluginConfigurationRemoteLoader{providerName: providerName, url: server.URL, httpClient: &http.Client{}, cacheDir: ""}
+		Convey("When load is called", func() {
+			content, err := loader.load()
+			Convey("Then the error returned should be nil and the remote content is returned", func() {
+				So(err, ShouldBeNil)
+				So(string(content), ShouldEqual, remotePluginConfiguration)
+			})
+		})
+	})
+}
+
+func TestNewPluginConfigurationHTTPClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(remotePluginConfiguration))
+	}))
+	defer server.Close()
+
+	Convey("Given a server using a self-signed cert and no TLS environment variables set", t, func() {
+		Convey("When the http client returned by newPluginConfigurationHTTPClient performs a request", func() {
+			c, err := newPluginConfigurationHTTPClient()
+			So(err, ShouldBeNil)
+			_, err = c.Get(server.URL)
+			Convey("Then the error returned should complain about the certificate", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "certificate")
+			})
+		})
+	})
+
+	Convey("Given a server using a self-signed cert and the OTF_INSECURE_SKIP_VERIFY env variable set", t, func() {
+		os.Setenv(otfVarInsecureSkipVerify, "true")
+		Convey("When the http client returned by newPluginConfigurationHTTPClient performs a request", func() {
+			c, err := newPluginConfigurationHTTPClient()
+			So(err, ShouldBeNil)
+			resp, err := c.Get(server.URL)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			})
+		})
+		os.Unsetenv(otfVarInsecureSkipVerify)
+	})
+
+	Convey("Given a server using a self-signed cert and the OTF_CA_CERT_FILE env variable pointing at the server cert", t, func() {
+		caCertFile, _ := ioutil.TempFile("", "ca.pem")
+		defer os.Remove(caCertFile.Name())
+		pem.Encode(caCertFile, &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		os.Setenv(otfVarCACertFile, caCertFile.Name())
+		Convey("When the http client returned by newPluginConfigurationHTTPClient performs a request", func() {
+			c, err := newPluginConfigurationHTTPClient()
+			So(err, ShouldBeNil)
+			resp, err := c.Get(server.URL)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			})
+		})
+		os.Unsetenv(otfVarCACertFile)
+	})
+
+	Convey("Given the OTF_CA_CERT_FILE env variable pointing at a file that does not contain certificates", t, func() {
+		caCertFile, _ := ioutil.TempFile("", "ca.pem")
+		defer os.Remove(caCertFile.Name())
+		caCertFile.WriteString("not a cert")
+		os.Setenv(otfVarCACertFile, caCertFile.Name())
+		Convey("When newPluginConfigurationHTTPClient is called", func() {
+			_, err := newPluginConfigurationHTTPClient()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, fmt.Sprintf("the CA certificate file '%s' configured in OTF_CA_CERT_FILE does not contain any valid PEM encoded certificate", caCertFile.Name()))
+			})
+		})
+		os.Unsetenv(otfVarCACertFile)
+	})
+}
+
+func TestNewPluginConfigurationRemoteURL(t *testing.T) {
+	Convey("Given the OTF_VAR_test_PLUGIN_CONFIGURATION_FILE env variable set with the URL of a server serving the plugin configuration", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(remotePluginConfiguration))
+		}))
+		defer server.Close()
+		cacheDir, _ := ioutil.TempDir("", "plugin_config_cache")
+		defer os.RemoveAll(cacheDir)
+		xdgCacheHome, xdgCacheHomeSet := os.LookupEnv("XDG_CACHE_HOME")
+		os.Setenv("XDG_CACHE_HOME", cacheDir)
+		otfVarPluginConfigurationFileName := fmt.Sprintf(otfVarPluginConfigurationFile, providerName)
+		os.Setenv(otfVarPluginConfigurationFileName, server.URL)
+		Convey("When NewPluginConfiguration is called", func() {
+			pluginConfiguration, err := NewPluginConfiguration(providerName)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the configuration should contain the remote plugin configuration", func() {
+				content, _ := ioutil.ReadAll(pluginConfiguration.Configuration)
+				So(strings.TrimSpace(string(content)), ShouldEqual, remotePluginConfiguration)
+			})
+		})
+		os.Unsetenv(otfVarPluginConfigurationFileName)
+		if xdgCacheHomeSet {
+			os.Setenv("XDG_CACHE_HOME", xdgCacheHome)
+		} else {
+			os.Unsetenv("XDG_CACHE_HOME")
+		}
+	})
+}
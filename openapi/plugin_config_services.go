@@ -22,7 +22,13 @@ type ServiceConfiguration interface {
 	Validate(runningPluginVersion string) error
 
 	// GetTelemetryConfiguration returns the telemetry configuration for this service provider
-	GetTelemetryConfiguration() TelemetryProvider
+	GetTelemetryConfiguration() []TelemetryProvider
+
+	// GetOverrides returns the overrides to apply to the OpenAPI document
+	GetOverrides() *ServiceOverridesV1
+
+	// GetTracingConfiguration returns the tracing configuration for this service provider
+	GetTracingConfiguration() *TracingConfig
 }
 
 // TelemetryConfig contains the configuration for the telemetry
@@ -31,6 +37,44 @@ type TelemetryConfig struct {
 	Graphite *TelemetryProviderGraphite `yaml:"graphite,omitempty"`
 	// HTTPEndpoint defines the configuration needed to ship telemetry to an http endpoint
 	HTTPEndpoint *TelemetryProviderHTTPEndpoint `yaml:"http_endpoint,omitempty"`
+	// Prometheus defines the configuration needed to ship telemetry to a Prometheus Pushgateway or node exporter textfile
+	Prometheus *TelemetryProviderPrometheus `yaml:"prometheus,omitempty"`
+	// Providers defines a list of telemetry providers the metrics will be shipped to simultaneously
+	Providers []TelemetryProviderConfigV1 `yaml:"providers,omitempty"`
+}
+
+// TelemetryProviderConfigV1 defines an item of the telemetry providers list. Only one of the telemetry providers (Graphite,
+// HTTPEndpoint or Prometheus) can be configured per item
+type TelemetryProviderConfigV1 struct {
+	// Graphite defines the configuration needed to ship telemetry to Graphite
+	Graphite *TelemetryProviderGraphite `yaml:"graphite,omitempty"`
+	// HTTPEndpoint defines the configuration needed to ship telemetry to an http endpoint
+	HTTPEndpoint *TelemetryProviderHTTPEndpoint `yaml:"http_endpoint,omitempty"`
+	// Prometheus defines the configuration needed to ship telemetry to a Prometheus Pushgateway or node exporter textfile
+	Prometheus *TelemetryProviderPrometheus `yaml:"prometheus,omitempty"`
+	// Timeout defines the time in seconds the plugin will wait for the metric submission to this telemetry provider to complete
+	Timeout int `yaml:"timeout,omitempty"`
+}
+
+// getTelemetryProvider returns the telemetry provider configured in the item
+func (t TelemetryProviderConfigV1) getTelemetryProvider() (TelemetryProvider, error) {
+	var telemetryProviders []TelemetryProvider
+	if t.Graphite != nil {
+		telemetryProviders = append(telemetryProviders, t.Graphite)
+	}
+	if t.HTTPEndpoint != nil {
+		telemetryProviders = append(telemetryProviders, t.HTTPEndpoint)
+	}
+	if t.Prometheus != nil {
+		telemetryProviders = append(telemetryProviders, t.Prometheus)
+	}
+	if len(telemetryProviders) != 1 {
+		return nil, fmt.Errorf("telemetry providers item must have exactly one telemetry provider configured (graphite, http_endpoint or prometheus), found %d", len(telemetryProviders))
+	}
+	if t.Timeout < 0 {
+		return nil, fmt.Errorf("telemetry provider timeout '%d' must be a positive number", t.Timeout)
+	}
+	return telemetryProviderWithTimeout{TelemetryProvider: telemetryProviders[0], timeout: t.Timeout}, nil
 }
 
 // ServiceConfigV1 defines configuration for the service provider
@@ -46,6 +90,12 @@ type ServiceConfigV1 struct {
 	SchemaConfigurationV1 []ServiceSchemaPropertyConfigurationV1 `yaml:"schema_configuration,omitempty"`
 
 	TelemetryConfig *TelemetryConfig `yaml:"telemetry,omitempty"`
+
+	// Overrides defines the overrides applied to the OpenAPI document before it is analysed
+	Overrides *ServiceOverridesV1 `yaml:"overrides,omitempty"`
+
+	// Tracing defines the configuration needed to export traces of the provider operations via OTLP/HTTP
+	Tracing *TracingConfig `yaml:"tracing,omitempty"`
 }
 
 // NewServiceConfigV1 creates a new instance of NewServiceConfigV1 struct with the values provided
@@ -74,36 +124,66 @@ func (s *ServiceConfigV1) IsInsecureSkipVerifyEnabled() bool {
 	return s.InsecureSkipVerify
 }
 
-// GetTelemetryConfiguration returns a TelemetryProvider configured for Graphite or HTTPEndpoint
-func (s *ServiceConfigV1) GetTelemetryConfiguration() TelemetryProvider {
-	if s.TelemetryConfig != nil {
-		if s.TelemetryConfig.Graphite != nil && s.TelemetryConfig.HTTPEndpoint != nil {
-			log.Printf("[WARN] ignoring telemetry due multiple telemetry providers configured (graphite and http_endpoint): select only one")
-			return nil
-		}
-		if s.TelemetryConfig.Graphite != nil {
-			log.Printf("[DEBUG] graphite telemetry configuration present")
-			err := s.TelemetryConfig.Graphite.Validate()
-			if err != nil {
-				log.Printf("[WARN] ignoring graphite telemetry due to the following validation error: %s", err)
-				return nil
-			}
-			log.Printf("[DEBUG] graphite telemetry provider enabled")
-			return s.TelemetryConfig.Graphite
+// GetTelemetryConfiguration returns the telemetry providers configured (Graphite, HTTPEndpoint and/or Prometheus). The
+// telemetry providers that are not valid are ignored
+func (s *ServiceConfigV1) GetTelemetryConfiguration() []TelemetryProvider {
+	if s.TelemetryConfig == nil {
+		log.Printf("[DEBUG] telemetry not configured")
+		return nil
+	}
+	var candidates []TelemetryProvider
+	if s.TelemetryConfig.Graphite != nil {
+		candidates = append(candidates, s.TelemetryConfig.Graphite)
+	}
+	if s.TelemetryConfig.HTTPEndpoint != nil {
+		candidates = append(candidates, s.TelemetryConfig.HTTPEndpoint)
+	}
+	if s.TelemetryConfig.Prometheus != nil {
+		candidates = append(candidates, s.TelemetryConfig.Prometheus)
+	}
+	for idx, telemetryProviderConfig := range s.TelemetryConfig.Providers {
+		telemetryProvider, err := telemetryProviderConfig.getTelemetryProvider()
+		if err != nil {
+			log.Printf("[WARN] ignoring telemetry providers item %d due to the following validation error: %s", idx, err)
+			continue
 		}
-		if s.TelemetryConfig.HTTPEndpoint != nil {
-			log.Printf("[DEBUG] http endpoint telemetry configuration present")
-			err := s.TelemetryConfig.HTTPEndpoint.Validate()
-			if err != nil {
-				log.Printf("[WARN] ignoring http endpoint telemetry due to the following validation error: %s", err)
-				return nil
-			}
-			log.Printf("[DEBUG] http endpoint telemetry provider enabled")
-			return s.TelemetryConfig.HTTPEndpoint
+		candidates = append(candidates, telemetryProvider)
+	}
+	var telemetryProviders []TelemetryProvider
+	for _, telemetryProvider := range candidates {
+		telemetryProviderName := getTelemetryProviderName(telemetryProvider)
+		log.Printf("[DEBUG] %s telemetry configuration present", telemetryProviderName)
+		if err := telemetryProvider.Validate(); err != nil {
+			log.Printf("[WARN] ignoring %s telemetry due to the following validation error: %s", telemetryProviderName, err)
+			continue
 		}
+		log.Printf("[DEBUG] %s telemetry provider enabled", telemetryProviderName)
+		telemetryProviders = append(telemetryProviders, telemetryProvider)
 	}
-	log.Printf("[DEBUG] telemetry not configured")
-	return nil
+	if len(telemetryProviders) == 0 {
+		log.Printf("[DEBUG] telemetry not configured")
+	}
+	return telemetryProviders
+}
+
+// GetTracingConfiguration returns the tracing configuration; nil is returned if tracing is not configured or the
+// configuration is not valid
+func (s *ServiceConfigV1) GetTracingConfiguration() *TracingConfig {
+	if s.Tracing == nil {
+		log.Printf("[DEBUG] tracing not configured")
+		return nil
+	}
+	if err := s.Tracing.Validate(); err != nil {
+		log.Printf("[WARN] ignoring tracing due to the following validation error: %s", err)
+		return nil
+	}
+	log.Printf("[DEBUG] tracing enabled")
+	return s.Tracing
+}
+
+// GetOverrides returns the overrides to apply to the OpenAPI document; nil is returned if not configured
+func (s *ServiceConfigV1) GetOverrides() *ServiceOverridesV1 {
+	return s.Overrides
 }
 
 // GetSchemaPropertyConfiguration returns the external configuration for the given schema property name; nil is returned
@@ -119,6 +199,7 @@ func (s *ServiceConfigV1) GetSchemaPropertyConfiguration(schemaPropertyName stri
 
 // Validate makes sure the configuration is valid:
 // - if the user has specified an OpenAPI plugin version, and if the plugin does not match the version then something is off
+// - if the user has specified overrides, the overrides must be valid
 func (s *ServiceConfigV1) Validate(runningPluginVersion string) error {
 	if !govalidator.IsURL(s.SwaggerURL) {
 		// fall back to try to load the swagger file from disk in case the path provided is a path to a file on disk
@@ -131,6 +212,9 @@ func (s *ServiceConfigV1) Validate(runningPluginVersion string) error {
 			return fmt.Errorf("plugin version '%s' in the plugin configuration file does not match the version of the OpenAPI plugin that is running '%s'", s.PluginVersion, runningPluginVersion)
 		}
 	}
+	if err := s.Overrides.Validate(); err != nil {
+		return fmt.Errorf("service overrides configuration not valid: %s", err)
+	}
 
 	return nil
 }
@@ -0,0 +1,118 @@
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Resource operation names used in the overrides to refer to the OpenAPI operations backing the terraform CRUD operations
+const (
+	overrideOperationCreate = "create"
+	overrideOperationRead   = "read"
+	overrideOperationUpdate = "update"
+	overrideOperationDelete = "delete"
+)
+
+var overrideDurationRegex = regexp.MustCompile("^\\d+(\\.\\d+)?[smh]{1}$")
+
+// ServiceOverridesV1 defines the overrides applied to the OpenAPI document before it is analysed. This enables users
+// consuming third party OpenAPI documents to configure the resources in the same way as if the corresponding 'x-terraform-*'
+// extensions were present in the document
+type ServiceOverridesV1 struct {
+	// Resources defines the list of resource overrides
+	Resources []ServiceResourceOverrideV1 `yaml:"resources,omitempty"`
+}
+
+// ServiceResourceOverrideV1 defines the overrides for a given resource. The resource can be identified either by its
+// root path or by its name
+type ServiceResourceOverrideV1 struct {
+	// Path defines the root path of the resource (e,g: /v1/cdns)
+	Path string `yaml:"path,omitempty"`
+	// Resource defines the name of the resource as computed from the OpenAPI document, without the provider name prefix (e,g: cdn_v1)
+	Resource string `yaml:"resource,omitempty"`
+	// Exclude defines whether the resource should be excluded from the provider (same as x-terraform-exclude-resource)
+	Exclude bool `yaml:"exclude,omitempty"`
+	// Name defines the preferred name of the resource (same as x-terraform-resource-name)
+	Name string `yaml:"name,omitempty"`
+	// Timeouts defines the timeouts of the resource operations (same as x-terraform-resource-timeout) keyed by operation: create, read, update or delete
+	Timeouts map[string]string `yaml:"timeouts,omitempty"`
+	// Polling defines the polling configuration of the resource operations keyed by operation: create, update or delete
+	Polling map[string]ServiceResourcePollingOverrideV1 `yaml:"polling,omitempty"`
+	// Properties defines the property overrides keyed by property name. Nested properties can be referred to using dot
+	// separated names (e,g: settings.label)
+	Properties map[string]ServicePropertyOverrideV1 `yaml:"properties,omitempty"`
+}
+
+// ServiceResourcePollingOverrideV1 defines the polling configuration for a resource operation (same as x-terraform-resource-poll-enabled,
+// x-terraform-resource-poll-completed-statuses and x-terraform-resource-poll-pending-statuses)
+type ServiceResourcePollingOverrideV1 struct {
+	// ResponseCode defines the response code of the operation that enables polling (e,g: 202)
+	ResponseCode int `yaml:"response_code"`
+	// TargetStatuses defines the statuses considered as completed
+	TargetStatuses []string `yaml:"target_statuses"`
+	// PendingStatuses defines the statuses considered as pending
+	PendingStatuses []string `yaml:"pending_statuses"`
+}
+
+// ServicePropertyOverrideV1 defines the overrides for a resource property. Values not set are left as defined in the OpenAPI document
+type ServicePropertyOverrideV1 struct {
+	// Immutable defines whether the property is immutable (same as x-terraform-immutable)
+	Immutable *bool `yaml:"immutable,omitempty"`
+	// Sensitive defines whether the property is sensitive (same as x-terraform-sensitive)
+	Sensitive *bool `yaml:"sensitive,omitempty"`
+	// Computed defines whether the property is optional computed (same as x-terraform-computed)
+	Computed *bool `yaml:"computed,omitempty"`
+	// ForceNew defines whether changing the property forces the creation of a new resource (same as x-terraform-force-new)
+	ForceNew *bool `yaml:"force_new,omitempty"`
+}
+
+// Validate makes sure the overrides are valid
+func (o *ServiceOverridesV1) Validate() error {
+	if o == nil {
+		return nil
+	}
+	for i, r := range o.Resources {
+		if err := r.validate(); err != nil {
+			return fmt.Errorf("resource override %d not valid: %s", i, err)
+		}
+	}
+	return nil
+}
+
+func (r ServiceResourceOverrideV1) validate() error {
+	if r.Path == "" && r.Resource == "" {
+		return fmt.Errorf("either path or resource must be provided")
+	}
+	if r.Path != "" && r.Resource != "" {
+		return fmt.Errorf("path and resource are mutually exclusive")
+	}
+	for operation, timeout := range r.Timeouts {
+		if !isValidOverrideOperation(operation, overrideOperationCreate, overrideOperationRead, overrideOperationUpdate, overrideOperationDelete) {
+			return fmt.Errorf("timeout operation '%s' not supported, supported operations are: create, read, update, delete", operation)
+		}
+		if !overrideDurationRegex.MatchString(timeout) {
+			return fmt.Errorf("timeout '%s' for operation '%s' not valid: the value must be formatted either in seconds (s), minutes (m) or hours (h)", timeout, operation)
+		}
+	}
+	for operation, polling := range r.Polling {
+		if !isValidOverrideOperation(operation, overrideOperationCreate, overrideOperationUpdate, overrideOperationDelete) {
+			return fmt.Errorf("polling operation '%s' not supported, supported operations are: create, update, delete", operation)
+		}
+		if polling.ResponseCode == 0 {
+			return fmt.Errorf("polling for operation '%s' is missing the response_code", operation)
+		}
+		if len(polling.TargetStatuses) == 0 {
+			return fmt.Errorf("polling for operation '%s' is missing the target_statuses", operation)
+		}
+	}
+	return nil
+}
+
+func isValidOverrideOperation(operation string, supportedOperations ...string) bool {
+	for _, supportedOperation := range supportedOperations {
+		if operation == supportedOperation {
+			return true
+		}
+	}
+	return false
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/oliveagle/jsonpath"
 	"log"
+	"os"
 	"os/exec"
 	"time"
 )
@@ -15,6 +16,9 @@ import (
 type ServiceSchemaPropertyConfiguration interface {
 	GetDefaultValue() (string, error)
 	ExecuteCommand() error
+	GetCredentialHelperValue(request CredentialHelperRequest) (string, error)
+	GetProfileSchemaPropertyName() string
+	GetDefaultValueForProfile(profile string) (string, error)
 }
 
 const cmdTimeout = 10
@@ -28,16 +32,24 @@ type ServiceSchemaPropertyConfigurationV1 struct {
 	Command               []string                                     `yaml:"cmd,flow,omitempty"`
 	CommandTimeout        int                                          `yaml:"cmd_timeout,omitempty"`
 	ExternalConfiguration ServiceSchemaPropertyExternalConfigurationV1 `yaml:"schema_property_external_configuration,omitempty"`
+	CredentialHelper      *ServiceSchemaPropertyCredentialHelperV1     `yaml:"credential_helper,omitempty"`
 }
 
 // ServiceSchemaPropertyExternalConfigurationV1 defines the external configuration for a provider property.
 type ServiceSchemaPropertyExternalConfigurationV1 struct {
 	// File defines the file containing the value of the schema property
 	File string `yaml:"file"`
-	// KeyName defines the specific key to look for within the File (only when json content type)
+	// KeyName defines the specific key to look for within the File (not applicable to raw content type)
 	KeyName string `yaml:"key_name"`
 	// ContentType defines the type of content the File has
-	ContentType string `yaml:"content_type"` // Currently supported types: raw, json
+	ContentType string `yaml:"content_type"` // Currently supported types: raw, json, yaml, dotenv, ini, toml
+	// Profile defines the section (top level key) of the File where the KeyName is looked up (not applicable to raw and dotenv content types)
+	Profile string `yaml:"profile,omitempty"`
+	// ProfileEnvVar defines the environment variable containing the profile. If the environment variable is set, it takes preference over Profile
+	ProfileEnvVar string `yaml:"profile_env_var,omitempty"`
+	// ProfileSchemaProperty defines the name of the provider's schema property containing the profile. If the user
+	// populates the provider's schema property, the profile value provided takes preference over both ProfileEnvVar and Profile
+	ProfileSchemaProperty string `yaml:"profile_schema_property,omitempty"`
 }
 
 // GetDefaultValue returns the default value for the schema property configuration. The following logic defines the preference
@@ -47,12 +59,27 @@ type ServiceSchemaPropertyExternalConfigurationV1 struct {
 //    - If 'file' field is populated then:
 //      - If the 'content_type' is raw the contents of the 'file' will be used as default value
 //      - If the 'content_type' is json then the content of the 'file' must be json structure and the default value used will be the one defined in the 'key_name'
+//      - If the 'content_type' is yaml, dotenv, ini or toml then the content of the 'file' will be parsed accordingly and the default value used will be the one defined in the 'key_name'
 //    - An error is thrown otherwise
+//    - If the content type supports profiles, the key will be looked up within the profile section defined either by the
+//      'profile_env_var' environment variable (if set) or the 'profile' field
 func (s ServiceSchemaPropertyConfigurationV1) GetDefaultValue() (string, error) {
+	return s.GetDefaultValueForProfile(s.ExternalConfiguration.getProfile())
+}
+
+// GetProfileSchemaPropertyName returns the name of the provider's schema property that can be used to select the external
+// configuration profile at runtime. An empty string is returned if not configured
+func (s ServiceSchemaPropertyConfigurationV1) GetProfileSchemaPropertyName() string {
+	return s.ExternalConfiguration.ProfileSchemaProperty
+}
+
+// GetDefaultValueForProfile returns the default value for the schema property configuration following the same logic as
+// GetDefaultValue but looking up the value within the profile provided
+func (s ServiceSchemaPropertyConfigurationV1) GetDefaultValueForProfile(profile string) (string, error) {
 	if &s.ExternalConfiguration != nil {
 		if s.ExternalConfiguration.File != "" {
-			log.Printf("[DEBUG] provider schema property '%s' configured to use as default value [ContentType=%s; File=%s, KeyName=%s]", s.SchemaPropertyName, s.ExternalConfiguration.ContentType, s.ExternalConfiguration.File, s.ExternalConfiguration.KeyName)
-			schemaFileParser, err := s.ExternalConfiguration.getFileParser()
+			log.Printf("[DEBUG] provider schema property '%s' configured to use as default value [ContentType=%s; File=%s, KeyName=%s, Profile=%s]", s.SchemaPropertyName, s.ExternalConfiguration.ContentType, s.ExternalConfiguration.File, s.ExternalConfiguration.KeyName, profile)
+			schemaFileParser, err := s.ExternalConfiguration.getFileParser(profile)
 			if err != nil {
 				return "", fmt.Errorf("failed to read external configuration file '%s' for schema property '%s': %s", s.ExternalConfiguration.File, s.SchemaPropertyName, err)
 			}
@@ -66,6 +93,20 @@ func (s ServiceSchemaPropertyConfigurationV1) GetDefaultValue() (string, error)
 	return s.DefaultValue, nil
 }
 
+// GetCredentialHelperValue executes the credential helper configured in the ServiceSchemaPropertyConfigurationV1 struct
+// if applicable and returns the value provided by the helper. If the property does not have a credential helper configured
+// an empty string and nil error will be returned
+func (s ServiceSchemaPropertyConfigurationV1) GetCredentialHelperValue(request CredentialHelperRequest) (string, error) {
+	if s.CredentialHelper == nil {
+		return "", nil
+	}
+	value, err := s.CredentialHelper.getValue(request)
+	if err != nil {
+		return "", fmt.Errorf("provider schema property '%s' credential helper failed: %s", s.SchemaPropertyName, err)
+	}
+	return value, nil
+}
+
 // ExecuteCommand run the 'Command' configured in the ServiceSchemaPropertyConfigurationV1 struct if applicable.
 // - If the command fails to execute the appropriate error will be returned including the error returned by exec
 // - If the command execution does not finish within the expected time (either before CommandTimeout or before the default timeout 10s)
@@ -123,7 +164,17 @@ func (s ServiceSchemaPropertyConfigurationV1) exec(doneChan chan error) {
 	doneChan <- nil
 }
 
-func (c ServiceSchemaPropertyExternalConfigurationV1) getFileParser() (schemaFileParser, error) {
+// getProfile returns the value of the ProfileEnvVar environment variable if set, the Profile value otherwise
+func (c ServiceSchemaPropertyExternalConfigurationV1) getProfile() string {
+	if c.ProfileEnvVar != "" {
+		if profile := os.Getenv(c.ProfileEnvVar); profile != "" {
+			return profile
+		}
+	}
+	return c.Profile
+}
+
+func (c ServiceSchemaPropertyExternalConfigurationV1) getFileParser(profile string) (schemaFileParser, error) {
 	schemaFileContent, err := getFileContent(c.File)
 	if err != nil {
 		return nil, err
@@ -135,7 +186,18 @@ func (c ServiceSchemaPropertyExternalConfigurationV1) getFileParser() (schemaFil
 		}
 		return parserRaw{content: schemaFileContent}, nil
 	case "json":
-		return parserJSON{jsonContent: schemaFileContent, keyName: c.KeyName}, nil
+		return parserJSON{jsonContent: schemaFileContent, keyName: c.KeyName, profile: profile}, nil
+	case "yaml":
+		return parserYAML{yamlContent: schemaFileContent, keyName: c.KeyName, profile: profile}, nil
+	case "dotenv":
+		if profile != "" {
+			log.Printf("[WARN] service external configuration of type 'dotenv' does not support profiles, ignoring profile '%s'", profile)
+		}
+		return parserDotEnv{content: schemaFileContent, keyName: c.KeyName}, nil
+	case "ini":
+		return parserINI{content: schemaFileContent, keyName: c.KeyName, profile: profile}, nil
+	case "toml":
+		return parserTOML{content: schemaFileContent, keyName: c.KeyName, profile: profile}, nil
 	default:
 		return nil, fmt.Errorf("'%s' content type not supported", c.ContentType)
 	}
@@ -156,6 +218,7 @@ func (p parserRaw) getValue() (string, error) {
 type parserJSON struct {
 	jsonContent string
 	keyName     string
+	profile     string
 }
 
 func (p parserJSON) getValue() (string, error) {
@@ -164,6 +227,10 @@ func (p parserJSON) getValue() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	jsonData, err = selectProfile(jsonData, p.profile)
+	if err != nil {
+		return "", err
+	}
 	res, err := jsonpath.JsonPathLookup(jsonData, p.keyName)
 	if err != nil {
 		return "", err
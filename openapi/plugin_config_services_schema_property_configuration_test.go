@@ -278,7 +278,7 @@ func TestServiceExternalConfigurationV1GetFileParser(t *testing.T) {
 			File:        tmpFile.Name(),
 		}
 		Convey("When getFileParser method is called with some content", func() {
-			parser, err := serviceExternalConfigurationV1.getFileParser()
+			parser, err := serviceExternalConfigurationV1.getFileParser("")
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -305,7 +305,7 @@ func TestServiceExternalConfigurationV1GetFileParser(t *testing.T) {
 			KeyName:     "$.firstName",
 		}
 		Convey("When getFileParser method is called with some content", func() {
-			parser, err := serviceExternalConfigurationV1.getFileParser()
+			parser, err := serviceExternalConfigurationV1.getFileParser("")
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -331,7 +331,7 @@ func TestServiceExternalConfigurationV1GetFileParser(t *testing.T) {
 			File:        tmpFile.Name(),
 		}
 		Convey("When getFileParser method is called with some content", func() {
-			_, err := serviceExternalConfigurationV1.getFileParser()
+			_, err := serviceExternalConfigurationV1.getFileParser("")
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
@@ -444,3 +444,102 @@ func TestSchemaFileParserJsonGetValue(t *testing.T) {
 		})
 	})
 }
+
+func TestServiceExternalConfigurationV1GetFileParserStructuredContentTypes(t *testing.T) {
+	Convey("Given a list of ServiceSchemaPropertyExternalConfigurationV1 configured with structured content types and a profile", t, func() {
+		testCases := []struct {
+			contentType    string
+			content        string
+			keyName        string
+			expectedParser schemaFileParser
+		}{
+			{contentType: "json", content: `{"production":{"token":"superSecret"}}`, keyName: "$.token", expectedParser: parserJSON{}},
+			{contentType: "yaml", content: "production:\n  token: superSecret", keyName: "token", expectedParser: parserYAML{}},
+			{contentType: "ini", content: "[production]\ntoken = superSecret", keyName: "token", expectedParser: parserINI{}},
+			{contentType: "toml", content: "[production]\ntoken = \"superSecret\"", keyName: "token", expectedParser: parserTOML{}},
+			{contentType: "dotenv", content: "token=superSecret", keyName: "token", expectedParser: parserDotEnv{}},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When getFileParser method is called for '%s' content with the 'production' profile", tc.contentType), func() {
+				tmpFile, _ := ioutil.TempFile("", "")
+				defer os.Remove(tmpFile.Name())
+				tmpFile.Write([]byte(tc.content))
+				serviceExternalConfigurationV1 := ServiceSchemaPropertyExternalConfigurationV1{
+					ContentType: tc.contentType,
+					File:        tmpFile.Name(),
+					KeyName:     tc.keyName,
+				}
+				parser, err := serviceExternalConfigurationV1.getFileParser("production")
+				Convey("Then the error returned should be nil and the parser should be of the expected type", func() {
+					So(err, ShouldBeNil)
+					So(parser, ShouldHaveSameTypeAs, tc.expectedParser)
+				})
+				Convey("And the parser get value should return the value from the profile", func() {
+					value, err := parser.getValue()
+					So(err, ShouldBeNil)
+					So(value, ShouldEqual, "superSecret")
+				})
+			})
+		}
+	})
+}
+
+func TestServiceExternalConfigurationV1GetProfile(t *testing.T) {
+	Convey("Given a ServiceSchemaPropertyExternalConfigurationV1 configured with a profile and a profile env var", t, func() {
+		serviceExternalConfigurationV1 := ServiceSchemaPropertyExternalConfigurationV1{
+			Profile:       "default",
+			ProfileEnvVar: "OTF_TEST_PROFILE",
+		}
+		Convey("When getProfile method is called and the env var is not set", func() {
+			profile := serviceExternalConfigurationV1.getProfile()
+			Convey("Then the profile returned should be the one configured in the profile field", func() {
+				So(profile, ShouldEqual, "default")
+			})
+		})
+		Convey("When getProfile method is called and the env var is set", func() {
+			os.Setenv("OTF_TEST_PROFILE", "production")
+			profile := serviceExternalConfigurationV1.getProfile()
+			os.Unsetenv("OTF_TEST_PROFILE")
+			Convey("Then the profile returned should be the one set in the env var", func() {
+				So(profile, ShouldEqual, "production")
+			})
+		})
+	})
+}
+
+func TestServiceSchemaConfigurationV1GetDefaultValueForProfile(t *testing.T) {
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 with an external 'ini' config containing multiple profiles", t, func() {
+		tmpFile, _ := ioutil.TempFile("", "")
+		defer os.Remove(tmpFile.Name())
+		tmpFile.Write([]byte("[default]\ntoken = defaultToken\n[production]\ntoken = productionToken"))
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
+			SchemaPropertyName: "schemaPropertyName",
+			ExternalConfiguration: ServiceSchemaPropertyExternalConfigurationV1{
+				ContentType:           "ini",
+				File:                  tmpFile.Name(),
+				KeyName:               "token",
+				Profile:               "default",
+				ProfileSchemaProperty: "profile",
+			},
+		}
+		Convey("When GetDefaultValue method is called", func() {
+			value, err := serviceSchemaConfigurationV1.GetDefaultValue()
+			Convey("Then the value returned should be the one from the configured profile", func() {
+				So(err, ShouldBeNil)
+				So(value, ShouldEqual, "defaultToken")
+			})
+		})
+		Convey("When GetDefaultValueForProfile method is called with the 'production' profile", func() {
+			value, err := serviceSchemaConfigurationV1.GetDefaultValueForProfile("production")
+			Convey("Then the value returned should be the one from the production profile", func() {
+				So(err, ShouldBeNil)
+				So(value, ShouldEqual, "productionToken")
+			})
+		})
+		Convey("When GetProfileSchemaPropertyName method is called", func() {
+			Convey("Then the value returned should be the configured profile schema property", func() {
+				So(serviceSchemaConfigurationV1.GetProfileSchemaPropertyName(), ShouldEqual, "profile")
+			})
+		})
+	})
+}
@@ -0,0 +1,138 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CredentialHelperRequest defines the JSON document sent to the credential helper via stdin
+type CredentialHelperRequest struct {
+	// ProviderName is the name of the provider requesting the credential (terraform-provider-<provider_name>)
+	ProviderName string `json:"provider_name"`
+	// PropertyName is the name of the provider's schema property the credential will be used for
+	PropertyName string `json:"property_name"`
+	// SpecURL is the location of the OpenAPI document the provider was built from
+	SpecURL string `json:"spec_url"`
+}
+
+// credentialHelperMaxOutputSize is the max number of bytes read from the credential helper output
+const credentialHelperMaxOutputSize = 64 * 1024
+
+// credentialHelperResponse defines the JSON document the credential helper is expected to write to stdout
+type credentialHelperResponse struct {
+	Value *string `json:"value"`
+}
+
+// ServiceSchemaPropertyCredentialHelperV1 defines the credential helper configuration for a provider property. The credential
+// helper is an external program that follows a similar protocol to Docker and git credential helpers: the plugin executes
+// the configured command writing a CredentialHelperRequest JSON document to its stdin and expects a JSON document containing
+// the 'value' to be written to stdout, e,g: {"value": "superSecret"}
+type ServiceSchemaPropertyCredentialHelperV1 struct {
+	// Command defines the credential helper command to execute using exec form: ["executable","param1","param2"]
+	Command []string `yaml:"cmd,flow"`
+	// Timeout defines the max timeout, in seconds, for the credential helper to execute. Defaults to 10s
+	Timeout int `yaml:"timeout,omitempty"`
+}
+
+// credentialHelperCache keeps the values returned by the credential helpers so each helper is executed at most once per
+// plugin execution for the same request
+var credentialHelperCache = struct {
+	sync.Mutex
+	values map[string]string
+}{values: map[string]string{}}
+
+// getValue returns the value provided by the credential helper for the given request. The value is cached so subsequent
+// calls with the same request will not execute the helper again. Note the output of the helper is never logged as it
+// is expected to contain secrets
+func (c ServiceSchemaPropertyCredentialHelperV1) getValue(request CredentialHelperRequest) (string, error) {
+	if len(c.Command) == 0 {
+		return "", fmt.Errorf("credential helper is missing the command to execute")
+	}
+	cacheKey := c.getCacheKey(request)
+	credentialHelperCache.Lock()
+	defer credentialHelperCache.Unlock()
+	if value, exists := credentialHelperCache.values[cacheKey]; exists {
+		log.Printf("[DEBUG] using cached value from credential helper '%s' for property '%s'", c.Command[0], request.PropertyName)
+		return value, nil
+	}
+	value, err := c.execute(request)
+	if err != nil {
+		return "", err
+	}
+	credentialHelperCache.values[cacheKey] = value
+	return value, nil
+}
+
+func (c ServiceSchemaPropertyCredentialHelperV1) getCacheKey(request CredentialHelperRequest) string {
+	return fmt.Sprintf("%s|%s|%s|%s", strings.Join(c.Command, " "), request.ProviderName, request.PropertyName, request.SpecURL)
+}
+
+func (c ServiceSchemaPropertyCredentialHelperV1) execute(request CredentialHelperRequest) (string, error) {
+	start := time.Now()
+	log.Printf("[INFO] executing credential helper '%s' for property '%s'", c.Command[0], request.PropertyName)
+
+	timeout := cmdTimeout
+	if c.Timeout > 0 {
+		timeout = c.Timeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	input, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := exec.CommandContext(ctx, c.Command[0], c.Command[1:]...) // #nosec G204 the command is configured by the user in the plugin configuration file
+	cmd.Stdin = bytes.NewReader(input)
+	// stderr is discarded since it may contain secrets (e,g: the helper printing its configuration on failures)
+	cmd.Stderr = nil
+	// stdout is read from a pipe instead of a buffer so the timeout is enforced even if a process spawned by the helper
+	// inherits stdout and keeps it open after the helper exits (exec only kills the helper process itself)
+	stdoutPipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", fmt.Errorf("credential helper '%s' failed: %s", c.Command[0], err)
+	}
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("credential helper '%s' failed: %s", c.Command[0], err)
+	}
+	output := make(chan []byte, 1)
+	go func() {
+		stdout, _ := ioutil.ReadAll(io.LimitReader(stdoutPipe, credentialHelperMaxOutputSize))
+		// draining any output over the limit so the helper does not block writing to stdout
+		io.Copy(ioutil.Discard, stdoutPipe) // #nosec G104
+		output <- stdout
+	}()
+	var stdout []byte
+	select {
+	case stdout = <-output:
+	case <-ctx.Done():
+	}
+	err = cmd.Wait()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("credential helper '%s' did not finish executing within the expected time %ds", c.Command[0], timeout)
+	}
+	// stdout is purposefully not included in the errors as it may contain secrets
+	if err != nil {
+		return "", fmt.Errorf("credential helper '%s' failed: %s", c.Command[0], err)
+	}
+
+	response := credentialHelperResponse{}
+	if err := json.Unmarshal(stdout, &response); err != nil {
+		return "", fmt.Errorf("credential helper '%s' returned an output that is not a valid JSON document", c.Command[0])
+	}
+	if response.Value == nil {
+		return "", fmt.Errorf("credential helper '%s' returned a JSON document missing the 'value' property", c.Command[0])
+	}
+	log.Printf("[INFO] credential helper '%s' for property '%s' executed successfully (time:%s)", c.Command[0], request.PropertyName, time.Since(start))
+	return *response.Value, nil
+}
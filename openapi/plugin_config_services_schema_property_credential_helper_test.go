@@ -0,0 +1,139 @@
+package openapi
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServiceSchemaPropertyCredentialHelperV1GetValue(t *testing.T) {
+	request := CredentialHelperRequest{ProviderName: "openapi", PropertyName: "apikey_auth", SpecURL: "http://host.com/swagger.yaml"}
+
+	Convey("Given a credential helper that returns a valid response based on the request received", t, func() {
+		credentialHelper := ServiceSchemaPropertyCredentialHelperV1{
+			Command: []string{"sh", "-c", `read input; case "$input" in *'"property_name":"apikey_auth"'*) echo '{"value":"superSecret"}';; *) exit 1;; esac`},
+		}
+		Convey("When getValue is called", func() {
+			value, err := credentialHelper.getValue(request)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the value returned should be the one provided by the helper", func() {
+				So(value, ShouldEqual, "superSecret")
+			})
+		})
+	})
+
+	Convey("Given a credential helper that writes to a file every time is executed", t, func() {
+		counterFile, _ := ioutil.TempFile("", "credential_helper_counter")
+		defer os.Remove(counterFile.Name())
+		credentialHelper := ServiceSchemaPropertyCredentialHelperV1{
+			Command: []string{"sh", "-c", fmt.Sprintf(`echo x >> %s; echo '{"value":"superSecret"}'`, counterFile.Name())},
+		}
+		Convey("When getValue is called multiple times with the same request", func() {
+			value1, err1 := credentialHelper.getValue(request)
+			value2, err2 := credentialHelper.getValue(request)
+			Convey("Then the values returned should be the same", func() {
+				So(err1, ShouldBeNil)
+				So(err2, ShouldBeNil)
+				So(value1, ShouldEqual, "superSecret")
+				So(value2, ShouldEqual, value1)
+			})
+			Convey("And the credential helper should have been executed only once", func() {
+				content, _ := ioutil.ReadFile(counterFile.Name())
+				So(strings.Count(string(content), "x"), ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a list of failing credential helpers", t, func() {
+		testCases := []struct {
+			name          string
+			command       []string
+			timeout       int
+			expectedError string
+		}{
+			{
+				name:          "helper with no command",
+				command:       []string{},
+				expectedError: "credential helper is missing the command to execute",
+			},
+			{
+				name:          "helper exiting with non zero code",
+				command:       []string{"sh", "-c", `echo '{"value":"superSecret"}'; echo "access denied" >&2; exit 1`},
+				expectedError: "credential helper 'sh' failed: exit status 1",
+			},
+			{
+				name:          "helper returning invalid JSON",
+				command:       []string{"sh", "-c", `echo 'superSecret'`},
+				expectedError: "credential helper 'sh' returned an output that is not a valid JSON document",
+			},
+			{
+				name:          "helper returning JSON without value",
+				command:       []string{"sh", "-c", `echo '{"token":"superSecret"}'`},
+				expectedError: "credential helper 'sh' returned a JSON document missing the 'value' property",
+			},
+			{
+				name:          "helper not finishing within the timeout",
+				command:       []string{"sleep", "5"},
+				timeout:       1,
+				expectedError: "credential helper 'sleep' did not finish executing within the expected time 1s",
+			},
+			{
+				name:          "helper leaving a process running that keeps its output open",
+				command:       []string{"sh", "-c", `sleep 5 & echo '{"value":"superSecret"}'`},
+				timeout:       1,
+				expectedError: "credential helper 'sh' did not finish executing within the expected time 1s",
+			},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When getValue is called for the %s", tc.name), func() {
+				credentialHelper := ServiceSchemaPropertyCredentialHelperV1{Command: tc.command, Timeout: tc.timeout}
+				start := time.Now()
+				value, err := credentialHelper.getValue(request)
+				Convey("Then the error returned should be the expected one and should not leak the helper output", func() {
+					So(err, ShouldNotBeNil)
+					So(err.Error(), ShouldEqual, tc.expectedError)
+					So(err.Error(), ShouldNotContainSubstring, "superSecret")
+					So(value, ShouldBeEmpty)
+				})
+				Convey("And getValue should return within the timeout", func() {
+					So(time.Since(start), ShouldBeLessThan, 3*time.Second)
+				})
+			})
+		}
+	})
+}
+
+func TestServiceSchemaConfigurationV1GetCredentialHelperValue(t *testing.T) {
+	request := CredentialHelperRequest{ProviderName: "openapi", PropertyName: "apikey_auth"}
+
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 with no credential helper configured", t, func() {
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{SchemaPropertyName: "apikey_auth"}
+		Convey("When GetCredentialHelperValue is called", func() {
+			value, err := serviceSchemaConfigurationV1.GetCredentialHelperValue(request)
+			Convey("Then the value returned should be empty and the error nil", func() {
+				So(err, ShouldBeNil)
+				So(value, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 with a credential helper that fails", t, func() {
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
+			SchemaPropertyName: "apikey_auth",
+			CredentialHelper:   &ServiceSchemaPropertyCredentialHelperV1{Command: []string{"sh", "-c", "exit 1"}},
+		}
+		Convey("When GetCredentialHelperValue is called", func() {
+			_, err := serviceSchemaConfigurationV1.GetCredentialHelperValue(request)
+			Convey("Then the error returned should reference the schema property", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "provider schema property 'apikey_auth' credential helper failed: credential helper 'sh' failed: exit status 1")
+			})
+		})
+	})
+}
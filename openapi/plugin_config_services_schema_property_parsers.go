@@ -0,0 +1,415 @@
+package openapi
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/oliveagle/jsonpath"
+	"gopkg.in/yaml.v2"
+)
+
+// parserYAML parses YAML documents. The key name can be either a dot separated path (e,g: credentials.token) or a JsonPath
+// expression (e,g: $.credentials.token)
+type parserYAML struct {
+	yamlContent string
+	keyName     string
+	profile     string
+}
+
+func (p parserYAML) getValue() (string, error) {
+	var yamlData interface{}
+	if err := yaml.Unmarshal([]byte(p.yamlContent), &yamlData); err != nil {
+		return "", err
+	}
+	data, ok := normalizeYAMLValue(yamlData).(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("yaml content is not an object")
+	}
+	return lookupStructuredValue(data, p.keyName, p.profile)
+}
+
+// normalizeYAMLValue converts the map[interface{}]interface{} values produced by the yaml decoder into map[string]interface{}
+// so the data can be queried the same way as JSON data
+func normalizeYAMLValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for key, val := range v {
+			m[fmt.Sprintf("%v", key)] = normalizeYAMLValue(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range v {
+			v[i] = normalizeYAMLValue(val)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// parserDotEnv parses dotenv files (KEY=VALUE lines). The key name is the name of the variable
+type parserDotEnv struct {
+	content string
+	keyName string
+}
+
+func (p parserDotEnv) getValue() (string, error) {
+	data, err := parseDotEnv(p.content)
+	if err != nil {
+		return "", err
+	}
+	if p.keyName == "" {
+		return "", fmt.Errorf("key_name is required for dotenv content")
+	}
+	value, exists := data[p.keyName]
+	if !exists {
+		return "", fmt.Errorf("key '%s' not found", p.keyName)
+	}
+	return value, nil
+}
+
+func parseDotEnv(content string) (map[string]string, error) {
+	data := map[string]string{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid dotenv line %d: expected KEY=VALUE", lineNumber)
+		}
+		value, err := parseUnquotedOrQuotedValue(strings.TrimSpace(parts[1]), "#")
+		if err != nil {
+			return nil, fmt.Errorf("invalid dotenv line %d: %s", lineNumber, err)
+		}
+		data[strings.TrimSpace(parts[0])] = value
+	}
+	return data, scanner.Err()
+}
+
+// parserINI parses INI files such as the ~/.aws/credentials profile files. Sections named '[profile <name>]' are registered
+// as '<name>'. The key name is either the key within the selected profile or a dot separated path: <section>.<key>
+type parserINI struct {
+	content string
+	keyName string
+	profile string
+}
+
+func (p parserINI) getValue() (string, error) {
+	data, err := parseINI(p.content)
+	if err != nil {
+		return "", err
+	}
+	return lookupStructuredValue(data, p.keyName, p.profile)
+}
+
+func parseINI(content string) (map[string]interface{}, error) {
+	data := map[string]interface{}{}
+	section := data
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("invalid ini line %d: section header is missing the closing bracket", lineNumber)
+			}
+			sectionName := strings.TrimSpace(line[1 : len(line)-1])
+			sectionName = strings.TrimSpace(strings.TrimPrefix(sectionName, "profile "))
+			if _, exists := data[sectionName]; !exists {
+				data[sectionName] = map[string]interface{}{}
+			}
+			section = data[sectionName].(map[string]interface{})
+			continue
+		}
+		separator := strings.IndexAny(line, "=:")
+		if separator <= 0 {
+			return nil, fmt.Errorf("invalid ini line %d: expected key = value", lineNumber)
+		}
+		value, err := parseUnquotedOrQuotedValue(strings.TrimSpace(line[separator+1:]), ";#")
+		if err != nil {
+			return nil, fmt.Errorf("invalid ini line %d: %s", lineNumber, err)
+		}
+		section[strings.TrimSpace(line[:separator])] = value
+	}
+	return data, scanner.Err()
+}
+
+// parserTOML parses TOML documents. Only the subset of TOML used for configuration files is supported: tables, dotted
+// keys and string, integer, float and boolean values. The key name can be either a dot separated path or a JsonPath expression
+type parserTOML struct {
+	content string
+	keyName string
+	profile string
+}
+
+func (p parserTOML) getValue() (string, error) {
+	data, err := parseTOML(p.content)
+	if err != nil {
+		return "", err
+	}
+	return lookupStructuredValue(data, p.keyName, p.profile)
+}
+
+func parseTOML(content string) (map[string]interface{}, error) {
+	data := map[string]interface{}{}
+	table := data
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if strings.HasPrefix(line, "[[") {
+				return nil, fmt.Errorf("invalid toml line %d: array of tables are not supported", lineNumber)
+			}
+			end := indexOutsideQuotes(line, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid toml line %d: table header is missing the closing bracket", lineNumber)
+			}
+			keys, err := parseTOMLKey(line[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid toml line %d: %s", lineNumber, err)
+			}
+			table, err = getOrCreateTOMLTable(data, keys)
+			if err != nil {
+				return nil, fmt.Errorf("invalid toml line %d: %s", lineNumber, err)
+			}
+			continue
+		}
+		separator := indexOutsideQuotes(line, '=')
+		if separator <= 0 {
+			return nil, fmt.Errorf("invalid toml line %d: expected key = value", lineNumber)
+		}
+		value, err := parseTOMLValue(strings.TrimSpace(line[separator+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid toml line %d: %s", lineNumber, err)
+		}
+		keys, err := parseTOMLKey(line[:separator])
+		if err != nil {
+			return nil, fmt.Errorf("invalid toml line %d: %s", lineNumber, err)
+		}
+		parent, err := getOrCreateTOMLTable(table, keys[:len(keys)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid toml line %d: %s", lineNumber, err)
+		}
+		parent[keys[len(keys)-1]] = value
+	}
+	return data, scanner.Err()
+}
+
+// parseTOMLKey splits a dotted key into its parts. Dots inside quoted parts (e,g: "a.b") are part of the key name
+func parseTOMLKey(key string) ([]string, error) {
+	var keys []string
+	for {
+		key = strings.TrimSpace(key)
+		separator := indexOutsideQuotes(key, '.')
+		part := key
+		if separator >= 0 {
+			part = strings.TrimSpace(key[:separator])
+		}
+		if part == "" {
+			return nil, fmt.Errorf("invalid key '%s'", key)
+		}
+		if part[0] == '"' || part[0] == '\'' {
+			end := findClosingQuote(part)
+			if end != len(part)-1 {
+				return nil, fmt.Errorf("invalid quoted key '%s'", part)
+			}
+			unquotedPart, err := unquoteValue(part)
+			if err != nil {
+				return nil, err
+			}
+			part = unquotedPart
+		}
+		keys = append(keys, part)
+		if separator < 0 {
+			return keys, nil
+		}
+		key = key[separator+1:]
+	}
+}
+
+// indexOutsideQuotes returns the index of the first occurrence of the char that is not within a quoted string, -1 if not found
+func indexOutsideQuotes(value string, char byte) int {
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case char:
+			return i
+		case '"', '\'':
+			end := findClosingQuote(value[i:])
+			if end < 0 {
+				return -1
+			}
+			i += end
+		}
+	}
+	return -1
+}
+
+// findClosingQuote returns the index of the quote closing the quoted string the value starts with, -1 if not found.
+// Escaped quotes are skipped in double quoted strings whereas single quoted strings are taken literally
+func findClosingQuote(value string) int {
+	quote := value[0]
+	for i := 1; i < len(value); i++ {
+		switch {
+		case quote == '"' && value[i] == '\\':
+			i++
+		case value[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+func unquoteValue(quotedValue string) (string, error) {
+	if quotedValue[0] == '"' {
+		return strconv.Unquote(quotedValue)
+	}
+	return quotedValue[1 : len(quotedValue)-1], nil
+}
+
+func getOrCreateTOMLTable(data map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	table := data
+	for _, key := range keys {
+		if _, exists := table[key]; !exists {
+			table[key] = map[string]interface{}{}
+		}
+		t, ok := table[key].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("key '%s' is already defined as a value", key)
+		}
+		table = t
+	}
+	return table, nil
+}
+
+func parseTOMLValue(value string) (interface{}, error) {
+	if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'") {
+		return parseUnquotedOrQuotedValue(value, "#")
+	}
+	if i := strings.Index(value, "#"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	switch {
+	case value == "true" || value == "false":
+		return value == "true", nil
+	case strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{"):
+		return nil, fmt.Errorf("arrays and inline tables are not supported")
+	}
+	if i, err := strconv.ParseInt(strings.Replace(value, "_", "", -1), 10, 64); err == nil {
+		return i, nil
+	}
+	if f, err := strconv.ParseFloat(strings.Replace(value, "_", "", -1), 64); err == nil {
+		return f, nil
+	}
+	return nil, fmt.Errorf("value '%s' not supported", value)
+}
+
+// parseUnquotedOrQuotedValue returns the value removing the surrounding quotes if applicable. Double quoted values support
+// escape sequences whereas single quoted values are taken literally. Only an inline comment may follow the closing quote.
+// Unquoted values are trimmed at the first inline comment (any of the commentChars preceded by a whitespace)
+func parseUnquotedOrQuotedValue(value string, commentChars string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if value[0] == '"' || value[0] == '\'' {
+		end := findClosingQuote(value)
+		if end < 0 {
+			return "", fmt.Errorf("missing closing quote")
+		}
+		if rest := strings.TrimSpace(value[end+1:]); rest != "" && !strings.ContainsRune(commentChars, rune(rest[0])) {
+			return "", fmt.Errorf("unexpected characters after the closing quote: %s", rest)
+		}
+		return unquoteValue(value[:end+1])
+	}
+	for i := 1; i < len(value); i++ {
+		if strings.ContainsRune(commentChars, rune(value[i])) && (value[i-1] == ' ' || value[i-1] == '\t') {
+			return strings.TrimSpace(value[:i]), nil
+		}
+	}
+	return value, nil
+}
+
+// selectProfile returns the section of the data matching the profile. If the profile is empty the data is returned as is
+func selectProfile(data interface{}, profile string) (interface{}, error) {
+	if profile == "" {
+		return data, nil
+	}
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("profile '%s' not found", profile)
+	}
+	section, exists := m[profile]
+	if !exists {
+		return nil, fmt.Errorf("profile '%s' not found", profile)
+	}
+	return section, nil
+}
+
+// lookupStructuredValue returns the value of the key within the data (or the profile's section if a profile is provided).
+// The key name can be either a JsonPath expression (starting with '$') or a dot separated path
+func lookupStructuredValue(data map[string]interface{}, keyName, profile string) (string, error) {
+	if keyName == "" {
+		return "", fmt.Errorf("key_name is required to look up the value")
+	}
+	section, err := selectProfile(data, profile)
+	if err != nil {
+		return "", err
+	}
+	var value interface{}
+	if strings.HasPrefix(keyName, "$") {
+		value, err = jsonpath.JsonPathLookup(section, keyName)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		value, err = lookupDotSeparatedPath(section, keyName)
+		if err != nil {
+			return "", err
+		}
+	}
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case int, int64, float64, bool:
+		return fmt.Sprintf("%v", v), nil
+	default:
+		return "", fmt.Errorf("key '%s' does not contain a scalar value", keyName)
+	}
+}
+
+func lookupDotSeparatedPath(data interface{}, path string) (interface{}, error) {
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("key '%s' not found", path)
+	}
+	// keys containing dots take preference over the nested path
+	if value, exists := m[path]; exists {
+		return value, nil
+	}
+	parts := strings.SplitN(path, ".", 2)
+	value, exists := m[parts[0]]
+	if !exists {
+		return nil, fmt.Errorf("key '%s' not found", path)
+	}
+	if len(parts) == 1 {
+		return value, nil
+	}
+	return lookupDotSeparatedPath(value, parts[1])
+}
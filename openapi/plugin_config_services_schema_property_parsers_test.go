@@ -0,0 +1,253 @@
+package openapi
+
+import (
+	"fmt"
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestSchemaFileParserYAMLGetValue(t *testing.T) {
+	yamlContent := `
+default:
+  token: defaultToken
+  port: 8080
+production:
+  token: productionToken
+  nested:
+    secret: productionSecret
+`
+	Convey("Given a yaml content and a list of key names and profiles", t, func() {
+		testCases := []struct {
+			keyName       string
+			profile       string
+			expectedValue string
+			expectedError string
+		}{
+			{keyName: "default.token", expectedValue: "defaultToken"},
+			{keyName: "$.default.token", expectedValue: "defaultToken"},
+			{keyName: "token", profile: "production", expectedValue: "productionToken"},
+			{keyName: "nested.secret", profile: "production", expectedValue: "productionSecret"},
+			{keyName: "port", profile: "default", expectedValue: "8080"},
+			{keyName: "token", profile: "staging", expectedError: "profile 'staging' not found"},
+			{keyName: "nonExisting", profile: "production", expectedError: "key 'nonExisting' not found"},
+			{keyName: "nested", profile: "production", expectedError: "key 'nested' does not contain a scalar value"},
+			{keyName: "", profile: "production", expectedError: "key_name is required to look up the value"},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When getValue is called with key name '%s' and profile '%s'", tc.keyName, tc.profile), func() {
+				value, err := parserYAML{yamlContent: yamlContent, keyName: tc.keyName, profile: tc.profile}.getValue()
+				Convey("Then the value and error returned should be the expected ones", func() {
+					if tc.expectedError != "" {
+						So(err, ShouldNotBeNil)
+						So(err.Error(), ShouldEqual, tc.expectedError)
+					} else {
+						So(err, ShouldBeNil)
+						So(value, ShouldEqual, tc.expectedValue)
+					}
+				})
+			})
+		}
+	})
+
+	Convey("Given a yaml content that is not an object", t, func() {
+		Convey("When getValue is called", func() {
+			_, err := parserYAML{yamlContent: "- item", keyName: "key"}.getValue()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "yaml content is not an object")
+			})
+		})
+	})
+}
+
+func TestSchemaFileParserDotEnvGetValue(t *testing.T) {
+	dotEnvContent := `# some comment
+TOKEN=plainToken
+export EXPORTED_TOKEN=exportedToken
+QUOTED_TOKEN="quoted token\nwith new line"
+LITERAL_TOKEN='literal $token'
+COMMENTED_TOKEN=commentedToken # inline comment
+QUOTED_COMMENTED_TOKEN="quotedToken" # it's "commented"
+`
+	Convey("Given a dotenv content and a list of key names", t, func() {
+		testCases := []struct {
+			keyName       string
+			expectedValue string
+			expectedError string
+		}{
+			{keyName: "TOKEN", expectedValue: "plainToken"},
+			{keyName: "EXPORTED_TOKEN", expectedValue: "exportedToken"},
+			{keyName: "QUOTED_TOKEN", expectedValue: "quoted token\nwith new line"},
+			{keyName: "LITERAL_TOKEN", expectedValue: "literal $token"},
+			{keyName: "COMMENTED_TOKEN", expectedValue: "commentedToken"},
+			{keyName: "QUOTED_COMMENTED_TOKEN", expectedValue: "quotedToken"},
+			{keyName: "NON_EXISTING", expectedError: "key 'NON_EXISTING' not found"},
+			{keyName: "", expectedError: "key_name is required for dotenv content"},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When getValue is called with key name '%s'", tc.keyName), func() {
+				value, err := parserDotEnv{content: dotEnvContent, keyName: tc.keyName}.getValue()
+				Convey("Then the value and error returned should be the expected ones", func() {
+					if tc.expectedError != "" {
+						So(err, ShouldNotBeNil)
+						So(err.Error(), ShouldEqual, tc.expectedError)
+					} else {
+						So(err, ShouldBeNil)
+						So(value, ShouldEqual, tc.expectedValue)
+					}
+				})
+			})
+		}
+	})
+
+	Convey("Given a dotenv content with an invalid line", t, func() {
+		Convey("When getValue is called", func() {
+			_, err := parserDotEnv{content: "TOKEN=value\nINVALID", keyName: "TOKEN"}.getValue()
+			Convey("Then the error returned should point at the invalid line", func() {
+				So(err.Error(), ShouldEqual, "invalid dotenv line 2: expected KEY=VALUE")
+			})
+		})
+	})
+}
+
+func TestSchemaFileParserINIGetValue(t *testing.T) {
+	iniContent := `; credentials file
+[default]
+token = defaultToken
+
+[profile production]
+token: productionToken ; inline comment
+region = us-west-1
+`
+	Convey("Given an ini content and a list of key names and profiles", t, func() {
+		testCases := []struct {
+			keyName       string
+			profile       string
+			expectedValue string
+			expectedError string
+		}{
+			{keyName: "token", profile: "default", expectedValue: "defaultToken"},
+			{keyName: "token", profile: "production", expectedValue: "productionToken"},
+			{keyName: "production.region", expectedValue: "us-west-1"},
+			{keyName: "token", profile: "staging", expectedError: "profile 'staging' not found"},
+			{keyName: "secret", profile: "default", expectedError: "key 'secret' not found"},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When getValue is called with key name '%s' and profile '%s'", tc.keyName, tc.profile), func() {
+				value, err := parserINI{content: iniContent, keyName: tc.keyName, profile: tc.profile}.getValue()
+				Convey("Then the value and error returned should be the expected ones", func() {
+					if tc.expectedError != "" {
+						So(err, ShouldNotBeNil)
+						So(err.Error(), ShouldEqual, tc.expectedError)
+					} else {
+						So(err, ShouldBeNil)
+						So(value, ShouldEqual, tc.expectedValue)
+					}
+				})
+			})
+		}
+	})
+
+	Convey("Given an ini content with a section header missing the closing bracket", t, func() {
+		Convey("When getValue is called", func() {
+			_, err := parserINI{content: "[default\ntoken = value", keyName: "token", profile: "default"}.getValue()
+			Convey("Then the error returned should point at the invalid line", func() {
+				So(err.Error(), ShouldEqual, "invalid ini line 1: section header is missing the closing bracket")
+			})
+		})
+	})
+}
+
+func TestSchemaFileParserTOMLGetValue(t *testing.T) {
+	tomlContent := `# credentials file
+title = "credentials"
+
+[default]
+token = "defaultToken"
+port = 8_080
+enabled = true
+
+[production]
+token = 'productionToken' # inline comment
+ratio = 0.5
+nested.secret = "productionSecret"
+
+[production.database]
+password = "dbPassword"
+`
+	Convey("Given a toml content and a list of key names and profiles", t, func() {
+		testCases := []struct {
+			keyName       string
+			profile       string
+			expectedValue string
+			expectedError string
+		}{
+			{keyName: "title", expectedValue: "credentials"},
+			{keyName: "token", profile: "default", expectedValue: "defaultToken"},
+			{keyName: "port", profile: "default", expectedValue: "8080"},
+			{keyName: "enabled", profile: "default", expectedValue: "true"},
+			{keyName: "token", profile: "production", expectedValue: "productionToken"},
+			{keyName: "ratio", profile: "production", expectedValue: "0.5"},
+			{keyName: "nested.secret", profile: "production", expectedValue: "productionSecret"},
+			{keyName: "$.database.password", profile: "production", expectedValue: "dbPassword"},
+			{keyName: "production.database.password", expectedValue: "dbPassword"},
+			{keyName: "token", profile: "staging", expectedError: "profile 'staging' not found"},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When getValue is called with key name '%s' and profile '%s'", tc.keyName, tc.profile), func() {
+				value, err := parserTOML{content: tomlContent, keyName: tc.keyName, profile: tc.profile}.getValue()
+				Convey("Then the value and error returned should be the expected ones", func() {
+					if tc.expectedError != "" {
+						So(err, ShouldNotBeNil)
+						So(err.Error(), ShouldEqual, tc.expectedError)
+					} else {
+						So(err, ShouldBeNil)
+						So(value, ShouldEqual, tc.expectedValue)
+					}
+				})
+			})
+		}
+	})
+
+	Convey("Given a toml content with quoted keys containing dots and quoted values followed by comments containing quotes", t, func() {
+		content := `[regions."eu.west"]
+"api.token" = "say \"hi\"" # it's "quoted"
+'literal.key' = 'literal # value'`
+		Convey("When parseTOML is called", func() {
+			data, err := parseTOML(content)
+			Convey("Then the quoted keys should not be split on the dots and the values should be the expected ones", func() {
+				So(err, ShouldBeNil)
+				So(data, ShouldResemble, map[string]interface{}{
+					"regions": map[string]interface{}{
+						"eu.west": map[string]interface{}{
+							"api.token":   `say "hi"`,
+							"literal.key": "literal # value",
+						},
+					},
+				})
+			})
+		})
+	})
+
+	Convey("Given a list of toml contents not supported", t, func() {
+		testCases := []struct {
+			content       string
+			expectedError string
+		}{
+			{content: "[[products]]\nname = \"hammer\"", expectedError: "invalid toml line 1: array of tables are not supported"},
+			{content: "ports = [8000, 8001]", expectedError: "invalid toml line 1: arrays and inline tables are not supported"},
+			{content: "token = someValue", expectedError: "invalid toml line 1: value 'someValue' not supported"},
+			{content: "token = \"value\"\ntoken.nested = \"value\"", expectedError: "invalid toml line 2: key 'token' is already defined as a value"},
+			{content: "token = \"value\" trailing", expectedError: "invalid toml line 1: unexpected characters after the closing quote: trailing"},
+			{content: "token = \"value", expectedError: "invalid toml line 1: missing closing quote"},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When getValue is called with content '%s'", tc.content), func() {
+				_, err := parserTOML{content: tc.content, keyName: "token"}.getValue()
+				Convey("Then the error returned should be the expected one", func() {
+					So(err, ShouldNotBeNil)
+					So(err.Error(), ShouldEqual, tc.expectedError)
+				})
+			})
+		}
+	})
+}
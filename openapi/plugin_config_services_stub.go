@@ -1,5 +1,7 @@
 package openapi
 
+import "fmt"
+
 // ServiceConfigStub implements the ServiceConfiguration interface and can be used to simplify the creation of the ProviderOpenAPI
 // provider by calling the CreateSchemaProviderWithConfiguration function passing in the stub wit the swagger URL populated
 // with the URL where the openapi doc is hosted.
@@ -7,8 +9,10 @@ type ServiceConfigStub struct {
 	SwaggerURL          string
 	PluginVersion       string
 	InsecureSkipVerify  bool
-	Telemetry           TelemetryProvider
+	Telemetry           []TelemetryProvider
 	SchemaConfiguration []*ServiceSchemaPropertyConfigurationStub
+	Overrides           *ServiceOverridesV1
+	Tracing             *TracingConfig
 	Err                 error
 }
 
@@ -20,6 +24,16 @@ type ServiceSchemaPropertyConfigurationStub struct {
 	Err                  error
 	GetDefaultValueFunc  func() (string, error)
 	ExecuteCommandCalled bool
+	// CredentialHelperValue defines the value returned by GetCredentialHelperValue
+	CredentialHelperValue string
+	// CredentialHelperErr defines the error returned by GetCredentialHelperValue
+	CredentialHelperErr error
+	// CredentialHelperRequest keeps track of the request received by GetCredentialHelperValue
+	CredentialHelperRequest *CredentialHelperRequest
+	// ProfileSchemaPropertyName defines the value returned by GetProfileSchemaPropertyName
+	ProfileSchemaPropertyName string
+	// ProfileDefaultValues defines the values returned by GetDefaultValueForProfile keyed by profile
+	ProfileDefaultValues map[string]string
 }
 
 // GetSwaggerURL returns the swagger URL value configured in the ServiceConfigStub.SwaggerURL field
@@ -37,6 +51,16 @@ func (s *ServiceConfigStub) IsInsecureSkipVerifyEnabled() bool {
 	return s.InsecureSkipVerify
 }
 
+// GetOverrides returns the overrides configured in the ServiceConfigStub.Overrides field
+func (s *ServiceConfigStub) GetOverrides() *ServiceOverridesV1 {
+	return s.Overrides
+}
+
+// GetTracingConfiguration returns the tracing configuration configured in the ServiceConfigStub.Tracing field
+func (s *ServiceConfigStub) GetTracingConfiguration() *TracingConfig {
+	return s.Tracing
+}
+
 // Validate returns an error if the ServiceConfigStub.Err field is set with an error
 func (s *ServiceConfigStub) Validate(runningPluginVersion string) error {
 	return s.Err
@@ -52,8 +76,8 @@ func (s ServiceConfigStub) GetSchemaPropertyConfiguration(schemaPropertyName str
 	return nil
 }
 
-// GetTelemetryConfiguration returns the TelemetryProvider configured in the ServiceConfigStub
-func (s ServiceConfigStub) GetTelemetryConfiguration() TelemetryProvider {
+// GetTelemetryConfiguration returns the TelemetryProviders configured in the ServiceConfigStub
+func (s ServiceConfigStub) GetTelemetryConfiguration() []TelemetryProvider {
 	return s.Telemetry
 }
 
@@ -65,6 +89,28 @@ func (s *ServiceSchemaPropertyConfigurationStub) GetDefaultValue() (string, erro
 	return s.DefaultValue, nil
 }
 
+// GetCredentialHelperValue keeps track of the request received and returns the configured
+// ServiceSchemaPropertyConfigurationStub.CredentialHelperValue and ServiceSchemaPropertyConfigurationStub.CredentialHelperErr
+func (s *ServiceSchemaPropertyConfigurationStub) GetCredentialHelperValue(request CredentialHelperRequest) (string, error) {
+	s.CredentialHelperRequest = &request
+	return s.CredentialHelperValue, s.CredentialHelperErr
+}
+
+// GetProfileSchemaPropertyName returns the value configured in the ServiceSchemaPropertyConfigurationStub.ProfileSchemaPropertyName field
+func (s *ServiceSchemaPropertyConfigurationStub) GetProfileSchemaPropertyName() string {
+	return s.ProfileSchemaPropertyName
+}
+
+// GetDefaultValueForProfile returns the value configured for the profile in the ServiceSchemaPropertyConfigurationStub.ProfileDefaultValues
+// field or an error if the profile does not exist
+func (s *ServiceSchemaPropertyConfigurationStub) GetDefaultValueForProfile(profile string) (string, error) {
+	value, exists := s.ProfileDefaultValues[profile]
+	if !exists {
+		return "", fmt.Errorf("profile '%s' not found", profile)
+	}
+	return value, nil
+}
+
 // ExecuteCommand keeps track if the execute command method has been called and returns the configured err
 // ServiceSchemaPropertyConfigurationStub.ServiceSchemaPropertyConfigurationStub if set
 func (s *ServiceSchemaPropertyConfigurationStub) ExecuteCommand() error {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
 	"io/ioutil"
 	"log"
 	"os"
@@ -180,6 +181,71 @@ func TestServiceConfigV1Validate(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a ServiceConfigV1 containing overrides that are not valid", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		serviceConfiguration = &ServiceConfigV1{
+			SwaggerURL: "http://sevice-api.com/swagger.yaml",
+			Overrides: &ServiceOverridesV1{
+				Resources: []ServiceResourceOverrideV1{{Exclude: true}},
+			},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "service overrides configuration not valid: resource override 0 not valid: either path or resource must be provided")
+			})
+		})
+	})
+}
+
+func TestServiceConfigV1GetOverrides(t *testing.T) {
+	Convey("Given a plugin configuration containing overrides", t, func() {
+		pluginConfig := `version: '1'
+services:
+    cdn:
+        swagger-url: http://sevice-api.com/swagger.yaml
+        overrides:
+            resources:
+            - path: /v1/cdns
+              name: cdn
+              timeouts:
+                create: 10m
+              polling:
+                create:
+                  response_code: 202
+                  target_statuses: [deployed]
+                  pending_statuses: [deploying]
+              properties:
+                label:
+                  immutable: true
+            - resource: firewalls_v1
+              exclude: true`
+		pluginConfigV1 := PluginConfigSchemaV1{}
+		err := yaml.Unmarshal([]byte(pluginConfig), &pluginConfigV1)
+		So(err, ShouldBeNil)
+		Convey("When GetOverrides method is called", func() {
+			overrides := pluginConfigV1.Services["cdn"].GetOverrides()
+			Convey("Then the overrides returned should match the configuration", func() {
+				trueValue := true
+				So(overrides, ShouldResemble, &ServiceOverridesV1{
+					Resources: []ServiceResourceOverrideV1{
+						{
+							Path:     "/v1/cdns",
+							Name:     "cdn",
+							Timeouts: map[string]string{"create": "10m"},
+							Polling: map[string]ServiceResourcePollingOverrideV1{
+								"create": {ResponseCode: 202, TargetStatuses: []string{"deployed"}, PendingStatuses: []string{"deploying"}},
+							},
+							Properties: map[string]ServicePropertyOverrideV1{"label": {Immutable: &trueValue}},
+						},
+						{Resource: "firewalls_v1", Exclude: true},
+					},
+				})
+				So(overrides.Validate(), ShouldBeNil)
+			})
+		})
+	})
 }
 
 func TestGetTelemetryConfiguration(t *testing.T) {
@@ -187,7 +253,7 @@ func TestGetTelemetryConfiguration(t *testing.T) {
 		name            string
 		serviceConfigV1 *ServiceConfigV1
 		inputPluginName string
-		expectedType    interface{}
+		expectedTypes   []interface{}
 		expectedError   string
 		expectedLogging []string
 	}{
@@ -202,7 +268,7 @@ func TestGetTelemetryConfiguration(t *testing.T) {
 				},
 			},
 			inputPluginName: "pluginName",
-			expectedType:    &TelemetryProviderGraphite{},
+			expectedTypes:   []interface{}{&TelemetryProviderGraphite{}},
 			expectedLogging: []string{"[DEBUG] graphite telemetry provider enabled"},
 		},
 		{
@@ -215,8 +281,49 @@ func TestGetTelemetryConfiguration(t *testing.T) {
 				},
 			},
 			inputPluginName: "pluginName",
-			expectedType:    &TelemetryProviderHTTPEndpoint{},
-			expectedLogging: []string{"[DEBUG] http endpoint telemetry provider enabled"},
+			expectedTypes:   []interface{}{&TelemetryProviderHTTPEndpoint{}},
+			expectedLogging: []string{"[DEBUG] http_endpoint telemetry provider enabled"},
+		},
+		{
+			name: "service is configured correctly with a prometheus provider",
+			serviceConfigV1: &ServiceConfigV1{
+				TelemetryConfig: &TelemetryConfig{
+					Prometheus: &TelemetryProviderPrometheus{
+						PushgatewayURL: "http://pushgateway.myhost.com:9091",
+					},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedTypes:   []interface{}{&TelemetryProviderPrometheus{}},
+			expectedLogging: []string{"[DEBUG] prometheus telemetry provider enabled"},
+		},
+		{
+			name: "service is configured with graphite and prometheus providers",
+			serviceConfigV1: &ServiceConfigV1{
+				TelemetryConfig: &TelemetryConfig{
+					Graphite: &TelemetryProviderGraphite{
+						Host: "my-graphite.com",
+						Port: 8125,
+					},
+					Prometheus: &TelemetryProviderPrometheus{
+						PushgatewayURL: "http://pushgateway.myhost.com:9091",
+					},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedTypes:   []interface{}{&TelemetryProviderGraphite{}, &TelemetryProviderPrometheus{}},
+			expectedLogging: []string{"[DEBUG] graphite telemetry provider enabled", "[DEBUG] prometheus telemetry provider enabled"},
+		},
+		{
+			name: "service skips prometheus telemetry due to the validation not passing",
+			serviceConfigV1: &ServiceConfigV1{
+				TelemetryConfig: &TelemetryConfig{
+					Prometheus: &TelemetryProviderPrometheus{},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedTypes:   nil,
+			expectedLogging: []string{"[WARN] ignoring prometheus telemetry due to the following validation error: prometheus telemetry configuration is missing a value for either the 'pushgateway_url' or the 'textfile_directory' property"},
 		},
 		{
 			name: "service is configured correctly with graphite and httpendpoint providers",
@@ -232,8 +339,8 @@ func TestGetTelemetryConfiguration(t *testing.T) {
 				},
 			},
 			inputPluginName: "pluginName",
-			expectedType:    nil,
-			expectedLogging: []string{"[WARN] ignoring telemetry due multiple telemetry providers configured (graphite and http_endpoint): select only one"},
+			expectedTypes:   []interface{}{&TelemetryProviderGraphite{}, &TelemetryProviderHTTPEndpoint{}},
+			expectedLogging: []string{"[DEBUG] graphite telemetry provider enabled", "[DEBUG] http_endpoint telemetry provider enabled"},
 		},
 		{
 			name: "service skips graphite telemetry due to the validation not passing",
@@ -246,7 +353,7 @@ func TestGetTelemetryConfiguration(t *testing.T) {
 				},
 			},
 			inputPluginName: "pluginName",
-			expectedType:    nil,
+			expectedTypes:   nil,
 			expectedLogging: []string{"[WARN] ignoring graphite telemetry due to the following validation error: graphite telemetry configuration is missing a value for the 'host property'"},
 		},
 		{
@@ -259,8 +366,67 @@ func TestGetTelemetryConfiguration(t *testing.T) {
 				},
 			},
 			inputPluginName: "pluginName",
-			expectedType:    nil,
-			expectedLogging: []string{"[WARN] ignoring http endpoint telemetry due to the following validation error: http endpoint telemetry configuration is missing a value for the 'url property'"},
+			expectedTypes:   nil,
+			expectedLogging: []string{"[WARN] ignoring http_endpoint telemetry due to the following validation error: http endpoint telemetry configuration is missing a value for the 'url property'"},
+		},
+		{
+			name: "service is configured with a list of telemetry providers",
+			serviceConfigV1: &ServiceConfigV1{
+				TelemetryConfig: &TelemetryConfig{
+					Providers: []TelemetryProviderConfigV1{
+						{
+							Graphite: &TelemetryProviderGraphite{
+								Host: "my-graphite.com",
+								Port: 8125,
+							},
+							Timeout: 1,
+						},
+						{
+							HTTPEndpoint: &TelemetryProviderHTTPEndpoint{
+								URL: "http://telemetry.myhost.com/v1/metrics",
+							},
+						},
+					},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedTypes:   []interface{}{telemetryProviderWithTimeout{}, telemetryProviderWithTimeout{}},
+			expectedLogging: []string{"[DEBUG] graphite telemetry provider enabled", "[DEBUG] http_endpoint telemetry provider enabled"},
+		},
+		{
+			name: "service skips the telemetry providers items that are not valid",
+			serviceConfigV1: &ServiceConfigV1{
+				TelemetryConfig: &TelemetryConfig{
+					Providers: []TelemetryProviderConfigV1{
+						{
+							Graphite: &TelemetryProviderGraphite{
+								Host: "my-graphite.com",
+								Port: 8125,
+							},
+							HTTPEndpoint: &TelemetryProviderHTTPEndpoint{
+								URL: "http://telemetry.myhost.com/v1/metrics",
+							},
+						},
+						{
+							Prometheus: &TelemetryProviderPrometheus{
+								PushgatewayURL: "http://pushgateway.myhost.com:9091",
+							},
+							Timeout: -1,
+						},
+						{
+							Prometheus: &TelemetryProviderPrometheus{},
+						},
+					},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedTypes:   nil,
+			expectedLogging: []string{
+				"[WARN] ignoring telemetry providers item 0 due to the following validation error: telemetry providers item must have exactly one telemetry provider configured (graphite, http_endpoint or prometheus), found 2",
+				"[WARN] ignoring telemetry providers item 1 due to the following validation error: telemetry provider timeout '-1' must be a positive number",
+				"[WARN] ignoring prometheus telemetry due to the following validation error: prometheus telemetry configuration is missing a value for either the 'pushgateway_url' or the 'textfile_directory' property",
+				"[DEBUG] telemetry not configured",
+			},
 		},
 		{
 			name: "TelemetryConfig is nil",
@@ -268,17 +434,61 @@ func TestGetTelemetryConfiguration(t *testing.T) {
 				TelemetryConfig: nil,
 			},
 			inputPluginName: "pluginName",
-			expectedType:    nil,
+			expectedTypes:   nil,
 			expectedLogging: []string{"[DEBUG] telemetry not configured"},
 		},
 	}
 	for _, tc := range testCases {
 		var buf bytes.Buffer
 		log.SetOutput(&buf)
-		telemetryHandler := tc.serviceConfigV1.GetTelemetryConfiguration()
-		assert.IsType(t, tc.expectedType, telemetryHandler, tc.name)
+		telemetryProviders := tc.serviceConfigV1.GetTelemetryConfiguration()
+		assert.Len(t, telemetryProviders, len(tc.expectedTypes), tc.name)
+		for idx, telemetryProvider := range telemetryProviders {
+			assert.IsType(t, tc.expectedTypes[idx], telemetryProvider, tc.name)
+		}
 		for _, log := range tc.expectedLogging {
 			assert.Contains(t, buf.String(), log, tc.name)
 		}
 	}
 }
+
+func TestGetTracingConfiguration(t *testing.T) {
+	Convey("Given a list of service configurations", t, func() {
+		testCases := []struct {
+			name            string
+			serviceConfigV1 *ServiceConfigV1
+			expectedTracing *TracingConfig
+			expectedLogging string
+		}{
+			{
+				name:            "service configured with a valid tracing configuration",
+				serviceConfigV1: &ServiceConfigV1{Tracing: &TracingConfig{Endpoint: "http://otel-collector:4318"}},
+				expectedTracing: &TracingConfig{Endpoint: "http://otel-collector:4318"},
+				expectedLogging: "[DEBUG] tracing enabled",
+			},
+			{
+				name:            "service configured with a tracing configuration that is not valid",
+				serviceConfigV1: &ServiceConfigV1{Tracing: &TracingConfig{}},
+				expectedTracing: nil,
+				expectedLogging: "[WARN] ignoring tracing due to the following validation error: tracing configuration is missing a value for the 'endpoint' property",
+			},
+			{
+				name:            "service not configured with tracing",
+				serviceConfigV1: &ServiceConfigV1{},
+				expectedTracing: nil,
+				expectedLogging: "[DEBUG] tracing not configured",
+			},
+		}
+		for _, tc := range testCases {
+			Convey("When GetTracingConfiguration is called for a "+tc.name, func() {
+				var buf bytes.Buffer
+				log.SetOutput(&buf)
+				tracingConfig := tc.serviceConfigV1.GetTracingConfiguration()
+				Convey("Then the tracing configuration returned and the logging should be the expected ones", func() {
+					So(tracingConfig, ShouldResemble, tc.expectedTracing)
+					So(buf.String(), ShouldContainSubstring, tc.expectedLogging)
+				})
+			})
+		}
+	})
+}
@@ -1,6 +1,11 @@
 package openapi
 
-import "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
 
 // TelemetryProviderConfiguration defines the struct type that specific telemetry providers can configure based on the
 // resource data received in GetTelemetryProviderConfiguration. The struct serves as a way to document in the metric
@@ -24,8 +29,31 @@ const (
 	TelemetryResourceOperationImport TelemetryResourceOperation = "import"
 )
 
-// TelemetryProvider holds the behaviour expected to be implemented for the Telemetry Providers supported. At the moment
-// only Graphite is supported.
+// TelemetryResourceOperationOutcome defines the outcome of the resource operation (CRUD) to be used in the telemetry metric
+type TelemetryResourceOperationOutcome string
+
+const (
+	// TelemetryResourceOperationOutcomeSuccess represents an operation that completed successfully
+	TelemetryResourceOperationOutcomeSuccess TelemetryResourceOperationOutcome = "success"
+	// TelemetryResourceOperationOutcomeFailure represents an operation that returned an error
+	TelemetryResourceOperationOutcomeFailure TelemetryResourceOperationOutcome = "failure"
+)
+
+// newTelemetryResourceOperationOutcome returns the outcome of an operation based on the error returned by the operation
+func newTelemetryResourceOperationOutcome(err error) TelemetryResourceOperationOutcome {
+	if err != nil {
+		return TelemetryResourceOperationOutcomeFailure
+	}
+	return TelemetryResourceOperationOutcomeSuccess
+}
+
+// getHTTPStatusClass returns the class of the given HTTP status code (e,g: 2xx, 4xx)
+func getHTTPStatusClass(statusCode int) string {
+	return fmt.Sprintf("%dxx", statusCode/100)
+}
+
+// TelemetryProvider holds the behaviour expected to be implemented for the Telemetry Providers supported (Graphite, HTTP
+// endpoint and Prometheus).
 type TelemetryProvider interface {
 	// Validate performs a check to confirm that the telemetry configuration is valid
 	Validate() error
@@ -34,6 +62,46 @@ type TelemetryProvider interface {
 	// IncServiceProviderResourceTotalRunsCounter is the method responsible for submitting to the corresponding telemetry platform the counter increase for service provider used along
 	// with tags for provider name, resource name, and Terraform operation
 	IncServiceProviderResourceTotalRunsCounter(providerName, resourceName string, tfOperation TelemetryResourceOperation, telemetryProviderConfiguration TelemetryProviderConfiguration) error
+	// TimingServiceProviderResourceOperationDuration is the method responsible for submitting to the corresponding telemetry platform how long a
+	// resource operation took along with tags for provider name, resource name, Terraform operation and outcome (success/failure)
+	TimingServiceProviderResourceOperationDuration(providerName, resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome, duration time.Duration, telemetryProviderConfiguration TelemetryProviderConfiguration) error
+	// IncServiceProviderResourceHTTPStatusClassCounter is the method responsible for submitting to the corresponding telemetry platform the counter
+	// increase for the HTTP status class (e,g: 2xx, 4xx, 5xx) returned by the API along with tags for provider name, resource name, and Terraform operation
+	IncServiceProviderResourceHTTPStatusClassCounter(providerName, resourceName string, tfOperation TelemetryResourceOperation, statusClass string, telemetryProviderConfiguration TelemetryProviderConfiguration) error
+	// TimingServiceProviderResourcePollingWait is the method responsible for submitting to the corresponding telemetry platform how long the
+	// provider waited for a resource to reach a completion status along with tags for provider name, resource name, and Terraform operation
+	TimingServiceProviderResourcePollingWait(providerName, resourceName string, tfOperation TelemetryResourceOperation, duration time.Duration, telemetryProviderConfiguration TelemetryProviderConfiguration) error
 	// GetTelemetryProviderConfiguration is the method responsible for getting a specific telemetry provider config given the input data provided
 	GetTelemetryProviderConfiguration(data *schema.ResourceData) TelemetryProviderConfiguration
 }
+
+// telemetryProviderWithTimeout wraps a TelemetryProvider configured with a specific submission timeout (in seconds)
+type telemetryProviderWithTimeout struct {
+	TelemetryProvider
+	timeout int
+}
+
+// getTelemetryProviderTimeout returns the submission timeout (in seconds) configured for the telemetry provider. The
+// default timeout is returned if the telemetry provider does not have a specific timeout configured
+func getTelemetryProviderTimeout(telemetryProvider TelemetryProvider, defaultTimeout int) int {
+	if tp, ok := telemetryProvider.(telemetryProviderWithTimeout); ok && tp.timeout > 0 {
+		return tp.timeout
+	}
+	return defaultTimeout
+}
+
+// getTelemetryProviderName returns a human readable name of the telemetry provider to be used in the logs
+func getTelemetryProviderName(telemetryProvider TelemetryProvider) string {
+	if tp, ok := telemetryProvider.(telemetryProviderWithTimeout); ok {
+		return getTelemetryProviderName(tp.TelemetryProvider)
+	}
+	switch telemetryProvider.(type) {
+	case *TelemetryProviderGraphite, TelemetryProviderGraphite:
+		return "graphite"
+	case *TelemetryProviderHTTPEndpoint, TelemetryProviderHTTPEndpoint:
+		return "http_endpoint"
+	case *TelemetryProviderPrometheus, TelemetryProviderPrometheus:
+		return "prometheus"
+	}
+	return fmt.Sprintf("%T", telemetryProvider)
+}
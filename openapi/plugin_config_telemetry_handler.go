@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"log"
+	"net/http"
+	"sync"
 	"time"
 )
 
@@ -17,45 +19,91 @@ type TelemetryHandler interface {
 	SubmitPluginExecutionMetrics()
 	// SubmitResourceExecutionMetrics submits the metrics related to resource operation execution
 	SubmitResourceExecutionMetrics(resourceName string, tfOperation TelemetryResourceOperation)
+	// SubmitResourceOperationDurationMetrics submits the metrics related to the duration and outcome of a resource operation
+	SubmitResourceOperationDurationMetrics(resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome, duration time.Duration)
+	// SubmitResourceHTTPStatusMetrics submits the metrics related to the HTTP status code returned by the API for a resource operation
+	SubmitResourceHTTPStatusMetrics(resourceName string, tfOperation TelemetryResourceOperation, statusCode int)
+	// SubmitResourcePollingWaitMetrics submits the metrics related to the time spent waiting for a resource to reach a completion status
+	SubmitResourcePollingWaitMetrics(resourceName string, tfOperation TelemetryResourceOperation, duration time.Duration)
 }
 
 const telemetryTimeout = 2
 
 type telemetryHandlerTimeoutSupport struct {
-	timeout           int
-	providerName      string
-	openAPIVersion    string
-	telemetryProvider TelemetryProvider
-	data              *schema.ResourceData
+	// timeout is the default time in seconds to wait for a metric submission to complete, telemetry providers configured
+	// with a specific timeout will use theirs instead
+	timeout            int
+	providerName       string
+	openAPIVersion     string
+	telemetryProviders []TelemetryProvider
+	data               *schema.ResourceData
 }
 
 // MetricSubmitter is the function holding the logic that actually submits the metric
 type MetricSubmitter func() error
 
+// telemetryProviderMetricSubmitter is the function holding the logic that submits the metric to the given telemetry provider
+type telemetryProviderMetricSubmitter func(telemetryProvider TelemetryProvider, telemetryConfig TelemetryProviderConfiguration) error
+
 func (t telemetryHandlerTimeoutSupport) SubmitPluginExecutionMetrics() {
-	if t.telemetryProvider == nil {
-		log.Println("[INFO] Telemetry provider not configured")
-		return
-	}
-	telemetryConfig := t.telemetryProvider.GetTelemetryProviderConfiguration(t.data)
-	t.submitMetric("IncOpenAPIPluginVersionTotalRunsCounter", func() error {
-		return t.telemetryProvider.IncOpenAPIPluginVersionTotalRunsCounter(t.openAPIVersion, telemetryConfig)
+	t.submitMetric("IncOpenAPIPluginVersionTotalRunsCounter", func(telemetryProvider TelemetryProvider, telemetryConfig TelemetryProviderConfiguration) error {
+		return telemetryProvider.IncOpenAPIPluginVersionTotalRunsCounter(t.openAPIVersion, telemetryConfig)
 	})
 }
 
 func (t telemetryHandlerTimeoutSupport) SubmitResourceExecutionMetrics(resourceName string, tfOperation TelemetryResourceOperation) {
-	if t.telemetryProvider == nil {
+	t.submitMetric("IncServiceProviderResourceTotalRunsCounter", func(telemetryProvider TelemetryProvider, telemetryConfig TelemetryProviderConfiguration) error {
+		return telemetryProvider.IncServiceProviderResourceTotalRunsCounter(t.providerName, resourceName, tfOperation, telemetryConfig)
+	})
+}
+
+func (t telemetryHandlerTimeoutSupport) SubmitResourceOperationDurationMetrics(resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome, duration time.Duration) {
+	t.submitMetric("TimingServiceProviderResourceOperationDuration", func(telemetryProvider TelemetryProvider, telemetryConfig TelemetryProviderConfiguration) error {
+		return telemetryProvider.TimingServiceProviderResourceOperationDuration(t.providerName, resourceName, tfOperation, outcome, duration, telemetryConfig)
+	})
+}
+
+func (t telemetryHandlerTimeoutSupport) SubmitResourceHTTPStatusMetrics(resourceName string, tfOperation TelemetryResourceOperation, statusCode int) {
+	t.submitMetric("IncServiceProviderResourceHTTPStatusClassCounter", func(telemetryProvider TelemetryProvider, telemetryConfig TelemetryProviderConfiguration) error {
+		return telemetryProvider.IncServiceProviderResourceHTTPStatusClassCounter(t.providerName, resourceName, tfOperation, getHTTPStatusClass(statusCode), telemetryConfig)
+	})
+}
+
+func (t telemetryHandlerTimeoutSupport) SubmitResourcePollingWaitMetrics(resourceName string, tfOperation TelemetryResourceOperation, duration time.Duration) {
+	t.submitMetric("TimingServiceProviderResourcePollingWait", func(telemetryProvider TelemetryProvider, telemetryConfig TelemetryProviderConfiguration) error {
+		return telemetryProvider.TimingServiceProviderResourcePollingWait(t.providerName, resourceName, tfOperation, duration, telemetryConfig)
+	})
+}
+
+// submitMetric submits the metric to all the telemetry providers concurrently and waits till all the submissions have
+// either completed or timed out. Each telemetry provider has its own timeout so a slow telemetry provider does not affect
+// the others
+func (t telemetryHandlerTimeoutSupport) submitMetric(metricName string, metricSubmitter telemetryProviderMetricSubmitter) {
+	if len(t.telemetryProviders) == 0 {
 		log.Println("[INFO] Telemetry provider not configured")
 		return
 	}
-	telemetryConfig := t.telemetryProvider.GetTelemetryProviderConfiguration(t.data)
-	t.submitMetric("IncServiceProviderResourceTotalRunsCounter", func() error {
-		return t.telemetryProvider.IncServiceProviderResourceTotalRunsCounter(t.providerName, resourceName, tfOperation, telemetryConfig)
-	})
+	var wg sync.WaitGroup
+	for _, telemetryProvider := range t.telemetryProviders {
+		// the telemetry provider configuration is read before spawning the go routine since the resource data is not safe for concurrent use
+		telemetryConfig := telemetryProvider.GetTelemetryProviderConfiguration(t.data)
+		wg.Add(1)
+		go func(telemetryProvider TelemetryProvider, telemetryConfig TelemetryProviderConfiguration) {
+			defer wg.Done()
+			timeout := getTelemetryProviderTimeout(telemetryProvider, t.timeout)
+			submitProviderMetric(getTelemetryProviderName(telemetryProvider), metricName, timeout, func() error {
+				return metricSubmitter(telemetryProvider, telemetryConfig)
+			})
+		}(telemetryProvider, telemetryConfig)
+	}
+	wg.Wait()
 }
 
-func (t telemetryHandlerTimeoutSupport) submitMetric(metricName string, metricSubmitter MetricSubmitter) {
-	doneChan := make(chan error)
+// submitProviderMetric submits the metric using the metric submitter, if the submission does not complete within the
+// timeout (in seconds) the plugin will continue its execution
+func submitProviderMetric(telemetryProviderName, metricName string, timeout int, metricSubmitter MetricSubmitter) {
+	// the channel is buffered so the go routine does not leak if the submission times out
+	doneChan := make(chan error, 1)
 	go func() {
 		doneChan <- metricSubmitter()
 	}()
@@ -63,10 +111,10 @@ func (t telemetryHandlerTimeoutSupport) submitMetric(metricName string, metricSu
 	select {
 	case err := <-doneChan:
 		if err != nil {
-			log.Printf("metric '%s' submission failed: %s", metricName, err)
+			log.Printf("metric '%s' submission to %s telemetry provider failed: %s", metricName, telemetryProviderName, err)
 		}
-	case <-time.After(time.Duration(t.timeout) * time.Second):
-		log.Printf("metric '%s' submission did not finish within the expected time %ds", metricName, t.timeout)
+	case <-time.After(time.Duration(timeout) * time.Second):
+		log.Printf("metric '%s' submission to %s telemetry provider did not finish within the expected time %ds", metricName, telemetryProviderName, timeout)
 	}
 }
 
@@ -85,3 +133,42 @@ func submitTelemetryMetric(providerClient ClientOpenAPI, tfOperation TelemetryRe
 func submitTelemetryMetricDataSource(providerClient ClientOpenAPI, tfOperation TelemetryResourceOperation, resourceName string) {
 	submitTelemetryMetric(providerClient, tfOperation, resourceName, "data_")
 }
+
+// getTelemetryHandler returns the telemetry handler of the provider client; nil is returned if the client or the resource
+// name are not available
+func getTelemetryHandler(providerClient ClientOpenAPI, resourceName string) TelemetryHandler {
+	if providerClient == nil || resourceName == "" {
+		return nil
+	}
+	return providerClient.GetTelemetryHandler()
+}
+
+// submitTelemetryOperationDurationMetric submits the duration of the operation started at the given time along with its
+// outcome. The error pointer is expected to point to the error returned by the operation, so this function can be deferred
+func submitTelemetryOperationDurationMetric(providerClient ClientOpenAPI, tfOperation TelemetryResourceOperation, resourceName string, prefix string, startTime time.Time, err *error) {
+	if telemetryHandler := getTelemetryHandler(providerClient, resourceName); telemetryHandler != nil {
+		var operationErr error
+		if err != nil {
+			operationErr = *err
+		}
+		telemetryHandler.SubmitResourceOperationDurationMetrics(fmt.Sprintf("%s%s", prefix, resourceName), tfOperation, newTelemetryResourceOperationOutcome(operationErr), time.Since(startTime))
+	}
+}
+
+// submitTelemetryHTTPStatusMetric submits the HTTP status class of the response returned by the API, nothing is submitted
+// if the response is nil (e,g: the request failed)
+func submitTelemetryHTTPStatusMetric(providerClient ClientOpenAPI, tfOperation TelemetryResourceOperation, resourceName string, prefix string, res *http.Response) {
+	if res == nil {
+		return
+	}
+	if telemetryHandler := getTelemetryHandler(providerClient, resourceName); telemetryHandler != nil {
+		telemetryHandler.SubmitResourceHTTPStatusMetrics(fmt.Sprintf("%s%s", prefix, resourceName), tfOperation, res.StatusCode)
+	}
+}
+
+// submitTelemetryPollingWaitMetric submits the time spent waiting for the resource to reach a completion status
+func submitTelemetryPollingWaitMetric(providerClient ClientOpenAPI, tfOperation TelemetryResourceOperation, resourceName string, duration time.Duration) {
+	if telemetryHandler := getTelemetryHandler(providerClient, resourceName); telemetryHandler != nil {
+		telemetryHandler.SubmitResourcePollingWaitMetrics(resourceName, tfOperation, duration)
+	}
+}
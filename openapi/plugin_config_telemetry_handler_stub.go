@@ -1,14 +1,41 @@
 package openapi
 
+import "time"
+
 type telemetryHandlerStub struct {
-	submitPluginExecutionMetricsFunc   func()
-	submitResourceExecutionMetricsFunc func(resourceName string, tfOperation TelemetryResourceOperation)
+	submitPluginExecutionMetricsFunc           func()
+	submitResourceExecutionMetricsFunc         func(resourceName string, tfOperation TelemetryResourceOperation)
+	submitResourceOperationDurationMetricsFunc func(resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome, duration time.Duration)
+	submitResourceHTTPStatusMetricsFunc        func(resourceName string, tfOperation TelemetryResourceOperation, statusCode int)
+	submitResourcePollingWaitMetricsFunc       func(resourceName string, tfOperation TelemetryResourceOperation, duration time.Duration)
 }
 
 func (t *telemetryHandlerStub) SubmitPluginExecutionMetrics() {
-	t.submitPluginExecutionMetricsFunc()
+	if t.submitPluginExecutionMetricsFunc != nil {
+		t.submitPluginExecutionMetricsFunc()
+	}
 }
 
 func (t *telemetryHandlerStub) SubmitResourceExecutionMetrics(resourceName string, tfOperation TelemetryResourceOperation) {
-	t.submitResourceExecutionMetricsFunc(resourceName, tfOperation)
+	if t.submitResourceExecutionMetricsFunc != nil {
+		t.submitResourceExecutionMetricsFunc(resourceName, tfOperation)
+	}
+}
+
+func (t *telemetryHandlerStub) SubmitResourceOperationDurationMetrics(resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome, duration time.Duration) {
+	if t.submitResourceOperationDurationMetricsFunc != nil {
+		t.submitResourceOperationDurationMetricsFunc(resourceName, tfOperation, outcome, duration)
+	}
+}
+
+func (t *telemetryHandlerStub) SubmitResourceHTTPStatusMetrics(resourceName string, tfOperation TelemetryResourceOperation, statusCode int) {
+	if t.submitResourceHTTPStatusMetricsFunc != nil {
+		t.submitResourceHTTPStatusMetricsFunc(resourceName, tfOperation, statusCode)
+	}
+}
+
+func (t *telemetryHandlerStub) SubmitResourcePollingWaitMetrics(resourceName string, tfOperation TelemetryResourceOperation, duration time.Duration) {
+	if t.submitResourcePollingWaitMetricsFunc != nil {
+		t.submitResourcePollingWaitMetricsFunc(resourceName, tfOperation, duration)
+	}
 }
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"log"
+	"net/http"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -12,10 +15,10 @@ import (
 func TestSubmitPluginExecutionMetrics(t *testing.T) {
 	stub := &telemetryProviderStub{}
 	ths := telemetryHandlerTimeoutSupport{
-		providerName:      "providerName",
-		timeout:           1,
-		openAPIVersion:    "0.25.0",
-		telemetryProvider: stub,
+		providerName:       "providerName",
+		timeout:            1,
+		openAPIVersion:     "0.25.0",
+		telemetryProviders: []TelemetryProvider{stub},
 	}
 	ths.SubmitPluginExecutionMetrics()
 	// The below confirm that the corresponding inc methods were called and also the info passed in was the correct one
@@ -26,10 +29,10 @@ func TestSubmitPluginExecutionMetrics_FailsNilTelemetryProvider(t *testing.T) {
 	var buf bytes.Buffer
 	log.SetOutput(&buf)
 	ths := telemetryHandlerTimeoutSupport{
-		providerName:      "providerName",
-		timeout:           1,
-		openAPIVersion:    "0.25.0",
-		telemetryProvider: nil,
+		providerName:       "providerName",
+		timeout:            1,
+		openAPIVersion:     "0.25.0",
+		telemetryProviders: nil,
 	}
 	ths.SubmitPluginExecutionMetrics()
 	assert.Contains(t, buf.String(), "[INFO] Telemetry provider not configured")
@@ -40,10 +43,10 @@ func TestSubmitResourceExecutionMetrics(t *testing.T) {
 	expectedTfOperation := TelemetryResourceOperationCreate
 	stub := &telemetryProviderStub{}
 	ths := telemetryHandlerTimeoutSupport{
-		providerName:      "providerName",
-		timeout:           1,
-		openAPIVersion:    "0.25.0",
-		telemetryProvider: stub,
+		providerName:       "providerName",
+		timeout:            1,
+		openAPIVersion:     "0.25.0",
+		telemetryProviders: []TelemetryProvider{stub},
 	}
 	ths.SubmitResourceExecutionMetrics(expectedResourceName, expectedTfOperation)
 	// The below confirm that the corresponding inc methods were called and also the info passed in was the correct one
@@ -56,28 +59,26 @@ func TestSubmitResourceExecutionMetrics_FailsNilTelemetryProvider(t *testing.T)
 	var buf bytes.Buffer
 	log.SetOutput(&buf)
 	ths := telemetryHandlerTimeoutSupport{
-		providerName:      "providerName",
-		timeout:           1,
-		openAPIVersion:    "0.25.0",
-		telemetryProvider: nil,
+		providerName:       "providerName",
+		timeout:            1,
+		openAPIVersion:     "0.25.0",
+		telemetryProviders: nil,
 	}
 	ths.SubmitResourceExecutionMetrics("resourceName", TelemetryResourceOperationCreate)
 	assert.Contains(t, buf.String(), "[INFO] Telemetry provider not configured")
 }
 
-func TestSubmitMetric(t *testing.T) {
+func TestSubmitProviderMetric(t *testing.T) {
 	testCases := []struct {
 		name                 string
-		ths                  telemetryHandlerTimeoutSupport
+		timeout              int
 		inputMetricName      string
 		inputMetricSubmitter func() error
 		expectedLogging      string
 	}{
 		{
-			name: "submitMetric method is called with a metric name and a metric submitter that runs before the timeout",
-			ths: telemetryHandlerTimeoutSupport{
-				timeout: 1,
-			},
+			name:            "submitProviderMetric method is called with a metric name and a metric submitter that runs before the timeout",
+			timeout:         1,
 			inputMetricName: "someMetricName",
 			inputMetricSubmitter: func() error {
 				return nil
@@ -85,37 +86,78 @@ func TestSubmitMetric(t *testing.T) {
 			expectedLogging: "",
 		},
 		{
-			name: "submitMetric method is called with a metric name and a metric submitter timeout",
-			ths: telemetryHandlerTimeoutSupport{
-				timeout: 0,
-			},
+			name:            "submitProviderMetric method is called with a metric name and a metric submitter timeout",
+			timeout:         0,
 			inputMetricName: "someMetricName",
 			inputMetricSubmitter: func() error {
 				time.Sleep(2 * time.Second)
 				return nil
 			},
-			expectedLogging: "metric 'someMetricName' submission did not finish within the expected time 0s\n",
+			expectedLogging: "metric 'someMetricName' submission to graphite telemetry provider did not finish within the expected time 0s\n",
 		},
 		{
-			name: "submitMetric method is called with a metric name and a metric submitter errors out",
-			ths: telemetryHandlerTimeoutSupport{
-				timeout: 1,
-			},
+			name:            "submitProviderMetric method is called with a metric name and a metric submitter errors out",
+			timeout:         1,
 			inputMetricName: "someMetricName",
 			inputMetricSubmitter: func() error {
 				return errors.New("some error")
 			},
-			expectedLogging: "metric 'someMetricName' submission failed: some error",
+			expectedLogging: "metric 'someMetricName' submission to graphite telemetry provider failed: some error",
 		},
 	}
 	for _, tc := range testCases {
 		var buf bytes.Buffer
 		log.SetOutput(&buf)
-		tc.ths.submitMetric(tc.inputMetricName, tc.inputMetricSubmitter)
+		submitProviderMetric("graphite", tc.inputMetricName, tc.timeout, tc.inputMetricSubmitter)
 		assert.Contains(t, buf.String(), tc.expectedLogging, tc.name)
 	}
 }
 
+func TestSubmitMetric_MultipleTelemetryProviders(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	fastProvider := &telemetryProviderStub{}
+	slowProvider := telemetryProviderWithTimeout{TelemetryProvider: &telemetryProviderStub{}, timeout: 1}
+	ths := telemetryHandlerTimeoutSupport{
+		providerName:       "providerName",
+		timeout:            5,
+		openAPIVersion:     "0.25.0",
+		telemetryProviders: []TelemetryProvider{slowProvider, fastProvider},
+	}
+	var mutex sync.Mutex
+	var submittedTo []TelemetryProvider
+	start := time.Now()
+	ths.submitMetric("someMetricName", func(telemetryProvider TelemetryProvider, telemetryConfig TelemetryProviderConfiguration) error {
+		if telemetryProvider == TelemetryProvider(slowProvider) {
+			time.Sleep(3 * time.Second)
+		}
+		mutex.Lock()
+		defer mutex.Unlock()
+		submittedTo = append(submittedTo, telemetryProvider)
+		return nil
+	})
+	// The slow provider times out after its own timeout (1s) instead of the default one (5s) and does not block the fast provider
+	assert.True(t, time.Since(start) < 3*time.Second)
+	mutex.Lock()
+	assert.Equal(t, []TelemetryProvider{fastProvider}, submittedTo)
+	mutex.Unlock()
+	assert.Contains(t, buf.String(), "metric 'someMetricName' submission to *openapi.telemetryProviderStub telemetry provider did not finish within the expected time 1s")
+}
+
+func TestSubmitPluginExecutionMetrics_MultipleTelemetryProviders(t *testing.T) {
+	stub1 := &telemetryProviderStub{}
+	stub2 := &telemetryProviderStub{}
+	ths := telemetryHandlerTimeoutSupport{
+		providerName:       "providerName",
+		timeout:            1,
+		openAPIVersion:     "0.25.0",
+		telemetryProviders: []TelemetryProvider{stub1, stub2},
+	}
+	ths.SubmitPluginExecutionMetrics()
+	assert.Equal(t, ths.openAPIVersion, stub1.openAPIPluginVersionReceived)
+	assert.Equal(t, ths.openAPIVersion, stub2.openAPIPluginVersionReceived)
+}
+
 func TestSubmitTelemetryMetric(t *testing.T) {
 	var resourceNameReceived string
 	var tfOperationReceived TelemetryResourceOperation
@@ -144,3 +186,125 @@ func TestSubmitTelemetryMetric_EmptyResourceName(t *testing.T) {
 	submitTelemetryMetric(clientOpenAPI, TelemetryResourceOperationCreate, "", "prefix_")
 	assert.False(t, submitResourceExecutionMetricsFuncCalled)
 }
+
+func TestSubmitResourceOperationDurationMetrics(t *testing.T) {
+	stub := &telemetryProviderStub{}
+	ths := telemetryHandlerTimeoutSupport{
+		providerName:       "providerName",
+		timeout:            1,
+		openAPIVersion:     "0.25.0",
+		telemetryProviders: []TelemetryProvider{stub},
+	}
+	ths.SubmitResourceOperationDurationMetrics("resourceName", TelemetryResourceOperationUpdate, TelemetryResourceOperationOutcomeFailure, 3*time.Second)
+	assert.Equal(t, ths.providerName, stub.providerNameReceived)
+	assert.Equal(t, "resourceName", stub.resourceNameReceived)
+	assert.Equal(t, TelemetryResourceOperationUpdate, stub.tfOperationReceived)
+	assert.Equal(t, TelemetryResourceOperationOutcomeFailure, stub.outcomeReceived)
+	assert.Equal(t, 3*time.Second, stub.durationReceived)
+}
+
+func TestSubmitResourceHTTPStatusMetrics(t *testing.T) {
+	stub := &telemetryProviderStub{}
+	ths := telemetryHandlerTimeoutSupport{
+		providerName:       "providerName",
+		timeout:            1,
+		openAPIVersion:     "0.25.0",
+		telemetryProviders: []TelemetryProvider{stub},
+	}
+	ths.SubmitResourceHTTPStatusMetrics("resourceName", TelemetryResourceOperationRead, 404)
+	assert.Equal(t, ths.providerName, stub.providerNameReceived)
+	assert.Equal(t, "resourceName", stub.resourceNameReceived)
+	assert.Equal(t, TelemetryResourceOperationRead, stub.tfOperationReceived)
+	assert.Equal(t, "4xx", stub.statusClassReceived)
+}
+
+func TestSubmitResourcePollingWaitMetrics(t *testing.T) {
+	stub := &telemetryProviderStub{}
+	ths := telemetryHandlerTimeoutSupport{
+		providerName:       "providerName",
+		timeout:            1,
+		openAPIVersion:     "0.25.0",
+		telemetryProviders: []TelemetryProvider{stub},
+	}
+	ths.SubmitResourcePollingWaitMetrics("resourceName", TelemetryResourceOperationDelete, 5*time.Second)
+	assert.Equal(t, ths.providerName, stub.providerNameReceived)
+	assert.Equal(t, "resourceName", stub.resourceNameReceived)
+	assert.Equal(t, TelemetryResourceOperationDelete, stub.tfOperationReceived)
+	assert.Equal(t, 5*time.Second, stub.pollingWaitReceived)
+}
+
+func TestSubmitResourceOperationMetrics_FailsNilTelemetryProvider(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	ths := telemetryHandlerTimeoutSupport{
+		providerName:       "providerName",
+		timeout:            1,
+		openAPIVersion:     "0.25.0",
+		telemetryProviders: nil,
+	}
+	ths.SubmitResourceOperationDurationMetrics("resourceName", TelemetryResourceOperationCreate, TelemetryResourceOperationOutcomeSuccess, time.Second)
+	ths.SubmitResourceHTTPStatusMetrics("resourceName", TelemetryResourceOperationCreate, 200)
+	ths.SubmitResourcePollingWaitMetrics("resourceName", TelemetryResourceOperationCreate, time.Second)
+	assert.Equal(t, 3, strings.Count(buf.String(), "[INFO] Telemetry provider not configured"))
+}
+
+func TestSubmitTelemetryOperationDurationMetric(t *testing.T) {
+	testCases := []struct {
+		name            string
+		err             error
+		expectedOutcome TelemetryResourceOperationOutcome
+	}{
+		{
+			name:            "operation succeeded",
+			err:             nil,
+			expectedOutcome: TelemetryResourceOperationOutcomeSuccess,
+		},
+		{
+			name:            "operation failed",
+			err:             errors.New("some error"),
+			expectedOutcome: TelemetryResourceOperationOutcomeFailure,
+		},
+	}
+	for _, tc := range testCases {
+		var resourceNameReceived string
+		var outcomeReceived TelemetryResourceOperationOutcome
+		var durationReceived time.Duration
+		clientOpenAPI := &clientOpenAPIStub{
+			telemetryHandler: &telemetryHandlerStub{
+				submitResourceOperationDurationMetricsFunc: func(resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome, duration time.Duration) {
+					resourceNameReceived = resourceName
+					outcomeReceived = outcome
+					durationReceived = duration
+				},
+			},
+		}
+		submitTelemetryOperationDurationMetric(clientOpenAPI, TelemetryResourceOperationCreate, "resourceName", "data_", time.Now().Add(-time.Second), &tc.err)
+		assert.Equal(t, "data_resourceName", resourceNameReceived, tc.name)
+		assert.Equal(t, tc.expectedOutcome, outcomeReceived, tc.name)
+		assert.True(t, durationReceived >= time.Second, tc.name)
+	}
+}
+
+func TestSubmitTelemetryHTTPStatusMetric(t *testing.T) {
+	var statusCodeReceived int
+	var submitResourceHTTPStatusMetricsFuncCalled bool
+	clientOpenAPI := &clientOpenAPIStub{
+		telemetryHandler: &telemetryHandlerStub{
+			submitResourceHTTPStatusMetricsFunc: func(resourceName string, tfOperation TelemetryResourceOperation, statusCode int) {
+				submitResourceHTTPStatusMetricsFuncCalled = true
+				statusCodeReceived = statusCode
+			},
+		},
+	}
+	submitTelemetryHTTPStatusMetric(clientOpenAPI, TelemetryResourceOperationRead, "resourceName", "", nil)
+	assert.False(t, submitResourceHTTPStatusMetricsFuncCalled, "no metric expected when there is no response")
+	submitTelemetryHTTPStatusMetric(clientOpenAPI, TelemetryResourceOperationRead, "resourceName", "", &http.Response{StatusCode: http.StatusAccepted})
+	assert.Equal(t, http.StatusAccepted, statusCodeReceived)
+}
+
+func TestGetHTTPStatusClass(t *testing.T) {
+	assert.Equal(t, "2xx", getHTTPStatusClass(http.StatusOK))
+	assert.Equal(t, "3xx", getHTTPStatusClass(http.StatusMovedPermanently))
+	assert.Equal(t, "4xx", getHTTPStatusClass(http.StatusNotFound))
+	assert.Equal(t, "5xx", getHTTPStatusClass(http.StatusBadGateway))
+}
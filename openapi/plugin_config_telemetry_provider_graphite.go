@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"log"
 	"strings"
+	"time"
 )
 
 // TelemetryProviderGraphite defines the configuration for Graphite. This struct also implements the TelemetryProvider interface
@@ -61,6 +62,34 @@ func (g TelemetryProviderGraphite) IncServiceProviderResourceTotalRunsCounter(pr
 	return nil
 }
 
+// TimingServiceProviderResourceOperationDuration will submit the timing 'statsd.<prefix>.terraform.provider.operation.duration' metric
+// with the duration of the operation and appends tags containing the 'provider_name', 'resource_name', 'terraform_operation' and 'outcome'
+func (g TelemetryProviderGraphite) TimingServiceProviderResourceOperationDuration(providerName, resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome, duration time.Duration, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	tags := []string{"provider_name:" + providerName, "resource_name:" + resourceName, fmt.Sprintf("terraform_operation:%s", tfOperation), fmt.Sprintf("outcome:%s", outcome)}
+	return g.submitTimingMetric("terraform.provider.operation.duration", duration, tags)
+}
+
+// IncServiceProviderResourceHTTPStatusClassCounter will increment the counter 'statsd.<prefix>.terraform.provider.http_status' metric
+// to 1 and appends tags containing the 'provider_name', 'resource_name', 'terraform_operation' and 'status_class'
+func (g TelemetryProviderGraphite) IncServiceProviderResourceHTTPStatusClassCounter(providerName, resourceName string, tfOperation TelemetryResourceOperation, statusClass string, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	tags := []string{"provider_name:" + providerName, "resource_name:" + resourceName, fmt.Sprintf("terraform_operation:%s", tfOperation), "status_class:" + statusClass}
+	metricName := "terraform.provider.http_status"
+	log.Printf("[INFO] graphite metric to be submitted: %s", metricName)
+	if err := g.submitMetric(metricName, tags); err != nil {
+		return err
+	}
+	log.Printf("[INFO] graphite metric successfully submitted: %s (tags: %s)", metricName, tags)
+	return nil
+}
+
+// TimingServiceProviderResourcePollingWait will submit the timing 'statsd.<prefix>.terraform.provider.polling.wait_duration' metric
+// with the time spent waiting for the resource to reach a completion status and appends tags containing the 'provider_name',
+// 'resource_name' and 'terraform_operation'
+func (g TelemetryProviderGraphite) TimingServiceProviderResourcePollingWait(providerName, resourceName string, tfOperation TelemetryResourceOperation, duration time.Duration, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	tags := []string{"provider_name:" + providerName, "resource_name:" + resourceName, fmt.Sprintf("terraform_operation:%s", tfOperation)}
+	return g.submitTimingMetric("terraform.provider.polling.wait_duration", duration, tags)
+}
+
 // GetTelemetryProviderConfiguration returns nil since Graphite does not need any TelemetryProviderConfiguration at the moment
 func (g TelemetryProviderGraphite) GetTelemetryProviderConfiguration(data *schema.ResourceData) TelemetryProviderConfiguration {
 	return nil
@@ -75,6 +104,19 @@ func (g TelemetryProviderGraphite) submitMetric(name string, tags []string) erro
 	return c.Incr(nameWithPrefix, tags, 1.0)
 }
 
+func (g TelemetryProviderGraphite) submitTimingMetric(name string, duration time.Duration, tags []string) error {
+	log.Printf("[INFO] graphite metric to be submitted: %s", name)
+	c, err := g.getGraphiteClient()
+	if err != nil {
+		return err
+	}
+	if err := c.Timing(g.buildMetricName(name), duration, tags, 1.0); err != nil {
+		return err
+	}
+	log.Printf("[INFO] graphite metric successfully submitted: %s (duration: %s, tags: %s)", name, duration, tags)
+	return nil
+}
+
 func (g TelemetryProviderGraphite) buildMetricName(name string) string {
 	if g.Prefix != "" {
 		return fmt.Sprintf("%s.%s", g.Prefix, name)
@@ -8,6 +8,7 @@ import (
 	"net"
 	"strconv"
 	"testing"
+	"time"
 )
 
 func TestTelemetryProviderGraphite_Validate(t *testing.T) {
@@ -162,3 +163,71 @@ func createTestGraphiteProviderBadHost() TelemetryProviderGraphite {
 	}
 	return tpg
 }
+
+func TestTelemetryProviderGraphite_TimingServiceProviderResourceOperationDuration(t *testing.T) {
+	expectedLogMetricToSubmit := "[INFO] graphite metric to be submitted: terraform.provider.operation.duration"
+	expectedLogMetricSuccess := "[INFO] graphite metric successfully submitted: terraform.provider.operation.duration (duration: 1.5s, tags: [provider_name:myProviderName resource_name:cdn_v1 terraform_operation:create outcome:failure])"
+	expectedMetric := "|ms|#provider_name:myProviderName,resource_name:cdn_v1,terraform_operation:create,outcome:failure"
+
+	var logging bytes.Buffer
+	log.SetOutput(&logging)
+
+	metricChannel := make(chan string)
+	pc, telemetryHost, telemetryPort := udpServer(metricChannel)
+	defer pc.Close()
+
+	telemetryPortInt, err := strconv.Atoi(telemetryPort)
+	tpg := TelemetryProviderGraphite{
+		Host:   telemetryHost,
+		Port:   telemetryPortInt,
+		Prefix: "myPrefixName",
+	}
+	err = tpg.TimingServiceProviderResourceOperationDuration("myProviderName", "cdn_v1", TelemetryResourceOperationCreate, TelemetryResourceOperationOutcomeFailure, 1500*time.Millisecond, nil)
+	assert.Nil(t, err)
+	assertExpectedMetricAndLogging(t, metricChannel, expectedMetric, expectedLogMetricToSubmit, expectedLogMetricSuccess, &logging)
+}
+
+func TestTelemetryProviderGraphite_IncServiceProviderResourceHTTPStatusClassCounter(t *testing.T) {
+	expectedLogMetricToSubmit := "[INFO] graphite metric to be submitted: terraform.provider.http_status"
+	expectedLogMetricSuccess := "[INFO] graphite metric successfully submitted: terraform.provider.http_status (tags: [provider_name:myProviderName resource_name:cdn_v1 terraform_operation:read status_class:4xx])"
+	expectedMetric := "myPrefixName.terraform.provider.http_status:1|c|#provider_name:myProviderName,resource_name:cdn_v1,terraform_operation:read,status_class:4xx"
+
+	var logging bytes.Buffer
+	log.SetOutput(&logging)
+
+	metricChannel := make(chan string)
+	pc, telemetryHost, telemetryPort := udpServer(metricChannel)
+	defer pc.Close()
+
+	telemetryPortInt, err := strconv.Atoi(telemetryPort)
+	tpg := TelemetryProviderGraphite{
+		Host:   telemetryHost,
+		Port:   telemetryPortInt,
+		Prefix: "myPrefixName",
+	}
+	err = tpg.IncServiceProviderResourceHTTPStatusClassCounter("myProviderName", "cdn_v1", TelemetryResourceOperationRead, "4xx", nil)
+	assert.Nil(t, err)
+	assertExpectedMetricAndLogging(t, metricChannel, expectedMetric, expectedLogMetricToSubmit, expectedLogMetricSuccess, &logging)
+}
+
+func TestTelemetryProviderGraphite_TimingServiceProviderResourcePollingWait(t *testing.T) {
+	expectedLogMetricToSubmit := "[INFO] graphite metric to be submitted: terraform.provider.polling.wait_duration"
+	expectedLogMetricSuccess := "[INFO] graphite metric successfully submitted: terraform.provider.polling.wait_duration (duration: 2s, tags: [provider_name:myProviderName resource_name:cdn_v1 terraform_operation:delete])"
+	expectedMetric := "|ms|#provider_name:myProviderName,resource_name:cdn_v1,terraform_operation:delete"
+
+	var logging bytes.Buffer
+	log.SetOutput(&logging)
+
+	metricChannel := make(chan string)
+	pc, telemetryHost, telemetryPort := udpServer(metricChannel)
+	defer pc.Close()
+
+	telemetryPortInt, err := strconv.Atoi(telemetryPort)
+	tpg := TelemetryProviderGraphite{
+		Host: telemetryHost,
+		Port: telemetryPortInt,
+	}
+	err = tpg.TimingServiceProviderResourcePollingWait("myProviderName", "cdn_v1", TelemetryResourceOperationDelete, 2*time.Second, nil)
+	assert.Nil(t, err)
+	assertExpectedMetricAndLogging(t, metricChannel, expectedMetric, expectedLogMetricToSubmit, expectedLogMetricSuccess, &logging)
+}
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"runtime"
 	"strings"
+	"time"
 )
 
 // TelemetryProviderHTTPEndpoint defines the configuration for HTTPEndpoint. This struct also implements the TelemetryProvider interface
@@ -23,6 +24,10 @@ type TelemetryProviderHTTPEndpoint struct {
 	// ProviderSchemaProperties defines what specific provider configuration properties and their values that will be injected into
 	// metric API request headers. Values must match a real property name in provider schema configuration.
 	ProviderSchemaProperties []string `yaml:"provider_schema_properties,omitempty"`
+	// Headers defines static headers (e,g: Authorization) that will be sent along with the metric API requests
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Buffer enables the in-process buffering of the metrics, if configured the metrics are submitted in batches
+	Buffer *TelemetryProviderHTTPEndpointBuffer `yaml:"buffer,omitempty"`
 }
 
 // telemetryProviderConfigurationHTTPEndpoint defines the specific telemetry configuration for the  HTTPEndpoint telemetry provider. This
@@ -35,14 +40,40 @@ type metricType string
 
 const (
 	metricTypeCounter metricType = "IncCounter"
+	metricTypeTiming  metricType = "Timing"
 )
 
+// telemetryMetricPayload defines the payloads that can be submitted to the HTTP endpoint
+type telemetryMetricPayload interface {
+	getMetricName() string
+}
+
 type telemetryMetric struct {
 	MetricType metricType `json:"metric_type"`
 	MetricName string     `json:"metric_name"`
 	Tags       []string   `json:"tags"`
 }
 
+func (t telemetryMetric) getMetricName() string {
+	return t.MetricName
+}
+
+// telemetryTimingMetric defines a timing metric, the value contains the duration in milliseconds
+type telemetryTimingMetric struct {
+	telemetryMetric
+	Value int64 `json:"value"`
+}
+
+func createNewTimingMetric(prefix, metricName string, duration time.Duration, tags []string) telemetryTimingMetric {
+	if prefix != "" {
+		metricName = fmt.Sprintf("%s.%s", prefix, metricName)
+	}
+	return telemetryTimingMetric{
+		telemetryMetric: telemetryMetric{MetricType: metricTypeTiming, MetricName: metricName, Tags: tags},
+		Value:           int64(duration / time.Millisecond),
+	}
+}
+
 func createNewCounterMetric(prefix, metricName string, tags []string) telemetryMetric {
 	if prefix != "" {
 		metricName = fmt.Sprintf("%s.%s", prefix, metricName)
@@ -50,6 +81,19 @@ func createNewCounterMetric(prefix, metricName string, tags []string) telemetryM
 	return telemetryMetric{MetricType: metricTypeCounter, MetricName: metricName, Tags: tags}
 }
 
+// telemetryCounterValueMetric defines a counter metric that is incremented by the value provided instead of 1
+type telemetryCounterValueMetric struct {
+	telemetryMetric
+	Value int64 `json:"value"`
+}
+
+func createNewCounterMetricWithValue(prefix, metricName string, value int64, tags []string) telemetryCounterValueMetric {
+	return telemetryCounterValueMetric{
+		telemetryMetric: createNewCounterMetric(prefix, metricName, tags),
+		Value:           value,
+	}
+}
+
 // Validate checks whether the provider is configured correctly. This validation is performed upon telemetry provider registration. If this
 // method returns an error the error will be logged but the telemetry will be disabled. Otherwise, the telemetry will be enabled
 // and the corresponding metrics will be shipped to Graphite
@@ -60,6 +104,9 @@ func (g TelemetryProviderHTTPEndpoint) Validate() error {
 	if !govalidator.IsURL(g.URL) {
 		return fmt.Errorf("http endpoint telemetry configuration does not have a valid URL '%s'", g.URL)
 	}
+	if g.Buffer != nil {
+		return g.Buffer.Validate()
+	}
 	return nil
 }
 
@@ -88,6 +135,32 @@ func (g TelemetryProviderHTTPEndpoint) IncServiceProviderResourceTotalRunsCounte
 	return nil
 }
 
+// TimingServiceProviderResourceOperationDuration will submit the metric type timing '<prefix>.terraform.provider.operation.duration'
+// with the duration of the operation in milliseconds. In addition, it will send tags with the provider name, resource name,
+// terraform operation called and the outcome of the operation (success/failure).
+func (g TelemetryProviderHTTPEndpoint) TimingServiceProviderResourceOperationDuration(providerName, resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome, duration time.Duration, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	tags := []string{"provider_name:" + providerName, "resource_name:" + resourceName, fmt.Sprintf("terraform_operation:%s", tfOperation), fmt.Sprintf("outcome:%s", outcome)}
+	metric := createNewTimingMetric(g.Prefix, "terraform.provider.operation.duration", duration, tags)
+	return g.submitMetric(metric, telemetryProviderConfiguration)
+}
+
+// IncServiceProviderResourceHTTPStatusClassCounter will submit an increment to 1 the metric type counter '<prefix>.terraform.provider.http_status'.
+// In addition, it will send tags with the provider name, resource name, terraform operation called and the HTTP status class (e,g: 2xx).
+func (g TelemetryProviderHTTPEndpoint) IncServiceProviderResourceHTTPStatusClassCounter(providerName, resourceName string, tfOperation TelemetryResourceOperation, statusClass string, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	tags := []string{"provider_name:" + providerName, "resource_name:" + resourceName, fmt.Sprintf("terraform_operation:%s", tfOperation), "status_class:" + statusClass}
+	metric := createNewCounterMetric(g.Prefix, "terraform.provider.http_status", tags)
+	return g.submitMetric(metric, telemetryProviderConfiguration)
+}
+
+// TimingServiceProviderResourcePollingWait will submit the metric type timing '<prefix>.terraform.provider.polling.wait_duration'
+// with the time spent in milliseconds waiting for the resource to reach a completion status. In addition, it will send tags
+// with the provider name, resource name and terraform operation called.
+func (g TelemetryProviderHTTPEndpoint) TimingServiceProviderResourcePollingWait(providerName, resourceName string, tfOperation TelemetryResourceOperation, duration time.Duration, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	tags := []string{"provider_name:" + providerName, "resource_name:" + resourceName, fmt.Sprintf("terraform_operation:%s", tfOperation)}
+	metric := createNewTimingMetric(g.Prefix, "terraform.provider.polling.wait_duration", duration, tags)
+	return g.submitMetric(metric, telemetryProviderConfiguration)
+}
+
 // GetTelemetryProviderConfiguration returns a telemetryProviderConfigurationHTTPEndpoint loaded with headers mapping to
 // the plugin configuration schema properties that match the ones specified in the TelemetryProviderHTTPEndpoint ProviderSchemaProperties values
 func (g TelemetryProviderHTTPEndpoint) GetTelemetryProviderConfiguration(data *schema.ResourceData) TelemetryProviderConfiguration {
@@ -103,7 +176,7 @@ func (g TelemetryProviderHTTPEndpoint) GetTelemetryProviderConfiguration(data *s
 	return tpConfig
 }
 
-func (g TelemetryProviderHTTPEndpoint) submitMetric(metric telemetryMetric, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+func (g TelemetryProviderHTTPEndpoint) submitMetric(metric telemetryMetricPayload, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
 	var telemetryConfiguration telemetryProviderConfigurationHTTPEndpoint
 	if telemetryProviderConfiguration != nil {
 		var ok bool
@@ -113,7 +186,12 @@ func (g TelemetryProviderHTTPEndpoint) submitMetric(metric telemetryMetric, tele
 		}
 	}
 
-	log.Printf("[INFO] http endpoint metric to be submitted: %s", metric.MetricName)
+	if g.Buffer != nil {
+		g.Buffer.getBuffer(g).add(metric, telemetryConfiguration.Headers)
+		return nil
+	}
+
+	log.Printf("[INFO] http endpoint metric to be submitted: %s", metric.getMetricName())
 	req, err := g.createNewRequest(metric, &telemetryConfiguration)
 	if err != nil {
 		return err
@@ -130,23 +208,34 @@ func (g TelemetryProviderHTTPEndpoint) submitMetric(metric telemetryMetric, tele
 	return nil
 }
 
-func (g TelemetryProviderHTTPEndpoint) createNewRequest(metric telemetryMetric, telemetryProviderConfiguration *telemetryProviderConfigurationHTTPEndpoint) (*http.Request, error) {
+func (g TelemetryProviderHTTPEndpoint) createNewRequest(metric telemetryMetricPayload, telemetryProviderConfiguration *telemetryProviderConfigurationHTTPEndpoint) (*http.Request, error) {
 	var body []byte
 	var err error
 	body, err = json.Marshal(metric)
 	if err != nil {
 		return nil, err
 	}
+	var headers map[string]string
+	if telemetryProviderConfiguration != nil {
+		headers = telemetryProviderConfiguration.Headers
+	}
+	return g.newMetricsRequest(body, headers)
+}
+
+// newMetricsRequest creates the POST request to the HTTP endpoint with the given body. The request will contain the static
+// headers configured as well as the headers provided (e,g: provider schema properties values)
+func (g TelemetryProviderHTTPEndpoint) newMetricsRequest(body []byte, headers map[string]string) (*http.Request, error) {
 	req, err := http.NewRequest(http.MethodPost, g.URL, strings.NewReader(string(body)))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set(contentType, "application/json")
 	req.Header.Set(userAgentHeader, version.BuildUserAgent(runtime.GOOS, runtime.GOARCH))
-	if telemetryProviderConfiguration != nil && telemetryProviderConfiguration.Headers != nil {
-		for schemaPropertyName, schemaPropertyValue := range telemetryProviderConfiguration.Headers {
-			req.Header.Set(schemaPropertyName, schemaPropertyValue)
-		}
+	for headerName, headerValue := range g.Headers {
+		req.Header.Set(headerName, headerValue)
+	}
+	for schemaPropertyName, schemaPropertyValue := range headers {
+		req.Header.Set(schemaPropertyName, schemaPropertyValue)
 	}
 	return req, nil
 }
@@ -0,0 +1,395 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultTelemetryBufferMaxBatchSize = 100
+const defaultTelemetryBufferMaxQueueSize = 1000
+const defaultTelemetryBufferFlushInterval = 10 * time.Second
+const defaultTelemetryBufferMaxRetries = 3
+const defaultTelemetryBufferRetryBackoff = 1 * time.Second
+
+// telemetryBufferShutdownTimeout is the time given to the buffer to ship the metrics queued when the provider process shuts
+// down. go-plugin kills the plugin process 2 seconds after asking it to shutdown so the flush must complete before that
+const telemetryBufferShutdownTimeout = 1500 * time.Millisecond
+const telemetryBufferRequestTimeout = 5 * time.Second
+
+// TelemetryProviderHTTPEndpointBuffer defines the configuration of the in-process buffer used to batch the metrics shipped
+// to the HTTP endpoint. When configured, the metrics are queued and submitted in batches (JSON array payload) instead of
+// submitting one request per metric.
+type TelemetryProviderHTTPEndpointBuffer struct {
+	// MaxBatchSize defines the maximum number of metrics sent in one request. The buffer is flushed as soon as the number of
+	// metrics queued reaches this number. Defaults to 100
+	MaxBatchSize int `yaml:"max_batch_size,omitempty"`
+	// MaxQueueSize defines the maximum number of metrics that can be queued waiting to be sent. Metrics received when the
+	// queue is full are dropped. Defaults to 1000
+	MaxQueueSize int `yaml:"max_queue_size,omitempty"`
+	// FlushInterval defines how often (in seconds) the buffer is flushed regardless of the number of metrics queued. Defaults to 10 seconds
+	FlushInterval int `yaml:"flush_interval,omitempty"`
+	// MaxRetries defines the number of times a batch submission is retried if the HTTP endpoint is not available. Defaults to 3
+	MaxRetries int `yaml:"max_retries,omitempty"`
+	// RetryBackoff defines the time (in seconds) to wait before the first retry, the time is doubled on each retry. Defaults to 1 second
+	RetryBackoff int `yaml:"retry_backoff,omitempty"`
+
+	once   sync.Once
+	buffer *telemetryMetricsBuffer
+}
+
+// Validate checks whether the buffer configuration is valid
+func (b *TelemetryProviderHTTPEndpointBuffer) Validate() error {
+	values := []struct {
+		name  string
+		value int
+	}{
+		{"max_batch_size", b.MaxBatchSize},
+		{"max_queue_size", b.MaxQueueSize},
+		{"flush_interval", b.FlushInterval},
+		{"max_retries", b.MaxRetries},
+		{"retry_backoff", b.RetryBackoff},
+	}
+	for _, v := range values {
+		if v.value < 0 {
+			return fmt.Errorf("http endpoint telemetry buffer configuration '%s' value '%d' must be a positive number", v.name, v.value)
+		}
+	}
+	return nil
+}
+
+// getBuffer returns the buffer for the given HTTP endpoint. The buffer is created (and its background worker started) the
+// first time it's requested
+func (b *TelemetryProviderHTTPEndpointBuffer) getBuffer(endpoint TelemetryProviderHTTPEndpoint) *telemetryMetricsBuffer {
+	b.once.Do(func() {
+		b.buffer = newTelemetryMetricsBuffer(endpoint, getPositiveOrDefault(b.MaxBatchSize, defaultTelemetryBufferMaxBatchSize),
+			getPositiveOrDefault(b.MaxQueueSize, defaultTelemetryBufferMaxQueueSize), getSecondsOrDefault(b.FlushInterval, defaultTelemetryBufferFlushInterval),
+			getPositiveOrDefault(b.MaxRetries, defaultTelemetryBufferMaxRetries), getSecondsOrDefault(b.RetryBackoff, defaultTelemetryBufferRetryBackoff))
+		b.buffer.start()
+		registerTelemetryMetricsBuffer(b.buffer)
+	})
+	return b.buffer
+}
+
+func getPositiveOrDefault(value, defaultValue int) int {
+	if value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+func getSecondsOrDefault(value int, defaultValue time.Duration) time.Duration {
+	if value > 0 {
+		return time.Duration(value) * time.Second
+	}
+	return defaultValue
+}
+
+// bufferedTelemetryMetric is a metric queued along with the request headers it has to be sent with
+type bufferedTelemetryMetric struct {
+	metric  telemetryMetricPayload
+	headers map[string]string
+}
+
+// pendingTelemetryBatch is a group of metrics sharing the same headers that is being submitted to the HTTP endpoint. The
+// attempt and timer are used to keep track of the batch while it's waiting to be retried
+type pendingTelemetryBatch struct {
+	metrics []telemetryMetricPayload
+	headers map[string]string
+	attempt int
+	timer   *time.Timer
+}
+
+// telemetryMetricsBuffer queues the metrics in a bounded queue and ships them in batches to the HTTP endpoint from a
+// background worker. The buffer is flushed when the batch is full, when the flush interval elapses or upon shutdown. Failed
+// submissions are retried from timers so the worker is never blocked waiting for a backoff to elapse
+type telemetryMetricsBuffer struct {
+	endpoint      TelemetryProviderHTTPEndpoint
+	maxBatchSize  int
+	flushInterval time.Duration
+	maxRetries    int
+	retryBackoff  time.Duration
+	httpClient    *http.Client
+
+	queue     chan bufferedTelemetryMetric
+	flushChan chan chan struct{}
+	dropped   uint64
+	// reportedDropped is only accessed by the worker and holds the number of dropped metrics already reported to the HTTP endpoint
+	reportedDropped uint64
+
+	pendingMutex sync.Mutex
+	pending      map[*pendingTelemetryBatch]struct{}
+}
+
+func newTelemetryMetricsBuffer(endpoint TelemetryProviderHTTPEndpoint, maxBatchSize, maxQueueSize int, flushInterval time.Duration, maxRetries int, retryBackoff time.Duration) *telemetryMetricsBuffer {
+	return &telemetryMetricsBuffer{
+		endpoint:      endpoint,
+		maxBatchSize:  maxBatchSize,
+		flushInterval: flushInterval,
+		maxRetries:    maxRetries,
+		retryBackoff:  retryBackoff,
+		httpClient:    &http.Client{Timeout: telemetryBufferRequestTimeout},
+		queue:         make(chan bufferedTelemetryMetric, maxQueueSize),
+		flushChan:     make(chan chan struct{}),
+		pending:       map[*pendingTelemetryBatch]struct{}{},
+	}
+}
+
+// add queues the metric. If the queue is full the metric is dropped and accounted in the dropped metrics counter
+func (b *telemetryMetricsBuffer) add(metric telemetryMetricPayload, headers map[string]string) {
+	select {
+	case b.queue <- bufferedTelemetryMetric{metric: metric, headers: headers}:
+		log.Printf("[INFO] http endpoint metric buffered: %s", metric.getMetricName())
+	default:
+		dropped := b.drop(1)
+		log.Printf("[WARN] http endpoint telemetry buffer is full, dropping metric '%s' (total metrics dropped: %d)", metric.getMetricName(), dropped)
+	}
+}
+
+func (b *telemetryMetricsBuffer) drop(n int) uint64 {
+	return atomic.AddUint64(&b.dropped, uint64(n))
+}
+
+// droppedMetrics returns the number of metrics dropped, either due to the queue being full or due to the HTTP endpoint not
+// accepting them
+func (b *telemetryMetricsBuffer) droppedMetrics() uint64 {
+	return atomic.LoadUint64(&b.dropped)
+}
+
+func (b *telemetryMetricsBuffer) start() {
+	go b.run()
+}
+
+func (b *telemetryMetricsBuffer) run() {
+	ticker := time.NewTicker(b.flushInterval)
+	defer ticker.Stop()
+	var batch []bufferedTelemetryMetric
+	for {
+		select {
+		case metric := <-b.queue:
+			batch = append(batch, metric)
+			if len(batch) >= b.maxBatchSize {
+				b.send(batch, true)
+				batch = nil
+			}
+		case <-ticker.C:
+			b.send(batch, true)
+			batch = nil
+		case done := <-b.flushChan:
+			// the process is about to exit so the batches waiting to be retried are sent right away and failures are not retried
+			for _, pending := range b.takePendingBatches() {
+				b.submit(pending, false)
+			}
+			batch = append(batch, b.drainQueue()...)
+			for len(batch) > 0 {
+				size := b.maxBatchSize
+				if len(batch) < size {
+					size = len(batch)
+				}
+				b.send(batch[:size], false)
+				batch = batch[size:]
+			}
+			batch = nil
+			close(done)
+		}
+	}
+}
+
+func (b *telemetryMetricsBuffer) drainQueue() []bufferedTelemetryMetric {
+	var metrics []bufferedTelemetryMetric
+	for {
+		select {
+		case metric := <-b.queue:
+			metrics = append(metrics, metric)
+		default:
+			return metrics
+		}
+	}
+}
+
+// flush sends all the metrics queued and waits till they are submitted or the timeout expires
+func (b *telemetryMetricsBuffer) flush(timeout time.Duration) error {
+	deadline := time.After(timeout)
+	done := make(chan struct{})
+	select {
+	case b.flushChan <- done:
+	case <-deadline:
+		return fmt.Errorf("http endpoint telemetry buffer flush did not start within the expected time %s", timeout)
+	}
+	select {
+	case <-done:
+		return nil
+	case <-deadline:
+		return fmt.Errorf("http endpoint telemetry buffer flush did not finish within the expected time %s", timeout)
+	}
+}
+
+// send submits the batch of metrics. Since metrics may need to be sent with different headers (e,g: provider schema
+// properties values), one request is sent per each different set of headers. If metrics were dropped since the last
+// submission, the '<prefix>.terraform.openapi_plugin.telemetry.metrics_dropped' counter is sent along with the batch
+func (b *telemetryMetricsBuffer) send(batch []bufferedTelemetryMetric, retry bool) {
+	if dropped := b.droppedMetrics(); dropped > b.reportedDropped {
+		metric := createNewCounterMetricWithValue(b.endpoint.Prefix, "terraform.openapi_plugin.telemetry.metrics_dropped", int64(dropped-b.reportedDropped), nil)
+		b.reportedDropped = dropped
+		batch = append(batch, bufferedTelemetryMetric{metric: metric})
+	}
+	if len(batch) == 0 {
+		return
+	}
+	var groupKeys []string
+	groups := map[string]*pendingTelemetryBatch{}
+	for _, m := range batch {
+		key := getHeadersKey(m.headers)
+		if _, exists := groups[key]; !exists {
+			groupKeys = append(groupKeys, key)
+			groups[key] = &pendingTelemetryBatch{headers: m.headers}
+		}
+		groups[key].metrics = append(groups[key].metrics, m.metric)
+	}
+	for _, key := range groupKeys {
+		b.submit(groups[key], retry)
+	}
+}
+
+// submit posts the batch to the HTTP endpoint. If the submission fails due to a transient error and retries are allowed,
+// the batch is scheduled to be retried after the backoff; otherwise the metrics are accounted as dropped
+func (b *telemetryMetricsBuffer) submit(batch *pendingTelemetryBatch, retry bool) {
+	retryable, err := b.sendBatch(batch.metrics, batch.headers)
+	if err == nil {
+		log.Printf("[INFO] http endpoint telemetry batch of %d metrics successfully submitted", len(batch.metrics))
+		return
+	}
+	if retry && retryable && batch.attempt < b.maxRetries {
+		backoff := b.retryBackoff << uint(batch.attempt)
+		log.Printf("[DEBUG] http endpoint telemetry batch submission failed (attempt %d): %s, retrying in %s", batch.attempt+1, err, backoff)
+		batch.attempt++
+		b.scheduleRetry(batch, backoff)
+		return
+	}
+	b.drop(len(batch.metrics))
+	log.Printf("[WARN] http endpoint telemetry batch of %d metrics could not be submitted: %s", len(batch.metrics), err)
+}
+
+// scheduleRetry submits the batch again once the backoff elapses. The retry runs in the timer's goroutine so the worker can
+// keep on processing the queue in the meantime
+func (b *telemetryMetricsBuffer) scheduleRetry(batch *pendingTelemetryBatch, backoff time.Duration) {
+	b.pendingMutex.Lock()
+	defer b.pendingMutex.Unlock()
+	b.pending[batch] = struct{}{}
+	batch.timer = time.AfterFunc(backoff, func() {
+		if b.takePendingBatch(batch) {
+			b.submit(batch, true)
+		}
+	})
+}
+
+// takePendingBatch removes the batch from the batches waiting to be retried. The bool returned is false if the batch had
+// already been taken by a flush
+func (b *telemetryMetricsBuffer) takePendingBatch(batch *pendingTelemetryBatch) bool {
+	b.pendingMutex.Lock()
+	defer b.pendingMutex.Unlock()
+	if _, exists := b.pending[batch]; !exists {
+		return false
+	}
+	delete(b.pending, batch)
+	return true
+}
+
+// takePendingBatches stops the timers of all the batches waiting to be retried and returns them
+func (b *telemetryMetricsBuffer) takePendingBatches() []*pendingTelemetryBatch {
+	b.pendingMutex.Lock()
+	defer b.pendingMutex.Unlock()
+	var batches []*pendingTelemetryBatch
+	for batch := range b.pending {
+		batch.timer.Stop()
+		batches = append(batches, batch)
+		delete(b.pending, batch)
+	}
+	return batches
+}
+
+// sendBatch posts the batch payload to the HTTP endpoint. The bool returned describes whether the failure is transient
+// and the request can be retried
+func (b *telemetryMetricsBuffer) sendBatch(metrics []telemetryMetricPayload, headers map[string]string) (bool, error) {
+	body, err := json.Marshal(metrics)
+	if err != nil {
+		return false, err
+	}
+	req, err := b.endpoint.newMetricsRequest(body, headers)
+	if err != nil {
+		return false, err
+	}
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("request POST %s failed. Response Error: '%s'", b.endpoint.URL, err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return retryable, fmt.Errorf("response returned from POST '%s' returned a non expected status code %d", b.endpoint.URL, resp.StatusCode)
+	}
+	return false, nil
+}
+
+func getHeadersKey(headers map[string]string) string {
+	var keys []string
+	for name, value := range headers {
+		keys = append(keys, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, "\n")
+}
+
+var telemetryMetricsBuffers struct {
+	sync.Mutex
+	buffers []*telemetryMetricsBuffer
+}
+
+func registerTelemetryMetricsBuffer(buffer *telemetryMetricsBuffer) {
+	telemetryMetricsBuffers.Lock()
+	defer telemetryMetricsBuffers.Unlock()
+	telemetryMetricsBuffers.buffers = append(telemetryMetricsBuffers.buffers, buffer)
+}
+
+// ShutdownTelemetry flushes the metrics that are still buffered by the telemetry providers and the traces that are still
+// pending to be exported. This function is expected to be called right before the provider process exits. The buffers
+// and tracers are flushed concurrently so the whole shutdown takes at most telemetryBufferShutdownTimeout
+func ShutdownTelemetry() {
+	telemetryMetricsBuffers.Lock()
+	buffers := telemetryMetricsBuffers.buffers
+	telemetryMetricsBuffers.buffers = nil
+	telemetryMetricsBuffers.Unlock()
+	otlpTracers.Lock()
+	tracers := otlpTracers.tracers
+	otlpTracers.tracers = nil
+	otlpTracers.Unlock()
+	var wg sync.WaitGroup
+	for _, tracer := range tracers {
+		wg.Add(1)
+		go func(tracer *otlpTracer) {
+			defer wg.Done()
+			if err := tracer.flush(telemetryBufferShutdownTimeout); err != nil {
+				log.Printf("[WARN] %s", err)
+			}
+		}(tracer)
+	}
+	for _, buffer := range buffers {
+		wg.Add(1)
+		go func(buffer *telemetryMetricsBuffer) {
+			defer wg.Done()
+			if err := buffer.flush(telemetryBufferShutdownTimeout); err != nil {
+				log.Printf("[WARN] %s", err)
+			}
+			if dropped := buffer.droppedMetrics(); dropped > 0 {
+				log.Printf("[WARN] http endpoint telemetry buffer dropped %d metrics", dropped)
+			}
+		}(buffer)
+	}
+	wg.Wait()
+}
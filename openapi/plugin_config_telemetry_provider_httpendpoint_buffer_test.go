@@ -0,0 +1,280 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type telemetryBatchCollector struct {
+	mutex       sync.Mutex
+	batches     [][]telemetryTimingMetric
+	headers     []http.Header
+	statusCodes []int
+	requests    chan struct{}
+}
+
+func newTelemetryBatchCollector(statusCodes ...int) (*telemetryBatchCollector, *httptest.Server) {
+	collector := &telemetryBatchCollector{statusCodes: statusCodes, requests: make(chan struct{}, 100)}
+	api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		collector.mutex.Lock()
+		defer collector.mutex.Unlock()
+		body, _ := ioutil.ReadAll(req.Body)
+		var batch []telemetryTimingMetric
+		if err := json.Unmarshal(body, &batch); err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		collector.batches = append(collector.batches, batch)
+		collector.headers = append(collector.headers, req.Header)
+		statusCode := http.StatusOK
+		if len(collector.statusCodes) > 0 {
+			statusCode = collector.statusCodes[0]
+			collector.statusCodes = collector.statusCodes[1:]
+		}
+		rw.WriteHeader(statusCode)
+		collector.requests <- struct{}{}
+	}))
+	return collector, api
+}
+
+func (c *telemetryBatchCollector) waitForRequests(t *testing.T, n int) {
+	for i := 0; i < n; i++ {
+		select {
+		case <-c.requests:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("expected %d requests but received %d", n, i)
+		}
+	}
+}
+
+func (c *telemetryBatchCollector) getBatches() [][]telemetryTimingMetric {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.batches
+}
+
+func TestTelemetryProviderHTTPEndpointBuffer_Validate(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		buffer      *TelemetryProviderHTTPEndpointBuffer
+		expectedErr error
+	}{
+		{
+			testName:    "happy path - default values",
+			buffer:      &TelemetryProviderHTTPEndpointBuffer{},
+			expectedErr: nil,
+		},
+		{
+			testName:    "happy path - all values populated",
+			buffer:      &TelemetryProviderHTTPEndpointBuffer{MaxBatchSize: 10, MaxQueueSize: 100, FlushInterval: 5, MaxRetries: 2, RetryBackoff: 1},
+			expectedErr: nil,
+		},
+		{
+			testName:    "negative max batch size",
+			buffer:      &TelemetryProviderHTTPEndpointBuffer{MaxBatchSize: -1},
+			expectedErr: errors.New("http endpoint telemetry buffer configuration 'max_batch_size' value '-1' must be a positive number"),
+		},
+		{
+			testName:    "negative retry backoff",
+			buffer:      &TelemetryProviderHTTPEndpointBuffer{RetryBackoff: -2},
+			expectedErr: errors.New("http endpoint telemetry buffer configuration 'retry_backoff' value '-2' must be a positive number"),
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.buffer.Validate()
+		assert.Equal(t, tc.expectedErr, err, tc.testName)
+	}
+}
+
+func TestTelemetryMetricsBuffer_FlushOnBatchSize(t *testing.T) {
+	collector, api := newTelemetryBatchCollector()
+	defer api.Close()
+
+	buffer := newTelemetryMetricsBuffer(TelemetryProviderHTTPEndpoint{URL: api.URL}, 2, 10, time.Hour, 0, time.Millisecond)
+	buffer.start()
+	buffer.add(createNewCounterMetric("", "terraform.provider", []string{"resource_name:cdn_v1"}), nil)
+	buffer.add(createNewTimingMetric("", "terraform.provider.operation.duration", 2*time.Second, []string{"resource_name:cdn_v1"}), nil)
+
+	collector.waitForRequests(t, 1)
+	batches := collector.getBatches()
+	assert.Len(t, batches, 1)
+	assert.Len(t, batches[0], 2)
+	assert.Equal(t, metricTypeCounter, batches[0][0].MetricType)
+	assert.Equal(t, "terraform.provider", batches[0][0].MetricName)
+	assert.Equal(t, metricTypeTiming, batches[0][1].MetricType)
+	assert.Equal(t, int64(2000), batches[0][1].Value)
+}
+
+func TestTelemetryMetricsBuffer_FlushOnInterval(t *testing.T) {
+	collector, api := newTelemetryBatchCollector()
+	defer api.Close()
+
+	buffer := newTelemetryMetricsBuffer(TelemetryProviderHTTPEndpoint{URL: api.URL}, 100, 10, 50*time.Millisecond, 0, time.Millisecond)
+	buffer.start()
+	buffer.add(createNewCounterMetric("", "terraform.provider", nil), nil)
+
+	collector.waitForRequests(t, 1)
+	assert.Len(t, collector.getBatches()[0], 1)
+}
+
+func TestTelemetryMetricsBuffer_FlushOnShutdown(t *testing.T) {
+	collector, api := newTelemetryBatchCollector()
+	defer api.Close()
+
+	tph := TelemetryProviderHTTPEndpoint{
+		URL:    api.URL,
+		Prefix: "openapi",
+		Buffer: &TelemetryProviderHTTPEndpointBuffer{MaxBatchSize: 2, FlushInterval: 3600},
+	}
+	for i := 0; i < 3; i++ {
+		err := tph.IncServiceProviderResourceTotalRunsCounter("cdn", fmt.Sprintf("cdn_v%d", i), TelemetryResourceOperationCreate, nil)
+		assert.Nil(t, err)
+	}
+	collector.waitForRequests(t, 1)
+	ShutdownTelemetry()
+
+	batches := collector.getBatches()
+	assert.Len(t, batches, 2)
+	assert.Len(t, batches[0], 2)
+	assert.Len(t, batches[1], 1)
+	assert.Equal(t, "openapi.terraform.provider", batches[1][0].MetricName)
+	assert.Equal(t, []string{"provider_name:cdn", "resource_name:cdn_v2", "terraform_operation:create"}, batches[1][0].Tags)
+}
+
+func TestTelemetryMetricsBuffer_Headers(t *testing.T) {
+	collector, api := newTelemetryBatchCollector()
+	defer api.Close()
+
+	endpoint := TelemetryProviderHTTPEndpoint{URL: api.URL, Headers: map[string]string{"Authorization": "Bearer some-token"}}
+	buffer := newTelemetryMetricsBuffer(endpoint, 100, 10, time.Hour, 0, time.Millisecond)
+	buffer.start()
+	buffer.add(createNewCounterMetric("", "metric_1", nil), map[string]string{"billing_id": "id1"})
+	buffer.add(createNewCounterMetric("", "metric_2", nil), map[string]string{"billing_id": "id2"})
+	buffer.add(createNewCounterMetric("", "metric_3", nil), map[string]string{"billing_id": "id1"})
+	assert.Nil(t, buffer.flush(time.Second))
+
+	// metrics with different headers are sent in different requests
+	batches := collector.getBatches()
+	assert.Len(t, batches, 2)
+	assert.Len(t, batches[0], 2)
+	assert.Equal(t, "id1", collector.headers[0].Get("billing_id"))
+	assert.Len(t, batches[1], 1)
+	assert.Equal(t, "id2", collector.headers[1].Get("billing_id"))
+	for _, headers := range collector.headers {
+		assert.Equal(t, "Bearer some-token", headers.Get("Authorization"))
+		assert.Equal(t, "application/json", headers.Get("Content-Type"))
+	}
+}
+
+func TestTelemetryMetricsBuffer_Retries(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		statusCodes      []int
+		maxRetries       int
+		expectedRequests int
+	}{
+		{
+			testName:         "request is retried after a transient error",
+			statusCodes:      []int{http.StatusServiceUnavailable, http.StatusOK},
+			maxRetries:       3,
+			expectedRequests: 2,
+		},
+		{
+			testName:         "request is retried up to the max retries",
+			statusCodes:      []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway},
+			maxRetries:       2,
+			expectedRequests: 3,
+		},
+		{
+			testName:         "request is not retried after a non transient error",
+			statusCodes:      []int{http.StatusBadRequest},
+			maxRetries:       3,
+			expectedRequests: 1,
+		},
+	}
+	for _, tc := range testCases {
+		collector, api := newTelemetryBatchCollector(tc.statusCodes...)
+		buffer := newTelemetryMetricsBuffer(TelemetryProviderHTTPEndpoint{URL: api.URL}, 1, 10, time.Hour, tc.maxRetries, time.Millisecond)
+		buffer.start()
+		buffer.add(createNewCounterMetric("", "terraform.provider", nil), nil)
+		collector.waitForRequests(t, tc.expectedRequests)
+		assert.Len(t, collector.getBatches(), tc.expectedRequests, tc.testName)
+		api.Close()
+	}
+}
+
+func TestTelemetryMetricsBuffer_RetriesDoNotBlockTheQueue(t *testing.T) {
+	collector, api := newTelemetryBatchCollector(http.StatusServiceUnavailable)
+	defer api.Close()
+
+	buffer := newTelemetryMetricsBuffer(TelemetryProviderHTTPEndpoint{URL: api.URL}, 1, 10, time.Hour, 1, time.Hour)
+	buffer.start()
+	buffer.add(createNewCounterMetric("", "metric_1", nil), nil)
+	buffer.add(createNewCounterMetric("", "metric_2", nil), nil)
+
+	// the second metric is submitted while the first one is waiting for its retry backoff (1h) to elapse
+	collector.waitForRequests(t, 2)
+	batches := collector.getBatches()
+	assert.Equal(t, "metric_1", batches[0][0].MetricName)
+	assert.Equal(t, "metric_2", batches[1][0].MetricName)
+
+	// upon flush the batches waiting to be retried are sent right away
+	assert.Nil(t, buffer.flush(time.Second))
+	batches = collector.getBatches()
+	assert.Len(t, batches, 3)
+	assert.Equal(t, "metric_1", batches[2][0].MetricName)
+	assert.Equal(t, uint64(0), buffer.droppedMetrics())
+}
+
+func TestTelemetryMetricsBuffer_FlushDoesNotRetry(t *testing.T) {
+	collector, api := newTelemetryBatchCollector(http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	defer api.Close()
+
+	buffer := newTelemetryMetricsBuffer(TelemetryProviderHTTPEndpoint{URL: api.URL}, 100, 10, time.Hour, 3, time.Millisecond)
+	buffer.start()
+	buffer.add(createNewCounterMetric("", "terraform.provider", nil), nil)
+	assert.Nil(t, buffer.flush(time.Second))
+	assert.Len(t, collector.getBatches(), 1)
+	assert.Equal(t, uint64(1), buffer.droppedMetrics())
+}
+
+func TestTelemetryMetricsBuffer_DropsMetricsWhenQueueIsFull(t *testing.T) {
+	collector, api := newTelemetryBatchCollector()
+	defer api.Close()
+
+	buffer := newTelemetryMetricsBuffer(TelemetryProviderHTTPEndpoint{URL: api.URL}, 100, 2, time.Hour, 0, time.Millisecond)
+	// the worker is not started yet so the metrics stay in the queue
+	for i := 0; i < 5; i++ {
+		buffer.add(createNewCounterMetric("", fmt.Sprintf("metric_%d", i), nil), nil)
+	}
+	assert.Equal(t, uint64(3), buffer.droppedMetrics())
+
+	buffer.start()
+	assert.Nil(t, buffer.flush(time.Second))
+	batches := collector.getBatches()
+	assert.Len(t, batches, 1)
+	assert.Len(t, batches[0], 3)
+	assert.Equal(t, "metric_0", batches[0][0].MetricName)
+	assert.Equal(t, "metric_1", batches[0][1].MetricName)
+	// the number of metrics dropped is reported along with the batch
+	assert.Equal(t, metricTypeCounter, batches[0][2].MetricType)
+	assert.Equal(t, "terraform.openapi_plugin.telemetry.metrics_dropped", batches[0][2].MetricName)
+	assert.Equal(t, int64(3), batches[0][2].Value)
+
+	// the dropped metrics are only reported once
+	buffer.add(createNewCounterMetric("", "metric_5", nil), nil)
+	assert.Nil(t, buffer.flush(time.Second))
+	batches = collector.getBatches()
+	assert.Len(t, batches, 2)
+	assert.Len(t, batches[1], 1)
+	assert.Equal(t, "metric_5", batches[1][0].MetricName)
+}
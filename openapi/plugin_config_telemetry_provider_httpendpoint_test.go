@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestTelemetryProviderHttpEndpoint_Validate(t *testing.T) {
@@ -344,3 +345,95 @@ func TestGetTelemetryProviderConfiguration(t *testing.T) {
 	assert.IsType(t, telemetryProviderConfigurationHTTPEndpoint{}, tpConfig)
 	assert.Equal(t, "prop_value", tpConfig.(telemetryProviderConfigurationHTTPEndpoint).Headers["prop_name"])
 }
+
+func TestCreateNewTimingMetric(t *testing.T) {
+	timingMetric := createNewTimingMetric("prefix", "terraform.provider.operation.duration", 1500*time.Millisecond, []string{"outcome:success"})
+	assert.Equal(t, metricTypeTiming, timingMetric.MetricType)
+	assert.Equal(t, "prefix.terraform.provider.operation.duration", timingMetric.MetricName)
+	assert.Equal(t, []string{"outcome:success"}, timingMetric.Tags)
+	assert.Equal(t, int64(1500), timingMetric.Value)
+}
+
+func TestTelemetryProviderHttpEndpointOperationMetrics(t *testing.T) {
+	testCases := []struct {
+		testName             string
+		submitMetric         func(tph TelemetryProviderHTTPEndpoint) error
+		expectedMetricType   metricType
+		expectedMetricName   string
+		expectedTags         []string
+		expectedValue        int64
+		returnedResponseCode int
+		expectedErr          error
+	}{
+		{
+			testName: "happy path - operation duration",
+			submitMetric: func(tph TelemetryProviderHTTPEndpoint) error {
+				return tph.TimingServiceProviderResourceOperationDuration("cdn", "cdn_resource", TelemetryResourceOperationCreate, TelemetryResourceOperationOutcomeSuccess, 2*time.Second, nil)
+			},
+			expectedMetricType:   metricTypeTiming,
+			expectedMetricName:   "terraform.provider.operation.duration",
+			expectedTags:         []string{"provider_name:cdn", "resource_name:cdn_resource", "terraform_operation:create", "outcome:success"},
+			expectedValue:        2000,
+			returnedResponseCode: http.StatusOK,
+		},
+		{
+			testName: "happy path - http status class",
+			submitMetric: func(tph TelemetryProviderHTTPEndpoint) error {
+				return tph.IncServiceProviderResourceHTTPStatusClassCounter("cdn", "cdn_resource", TelemetryResourceOperationUpdate, "5xx", nil)
+			},
+			expectedMetricType:   metricTypeCounter,
+			expectedMetricName:   "terraform.provider.http_status",
+			expectedTags:         []string{"provider_name:cdn", "resource_name:cdn_resource", "terraform_operation:update", "status_class:5xx"},
+			returnedResponseCode: http.StatusOK,
+		},
+		{
+			testName: "happy path - polling wait",
+			submitMetric: func(tph TelemetryProviderHTTPEndpoint) error {
+				return tph.TimingServiceProviderResourcePollingWait("cdn", "cdn_resource", TelemetryResourceOperationDelete, 250*time.Millisecond, nil)
+			},
+			expectedMetricType:   metricTypeTiming,
+			expectedMetricName:   "terraform.provider.polling.wait_duration",
+			expectedTags:         []string{"provider_name:cdn", "resource_name:cdn_resource", "terraform_operation:delete"},
+			expectedValue:        250,
+			returnedResponseCode: http.StatusOK,
+		},
+		{
+			testName: "metric submission fails",
+			submitMetric: func(tph TelemetryProviderHTTPEndpoint) error {
+				return tph.TimingServiceProviderResourcePollingWait("cdn", "cdn_resource", TelemetryResourceOperationDelete, 250*time.Millisecond, nil)
+			},
+			expectedMetricType:   metricTypeTiming,
+			expectedMetricName:   "terraform.provider.polling.wait_duration",
+			expectedTags:         []string{"provider_name:cdn", "resource_name:cdn_resource", "terraform_operation:delete"},
+			expectedValue:        250,
+			returnedResponseCode: http.StatusNotFound,
+			expectedErr:          errors.New("/v1/metrics' returned a non expected status code 404"),
+		},
+	}
+
+	for _, tc := range testCases {
+		api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			reqBody, err := ioutil.ReadAll(req.Body)
+			assert.Nil(t, err, tc.testName)
+			metric := telemetryTimingMetric{}
+			err = json.Unmarshal(reqBody, &metric)
+			assert.Nil(t, err, tc.testName)
+			assert.Equal(t, tc.expectedMetricType, metric.MetricType, tc.testName)
+			assert.Equal(t, tc.expectedMetricName, metric.MetricName, tc.testName)
+			assert.Equal(t, tc.expectedTags, metric.Tags, tc.testName)
+			assert.Equal(t, tc.expectedValue, metric.Value, tc.testName)
+			rw.WriteHeader(tc.returnedResponseCode)
+		}))
+		tph := TelemetryProviderHTTPEndpoint{
+			URL: fmt.Sprintf("%s/v1/metrics", api.URL),
+		}
+		err := tc.submitMetric(tph)
+		api.Close()
+		if tc.expectedErr == nil {
+			assert.NoError(t, err, tc.testName)
+		} else {
+			assert.Error(t, err, tc.testName)
+			assert.Contains(t, err.Error(), tc.expectedErr.Error(), tc.testName)
+		}
+	}
+}
@@ -0,0 +1,442 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/dikhan/terraform-provider-openapi/openapi/version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const prometheusDefaultJob = "terraform-provider-openapi"
+const prometheusTextfileName = "terraform_provider_openapi.prom"
+const prometheusInstanceLabel = "instance"
+const prometheusPushTimeout = 5 * time.Second
+
+// prometheusLockTimeout is the max time to wait for the lock of the counters file held by other plugin processes
+const prometheusLockTimeout = 10 * time.Second
+
+// prometheusStaleLockAge is the age after which a lock is considered abandoned (e,g: the process holding it was killed)
+const prometheusStaleLockAge = 30 * time.Second
+
+const prometheusLockRetryInterval = 50 * time.Millisecond
+
+var prometheusMetricNameRegex = regexp.MustCompile("^[a-zA-Z_:][a-zA-Z0-9_:]*$")
+
+// prometheusInstance identifies the host the plugin runs on and it is used as part of the Pushgateway grouping key
+var prometheusInstance = getPrometheusInstance()
+
+// prometheusStateDir is the directory where the counters pushed to the Pushgateway are accumulated
+var prometheusStateDir = getPrometheusStateDir()
+
+// prometheusMutex serialises the updates of the counters within the plugin process, the updates from different processes
+// are serialised with a lock file
+var prometheusMutex sync.Mutex
+
+func getPrometheusInstance() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return "unknown"
+	}
+	return hostname
+}
+
+func getPrometheusStateDir() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+	return filepath.Join(cacheDir, pluginConfigurationCacheDirName, "prometheus")
+}
+
+// TelemetryProviderPrometheus defines the configuration for Prometheus. This struct also implements the TelemetryProvider interface
+// and exposes the metrics in the Prometheus exposition format with the following names by default <prefix>_terraform_* where
+// '<prefix>' can be configured. The metrics can be either pushed to a Pushgateway compatible endpoint or written to a file
+// in the node exporter textfile collector directory.
+type TelemetryProviderPrometheus struct {
+	// PushgatewayURL describes the Pushgateway compatible endpoint where the metrics are pushed to (e,g: http://pushgateway:9091)
+	PushgatewayURL string `yaml:"pushgateway_url,omitempty"`
+	// Job describes the job grouping label used when pushing metrics to the Pushgateway. Defaults to terraform-provider-openapi
+	Job string `yaml:"job,omitempty"`
+	// TextfileDirectory describes the node exporter textfile collector directory where the metrics are written to
+	TextfileDirectory string `yaml:"textfile_directory,omitempty"`
+	// Prefix enables to append a prefix to the metrics names
+	Prefix string `yaml:"prefix,omitempty"`
+}
+
+// Validate checks whether the provider is configured correctly. This validation is performed upon telemetry provider registration. If this
+// method returns an error the error will be logged but the telemetry will be disabled. Otherwise, the telemetry will be enabled
+// and the corresponding metrics will be shipped to Prometheus
+func (p TelemetryProviderPrometheus) Validate() error {
+	if p.PushgatewayURL == "" && p.TextfileDirectory == "" {
+		return errors.New("prometheus telemetry configuration is missing a value for either the 'pushgateway_url' or the 'textfile_directory' property")
+	}
+	if p.PushgatewayURL != "" && p.TextfileDirectory != "" {
+		return errors.New("prometheus telemetry configuration 'pushgateway_url' and 'textfile_directory' properties are mutually exclusive")
+	}
+	if p.PushgatewayURL != "" && !govalidator.IsURL(p.PushgatewayURL) {
+		return fmt.Errorf("prometheus telemetry configuration does not have a valid pushgateway URL '%s'", p.PushgatewayURL)
+	}
+	if p.Prefix != "" && !prometheusMetricNameRegex.MatchString(p.Prefix) {
+		return fmt.Errorf("prometheus telemetry configuration prefix '%s' is not valid, it must match the regex %s", p.Prefix, prometheusMetricNameRegex)
+	}
+	return nil
+}
+
+// IncOpenAPIPluginVersionTotalRunsCounter will increment the counter '<prefix>_terraform_openapi_plugin_version_total_runs' metric
+// by 1 with the 'openapi_plugin_version' label containing the version used.
+func (p TelemetryProviderPrometheus) IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion string, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	labels := map[string]string{"openapi_plugin_version": openAPIPluginVersion}
+	return p.incCounter("terraform_openapi_plugin_version_total_runs", labels)
+}
+
+// IncServiceProviderResourceTotalRunsCounter will increment the counter '<prefix>_terraform_provider_total_runs' metric by 1
+// with labels containing the 'provider_name', 'resource_name', and 'terraform_operation' called
+func (p TelemetryProviderPrometheus) IncServiceProviderResourceTotalRunsCounter(providerName, resourceName string, tfOperation TelemetryResourceOperation, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	labels := map[string]string{"provider_name": providerName, "resource_name": resourceName, "terraform_operation": string(tfOperation)}
+	return p.incCounter("terraform_provider_total_runs", labels)
+}
+
+// TimingServiceProviderResourceOperationDuration will add the duration of the operation to the counter '<prefix>_terraform_provider_operation_duration_seconds_sum'
+// and increment by 1 the counter '<prefix>_terraform_provider_operation_duration_seconds_count' with labels containing the 'provider_name',
+// 'resource_name', 'terraform_operation' and 'outcome'
+func (p TelemetryProviderPrometheus) TimingServiceProviderResourceOperationDuration(providerName, resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome, duration time.Duration, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	labels := map[string]string{"provider_name": providerName, "resource_name": resourceName, "terraform_operation": string(tfOperation), "outcome": string(outcome)}
+	return p.addDuration("terraform_provider_operation_duration_seconds", labels, duration)
+}
+
+// IncServiceProviderResourceHTTPStatusClassCounter will increment the counter '<prefix>_terraform_provider_http_status_total' metric by 1
+// with labels containing the 'provider_name', 'resource_name', 'terraform_operation' and 'status_class'
+func (p TelemetryProviderPrometheus) IncServiceProviderResourceHTTPStatusClassCounter(providerName, resourceName string, tfOperation TelemetryResourceOperation, statusClass string, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	labels := map[string]string{"provider_name": providerName, "resource_name": resourceName, "terraform_operation": string(tfOperation), "status_class": statusClass}
+	return p.incCounter("terraform_provider_http_status_total", labels)
+}
+
+// TimingServiceProviderResourcePollingWait will add the polling wait duration to the counter '<prefix>_terraform_provider_polling_wait_seconds_sum'
+// and increment by 1 the counter '<prefix>_terraform_provider_polling_wait_seconds_count' with labels containing the 'provider_name',
+// 'resource_name' and 'terraform_operation'
+func (p TelemetryProviderPrometheus) TimingServiceProviderResourcePollingWait(providerName, resourceName string, tfOperation TelemetryResourceOperation, duration time.Duration, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	labels := map[string]string{"provider_name": providerName, "resource_name": resourceName, "terraform_operation": string(tfOperation)}
+	return p.addDuration("terraform_provider_polling_wait_seconds", labels, duration)
+}
+
+// GetTelemetryProviderConfiguration returns nil since Prometheus does not need any TelemetryProviderConfiguration at the moment
+func (p TelemetryProviderPrometheus) GetTelemetryProviderConfiguration(data *schema.ResourceData) TelemetryProviderConfiguration {
+	return nil
+}
+
+func (p TelemetryProviderPrometheus) buildMetricName(name string) string {
+	if p.Prefix != "" {
+		return fmt.Sprintf("%s_%s", p.Prefix, name)
+	}
+	return name
+}
+
+func (p TelemetryProviderPrometheus) getJob() string {
+	if p.Job != "" {
+		return p.Job
+	}
+	return prometheusDefaultJob
+}
+
+func (p TelemetryProviderPrometheus) incCounter(name string, labels map[string]string) error {
+	return p.addToCounters(labels, map[string]float64{name: 1})
+}
+
+// addDuration adds the duration to the '<name>_sum' counter and increments the '<name>_count' counter so the average
+// duration can be calculated in the same way as for Prometheus summaries
+func (p TelemetryProviderPrometheus) addDuration(name string, labels map[string]string, duration time.Duration) error {
+	return p.addToCounters(labels, map[string]float64{name + "_sum": duration.Seconds(), name + "_count": 1})
+}
+
+// addToCounters adds the values to the counters with the given labels. Terraform runs a separate plugin process per provider
+// configuration and run, so the counters of all the processes running in the host are accumulated in the same file: the
+// textfile itself or, when pushing to the Pushgateway, a state file whose content replaces the host's group
+func (p TelemetryProviderPrometheus) addToCounters(labels map[string]string, values map[string]float64) error {
+	var metricNames []string
+	for name := range values {
+		metricNames = append(metricNames, p.buildMetricName(name))
+	}
+	sort.Strings(metricNames)
+	log.Printf("[INFO] prometheus metric to be submitted: %s", metricNames)
+	prometheusMutex.Lock()
+	defer prometheusMutex.Unlock()
+	countersPath, err := p.getCountersPath()
+	if err != nil {
+		return err
+	}
+	unlock, err := lockPrometheusCounters(countersPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	samples, err := readPrometheusCounters(countersPath)
+	if err != nil {
+		return err
+	}
+	for name, value := range values {
+		samples = samples.add(p.buildMetricName(name), labels, value)
+	}
+	if p.PushgatewayURL != "" {
+		// the counters are pushed before storing them so the stored counters are always the ones the Pushgateway has
+		if err := p.pushCounters(samples); err != nil {
+			return err
+		}
+		err = writePrometheusCounters(countersPath, samples, 0600)
+	} else {
+		err = writePrometheusCounters(countersPath, samples, 0644)
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] prometheus metric successfully submitted: %s (labels: %s)", metricNames, labels)
+	return nil
+}
+
+// getCountersPath returns the path of the file where the counters are accumulated. For the textfile collector it is the
+// textfile read by the node exporter; for the Pushgateway it is a state file per push URL
+func (p TelemetryProviderPrometheus) getCountersPath() (string, error) {
+	if p.PushgatewayURL == "" {
+		return p.getTextfilePath(), nil
+	}
+	if err := os.MkdirAll(prometheusStateDir, 0700); err != nil {
+		return "", err
+	}
+	return filepath.Join(prometheusStateDir, fmt.Sprintf("%x.prom", sha256.Sum256([]byte(p.getPushURL())))), nil
+}
+
+// getTextfilePath returns the path of the textfile shared by all the plugin processes
+func (p TelemetryProviderPrometheus) getTextfilePath() string {
+	return filepath.Join(p.TextfileDirectory, prometheusTextfileName)
+}
+
+// getPushURL returns the Pushgateway URL of the group the counters are pushed to. The grouping key is made of the job
+// and the host (instance) so the number of groups is bounded by the number of hosts running the plugin
+func (p TelemetryProviderPrometheus) getPushURL() string {
+	return fmt.Sprintf("%s/metrics/job/%s/%s/%s", strings.TrimSuffix(p.PushgatewayURL, "/"), url.PathEscape(p.getJob()), prometheusInstanceLabel, url.PathEscape(prometheusInstance))
+}
+
+// lockPrometheusCounters acquires the lock of the counters file so the read, update and write of the counters is atomic
+// across plugin processes. The lock is a file created next to the counters file and the function returned releases it
+func lockPrometheusCounters(countersPath string) (func(), error) {
+	lockPath := countersPath + ".lock"
+	deadline := time.Now().Add(prometheusLockTimeout)
+	for {
+		lockFile, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600) // #nosec G304
+		if err == nil {
+			lockFile.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if lockInfo, err := os.Stat(lockPath); err == nil && time.Since(lockInfo.ModTime()) > prometheusStaleLockAge {
+			log.Printf("[WARN] removing stale prometheus lock '%s'", lockPath)
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for the prometheus lock '%s' held by another plugin process", lockPath)
+		}
+		time.Sleep(prometheusLockRetryInterval)
+	}
+}
+
+// readPrometheusCounters reads the counters stored in the file, no counters are returned if the file does not exist yet
+func readPrometheusCounters(countersPath string) (prometheusSamples, error) {
+	content, err := ioutil.ReadFile(countersPath) // #nosec G304
+	if err != nil {
+		if os.IsNotExist(err) {
+			return prometheusSamples{}, nil
+		}
+		return nil, err
+	}
+	samples, err := parsePrometheusSamples(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the prometheus counters from '%s': %s", countersPath, err)
+	}
+	return samples, nil
+}
+
+// writePrometheusCounters writes the counters to a temporary file first and then renames it so the node exporter (or the
+// next plugin process) never reads a partially written file
+func writePrometheusCounters(countersPath string, samples prometheusSamples, perm os.FileMode) error {
+	tmpFile, err := ioutil.TempFile(filepath.Dir(countersPath), filepath.Base(countersPath)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.WriteString(samples.format()); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpFile.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), countersPath)
+}
+
+// pushCounters pushes the counters to the Pushgateway host group. The PUT request replaces all the metrics of the group,
+// which contains the counters accumulated by all the plugin processes of the host
+func (p TelemetryProviderPrometheus) pushCounters(samples prometheusSamples) error {
+	pushURL := p.getPushURL()
+	req, err := http.NewRequest(http.MethodPut, pushURL, strings.NewReader(samples.format()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(contentType, "text/plain; version=0.0.4")
+	req.Header.Set(userAgentHeader, version.BuildUserAgent(runtime.GOOS, runtime.GOARCH))
+	c := http.Client{Timeout: prometheusPushTimeout}
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("request PUT %s failed. Response Error: '%s'", pushURL, err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("response returned from PUT '%s' returned a non expected status code %d", pushURL, resp.StatusCode)
+	}
+	return nil
+}
+
+// prometheusSample represents a sample in the Prometheus text exposition format
+type prometheusSample struct {
+	name   string
+	labels map[string]string
+	value  float64
+}
+
+type prometheusSamples []prometheusSample
+
+// add adds the value to the sample matching the name and labels, adding the sample if it does not exist yet
+func (s prometheusSamples) add(name string, labels map[string]string, value float64) prometheusSamples {
+	key := formatPrometheusSeries(name, labels)
+	for i, sample := range s {
+		if formatPrometheusSeries(sample.name, sample.labels) == key {
+			s[i].value += value
+			return s
+		}
+	}
+	return append(s, prometheusSample{name: name, labels: labels, value: value})
+}
+
+// format returns the samples in the Prometheus text exposition format, all metrics are declared as counters
+func (s prometheusSamples) format() string {
+	series := map[string][]string{}
+	for _, sample := range s {
+		series[sample.name] = append(series[sample.name], fmt.Sprintf("%s %s", formatPrometheusSeries(sample.name, sample.labels), strconv.FormatFloat(sample.value, 'f', -1, 64)))
+	}
+	var names []string
+	for name := range series {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var sb strings.Builder
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("# TYPE %s counter\n", name))
+		sort.Strings(series[name])
+		for _, line := range series[name] {
+			sb.WriteString(line + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// parsePrometheusSamples parses the samples in the Prometheus text exposition format written by format
+func parsePrometheusSamples(content string) (prometheusSamples, error) {
+	samples := prometheusSamples{}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		valueIndex := strings.LastIndex(line, " ")
+		if valueIndex < 0 {
+			return nil, fmt.Errorf("sample '%s' is missing the value", line)
+		}
+		value, err := strconv.ParseFloat(line[valueIndex+1:], 64)
+		if err != nil {
+			return nil, fmt.Errorf("sample '%s' does not have a valid value: %s", line, err)
+		}
+		series := line[:valueIndex]
+		name := series
+		labels := map[string]string{}
+		if labelsIndex := strings.Index(series, "{"); labelsIndex >= 0 {
+			name = series[:labelsIndex]
+			labels, err = parsePrometheusLabels(series[labelsIndex:])
+			if err != nil {
+				return nil, fmt.Errorf("sample '%s' does not have valid labels: %s", line, err)
+			}
+		}
+		samples = samples.add(name, labels, value)
+	}
+	return samples, nil
+}
+
+// parsePrometheusLabels parses the labels of a series with the form {name="value",...} unescaping the values
+func parsePrometheusLabels(series string) (map[string]string, error) {
+	if !strings.HasPrefix(series, "{") || !strings.HasSuffix(series, "}") {
+		return nil, fmt.Errorf("labels must be enclosed in curly brackets")
+	}
+	labels := map[string]string{}
+	rest := series[1 : len(series)-1]
+	for rest != "" {
+		nameEnd := strings.Index(rest, `="`)
+		if nameEnd < 0 {
+			return nil, fmt.Errorf("label '%s' is missing the value", rest)
+		}
+		name := rest[:nameEnd]
+		var value strings.Builder
+		i := nameEnd + 2
+		for ; i < len(rest) && rest[i] != '"'; i++ {
+			if rest[i] == '\\' && i+1 < len(rest) {
+				i++
+				if rest[i] == 'n' {
+					value.WriteByte('\n')
+					continue
+				}
+			}
+			value.WriteByte(rest[i])
+		}
+		if i >= len(rest) {
+			return nil, fmt.Errorf("label '%s' value is not terminated", name)
+		}
+		labels[name] = value.String()
+		rest = strings.TrimPrefix(rest[i+1:], ",")
+	}
+	return labels, nil
+}
+
+func formatPrometheusSeries(name string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return name
+	}
+	var labelNames []string
+	for labelName := range labels {
+		labelNames = append(labelNames, labelName)
+	}
+	sort.Strings(labelNames)
+	var pairs []string
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	for _, labelName := range labelNames {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, labelName, replacer.Replace(labels[labelName])))
+	}
+	return fmt.Sprintf("%s{%s}", name, strings.Join(pairs, ","))
+}
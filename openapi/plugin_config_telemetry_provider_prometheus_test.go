@@ -0,0 +1,238 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTelemetryProviderPrometheus_Validate(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		pushgatewayURL string
+		textfileDir    string
+		prefix         string
+		expectedErr    error
+	}{
+		{
+			testName:       "happy path - pushgateway url populated",
+			pushgatewayURL: "http://pushgateway.myhost.com:9091",
+			expectedErr:    nil,
+		},
+		{
+			testName:    "happy path - textfile directory and prefix populated",
+			textfileDir: "/var/lib/node_exporter/textfile_collector",
+			prefix:      "my_prefix",
+			expectedErr: nil,
+		},
+		{
+			testName:    "pushgateway url and textfile directory are empty",
+			expectedErr: errors.New("prometheus telemetry configuration is missing a value for either the 'pushgateway_url' or the 'textfile_directory' property"),
+		},
+		{
+			testName:       "pushgateway url and textfile directory are both populated",
+			pushgatewayURL: "http://pushgateway.myhost.com:9091",
+			textfileDir:    "/var/lib/node_exporter/textfile_collector",
+			expectedErr:    errors.New("prometheus telemetry configuration 'pushgateway_url' and 'textfile_directory' properties are mutually exclusive"),
+		},
+		{
+			testName:       "pushgateway url is wrongly formatted",
+			pushgatewayURL: "htop://something-wrong.com",
+			expectedErr:    errors.New("prometheus telemetry configuration does not have a valid pushgateway URL 'htop://something-wrong.com'"),
+		},
+		{
+			testName:    "prefix is not a valid metric name",
+			textfileDir: "/var/lib/node_exporter/textfile_collector",
+			prefix:      "my.prefix",
+			expectedErr: errors.New("prometheus telemetry configuration prefix 'my.prefix' is not valid, it must match the regex ^[a-zA-Z_:][a-zA-Z0-9_:]*$"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tpp := TelemetryProviderPrometheus{
+			PushgatewayURL:    tc.pushgatewayURL,
+			TextfileDirectory: tc.textfileDir,
+			Prefix:            tc.prefix,
+		}
+		err := tpp.Validate()
+		assert.Equal(t, tc.expectedErr, err, tc.testName)
+	}
+}
+
+func TestTelemetryProviderPrometheus_Textfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prometheus_textfile")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	// counters written by some other plugin process which must be accumulated
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "terraform_provider_openapi.prom"), []byte("# TYPE prefix_terraform_openapi_plugin_version_total_runs counter\nprefix_terraform_openapi_plugin_version_total_runs{openapi_plugin_version=\"0.25.0\"} 5\n"), 0644))
+
+	tpp := TelemetryProviderPrometheus{TextfileDirectory: dir, Prefix: "prefix"}
+	assert.Nil(t, tpp.IncOpenAPIPluginVersionTotalRunsCounter("0.25.0", nil))
+	assert.Nil(t, tpp.IncOpenAPIPluginVersionTotalRunsCounter("0.25.0", nil))
+	assert.Nil(t, tpp.IncServiceProviderResourceTotalRunsCounter("cdn", "cdn_v1", TelemetryResourceOperationCreate, nil))
+	assert.Nil(t, tpp.IncServiceProviderResourceTotalRunsCounter("cdn", "cdn_v1", TelemetryResourceOperationRead, nil))
+	assert.Nil(t, tpp.IncServiceProviderResourceTotalRunsCounter("cdn", "cdn_v1", TelemetryResourceOperationRead, nil))
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "terraform_provider_openapi.prom"))
+	assert.Nil(t, err)
+	expectedContent := `# TYPE prefix_terraform_openapi_plugin_version_total_runs counter
+prefix_terraform_openapi_plugin_version_total_runs{openapi_plugin_version="0.25.0"} 7
+# TYPE prefix_terraform_provider_total_runs counter
+prefix_terraform_provider_total_runs{provider_name="cdn",resource_name="cdn_v1",terraform_operation="create"} 1
+prefix_terraform_provider_total_runs{provider_name="cdn",resource_name="cdn_v1",terraform_operation="read"} 2
+`
+	assert.Equal(t, expectedContent, string(content))
+
+	files, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Len(t, files, 1, "temporary and lock files should be cleaned up")
+}
+
+func TestTelemetryProviderPrometheus_TextfileLockedByOtherProcess(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prometheus_textfile")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	lockPath := filepath.Join(dir, "terraform_provider_openapi.prom.lock")
+	assert.Nil(t, ioutil.WriteFile(lockPath, []byte{}, 0600))
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		os.Remove(lockPath)
+	}()
+
+	tpp := TelemetryProviderPrometheus{TextfileDirectory: dir}
+	assert.Nil(t, tpp.IncOpenAPIPluginVersionTotalRunsCounter("0.25.0", nil))
+	content, err := ioutil.ReadFile(filepath.Join(dir, "terraform_provider_openapi.prom"))
+	assert.Nil(t, err)
+	assert.Equal(t, "# TYPE terraform_openapi_plugin_version_total_runs counter\nterraform_openapi_plugin_version_total_runs{openapi_plugin_version=\"0.25.0\"} 1\n", string(content))
+
+	// a lock older than prometheusStaleLockAge is considered abandoned
+	assert.Nil(t, ioutil.WriteFile(lockPath, []byte{}, 0600))
+	staleTime := time.Now().Add(-2 * prometheusStaleLockAge)
+	assert.Nil(t, os.Chtimes(lockPath, staleTime, staleTime))
+	assert.Nil(t, tpp.IncOpenAPIPluginVersionTotalRunsCounter("0.25.0", nil))
+	_, err = os.Stat(lockPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestTelemetryProviderPrometheus_TextfileOperationMetrics(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prometheus_textfile")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	tpp := TelemetryProviderPrometheus{TextfileDirectory: dir}
+	assert.Nil(t, tpp.TimingServiceProviderResourceOperationDuration("cdn", "cdn_v1", TelemetryResourceOperationCreate, TelemetryResourceOperationOutcomeSuccess, 1500*time.Millisecond, nil))
+	assert.Nil(t, tpp.TimingServiceProviderResourceOperationDuration("cdn", "cdn_v1", TelemetryResourceOperationCreate, TelemetryResourceOperationOutcomeSuccess, 500*time.Millisecond, nil))
+	assert.Nil(t, tpp.IncServiceProviderResourceHTTPStatusClassCounter("cdn", "cdn_v1", TelemetryResourceOperationCreate, "2xx", nil))
+	assert.Nil(t, tpp.TimingServiceProviderResourcePollingWait("cdn", "cdn_v1", TelemetryResourceOperationCreate, 3*time.Second, nil))
+
+	content, err := ioutil.ReadFile(tpp.getTextfilePath())
+	assert.Nil(t, err)
+	expectedContent := `# TYPE terraform_provider_http_status_total counter
+terraform_provider_http_status_total{provider_name="cdn",resource_name="cdn_v1",status_class="2xx",terraform_operation="create"} 1
+# TYPE terraform_provider_operation_duration_seconds_count counter
+terraform_provider_operation_duration_seconds_count{outcome="success",provider_name="cdn",resource_name="cdn_v1",terraform_operation="create"} 2
+# TYPE terraform_provider_operation_duration_seconds_sum counter
+terraform_provider_operation_duration_seconds_sum{outcome="success",provider_name="cdn",resource_name="cdn_v1",terraform_operation="create"} 2
+# TYPE terraform_provider_polling_wait_seconds_count counter
+terraform_provider_polling_wait_seconds_count{provider_name="cdn",resource_name="cdn_v1",terraform_operation="create"} 1
+# TYPE terraform_provider_polling_wait_seconds_sum counter
+terraform_provider_polling_wait_seconds_sum{provider_name="cdn",resource_name="cdn_v1",terraform_operation="create"} 3
+`
+	assert.Equal(t, expectedContent, string(content))
+}
+
+func TestTelemetryProviderPrometheus_TextfileFailureScenarios(t *testing.T) {
+	tpp := TelemetryProviderPrometheus{TextfileDirectory: "/non/existing/directory"}
+	err := tpp.IncOpenAPIPluginVersionTotalRunsCounter("0.25.0", nil)
+	assert.NotNil(t, err)
+}
+
+func TestTelemetryProviderPrometheus_Pushgateway(t *testing.T) {
+	var pushedMethod, pushedPath, pushedBody, pushedContentType string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pushedMethod = r.Method
+		pushedPath = r.URL.Path
+		pushedContentType = r.Header.Get(contentType)
+		body, _ := ioutil.ReadAll(r.Body)
+		pushedBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	defaultStateDir := prometheusStateDir
+	prometheusStateDir, _ = ioutil.TempDir("", "prometheus_state")
+	defer func() {
+		os.RemoveAll(prometheusStateDir)
+		prometheusStateDir = defaultStateDir
+	}()
+	tpp := TelemetryProviderPrometheus{PushgatewayURL: ts.URL}
+	assert.Nil(t, tpp.IncServiceProviderResourceTotalRunsCounter("cdn", "cdn_v1", TelemetryResourceOperationCreate, nil))
+	assert.Nil(t, tpp.IncServiceProviderResourceTotalRunsCounter("cdn", "cdn_v1", TelemetryResourceOperationRead, nil))
+	assert.Nil(t, tpp.IncServiceProviderResourceTotalRunsCounter("cdn", "cdn_v1", TelemetryResourceOperationCreate, nil))
+	assert.Equal(t, http.MethodPut, pushedMethod)
+	assert.Equal(t, "/metrics/job/terraform-provider-openapi/instance/"+prometheusInstance, pushedPath)
+	assert.Equal(t, "text/plain; version=0.0.4", pushedContentType)
+	expectedBody := `# TYPE terraform_provider_total_runs counter
+terraform_provider_total_runs{provider_name="cdn",resource_name="cdn_v1",terraform_operation="create"} 2
+terraform_provider_total_runs{provider_name="cdn",resource_name="cdn_v1",terraform_operation="read"} 1
+`
+	assert.Equal(t, expectedBody, pushedBody)
+
+	// the counters pushed by a new plugin process of the same host are accumulated with the ones pushed previously
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(prometheusStateDir, "unrelated.prom"), []byte("unrelated 1\n"), 0600))
+	assert.Nil(t, TelemetryProviderPrometheus{PushgatewayURL: ts.URL}.IncServiceProviderResourceTotalRunsCounter("cdn", "cdn_v1", TelemetryResourceOperationRead, nil))
+	expectedBody = `# TYPE terraform_provider_total_runs counter
+terraform_provider_total_runs{provider_name="cdn",resource_name="cdn_v1",terraform_operation="create"} 2
+terraform_provider_total_runs{provider_name="cdn",resource_name="cdn_v1",terraform_operation="read"} 2
+`
+	assert.Equal(t, expectedBody, pushedBody)
+}
+
+func TestTelemetryProviderPrometheus_PushgatewayFailureScenarios(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+	defaultStateDir := prometheusStateDir
+	prometheusStateDir, _ = ioutil.TempDir("", "prometheus_state")
+	defer func() {
+		os.RemoveAll(prometheusStateDir)
+		prometheusStateDir = defaultStateDir
+	}()
+	tpp := TelemetryProviderPrometheus{PushgatewayURL: ts.URL, Job: "my-job"}
+	err := tpp.IncOpenAPIPluginVersionTotalRunsCounter("0.25.0", nil)
+	assert.EqualError(t, err, fmt.Sprintf("response returned from PUT '%s/metrics/job/my-job/instance/%s' returned a non expected status code 400", ts.URL, prometheusInstance))
+	// the counters not accepted by the Pushgateway are not stored
+	files, err := ioutil.ReadDir(prometheusStateDir)
+	assert.Nil(t, err)
+	assert.Empty(t, files)
+}
+
+func TestPrometheusSamplesFormat(t *testing.T) {
+	samples := prometheusSamples{}.
+		add("some_metric", map[string]string{}, 1).
+		add("some_metric", map[string]string{"label": `with "quotes", commas and \ backslash`}, 2.5)
+	assert.Equal(t, "# TYPE some_metric counter\nsome_metric 1\nsome_metric{label=\"with \\\"quotes\\\", commas and \\\\ backslash\"} 2.5\n", samples.format())
+}
+
+func TestParsePrometheusSamples(t *testing.T) {
+	samples := prometheusSamples{}.
+		add("some_metric", map[string]string{}, 1).
+		add("some_metric", map[string]string{"label": "with \"quotes\", commas, \\ backslash and\nnew line", "other": "value"}, 2.5)
+	parsedSamples, err := parsePrometheusSamples(samples.format())
+	assert.Nil(t, err)
+	assert.Equal(t, samples.format(), parsedSamples.format())
+
+	_, err = parsePrometheusSamples("some_metric{label=\"value} 1\n")
+	assert.EqualError(t, err, "sample 'some_metric{label=\"value} 1' does not have valid labels: label 'label' value is not terminated")
+	_, err = parsePrometheusSamples("some_metric not_a_number\n")
+	assert.NotNil(t, err)
+}
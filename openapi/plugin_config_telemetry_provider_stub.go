@@ -1,6 +1,10 @@
 package openapi
 
-import "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
 
 type telemetryProviderStub struct {
 	validationError              error
@@ -9,6 +13,10 @@ type telemetryProviderStub struct {
 	providerNameReceived         string
 	resourceNameReceived         string
 	tfOperationReceived          TelemetryResourceOperation
+	outcomeReceived              TelemetryResourceOperationOutcome
+	durationReceived             time.Duration
+	statusClassReceived          string
+	pollingWaitReceived          time.Duration
 	telemetryProviderConfig      TelemetryProviderConfiguration
 }
 
@@ -34,6 +42,31 @@ func (t *telemetryProviderStub) IncServiceProviderResourceTotalRunsCounter(provi
 	return nil
 }
 
+func (t *telemetryProviderStub) TimingServiceProviderResourceOperationDuration(providerName, resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome, duration time.Duration, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	t.providerNameReceived = providerName
+	t.resourceNameReceived = resourceName
+	t.tfOperationReceived = tfOperation
+	t.outcomeReceived = outcome
+	t.durationReceived = duration
+	return nil
+}
+
+func (t *telemetryProviderStub) IncServiceProviderResourceHTTPStatusClassCounter(providerName, resourceName string, tfOperation TelemetryResourceOperation, statusClass string, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	t.providerNameReceived = providerName
+	t.resourceNameReceived = resourceName
+	t.tfOperationReceived = tfOperation
+	t.statusClassReceived = statusClass
+	return nil
+}
+
+func (t *telemetryProviderStub) TimingServiceProviderResourcePollingWait(providerName, resourceName string, tfOperation TelemetryResourceOperation, duration time.Duration, telemetryProviderConfiguration TelemetryProviderConfiguration) error {
+	t.providerNameReceived = providerName
+	t.resourceNameReceived = resourceName
+	t.tfOperationReceived = tfOperation
+	t.pollingWaitReceived = duration
+	return nil
+}
+
 func (t *telemetryProviderStub) GetTelemetryProviderConfiguration(data *schema.ResourceData) TelemetryProviderConfiguration {
 	return t.telemetryProviderConfig
 }
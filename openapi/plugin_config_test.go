@@ -196,7 +196,7 @@ services:
 					Port:   expectedTelemetryPort,
 					Prefix: "openapi",
 				}
-				So(serviceConfiguration.GetTelemetryConfiguration(), ShouldResemble, TelemetryProvider(expectedGraphiteProvider))
+				So(serviceConfiguration.GetTelemetryConfiguration(), ShouldResemble, []TelemetryProvider{expectedGraphiteProvider})
 			})
 		})
 	})
@@ -234,7 +234,40 @@ services:
 					URL:    expectedTelemetryHost,
 					Prefix: expectedPrefix,
 				}
-				So(serviceConfiguration.GetTelemetryConfiguration(), ShouldResemble, TelemetryProvider(expectedHTTPEndpointProvider))
+				So(serviceConfiguration.GetTelemetryConfiguration(), ShouldResemble, []TelemetryProvider{expectedHTTPEndpointProvider})
+			})
+		})
+	})
+
+	Convey("Given a PluginConfiguration for 'test' provider and a plugin configuration file containing a list of telemetry providers and a service called 'test'", t, func() {
+		pluginConfig := fmt.Sprintf(`version: '1'
+services:
+    %s:
+      telemetry:
+        providers:
+          - graphite:
+              host: telemetry.myhost.com
+              port: 8125
+            timeout: 1
+          - http_endpoint:
+              url: http://some-host/v1/metrics
+      swagger-url: %s`, providerName, otfVarSwaggerURLValue)
+		configReader := strings.NewReader(pluginConfig)
+		pluginConfiguration := PluginConfiguration{
+			ProviderName:  providerName,
+			Configuration: configReader,
+		}
+		Convey("When getServiceConfiguration is called", func() {
+			serviceConfiguration, err := pluginConfiguration.getServiceConfiguration()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the serviceConfiguration contains both telemetry providers along with their timeouts", func() {
+				expectedTelemetryProviders := []TelemetryProvider{
+					telemetryProviderWithTimeout{TelemetryProvider: &TelemetryProviderGraphite{Host: "telemetry.myhost.com", Port: 8125}, timeout: 1},
+					telemetryProviderWithTimeout{TelemetryProvider: &TelemetryProviderHTTPEndpoint{URL: "http://some-host/v1/metrics"}},
+				}
+				So(serviceConfiguration.GetTelemetryConfiguration(), ShouldResemble, expectedTelemetryProviders)
 			})
 		})
 	})
@@ -0,0 +1,45 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+)
+
+const defaultTracingTimeout = 5 * time.Second
+
+// TracingConfig defines the configuration needed to export traces via OTLP/HTTP (e,g: to an OpenTelemetry collector)
+type TracingConfig struct {
+	// Endpoint describes the OTLP/HTTP endpoint where the traces are exported to (e,g: http://otel-collector:4318). If the
+	// URL provided does not end with the /v1/traces path it will be appended automatically
+	Endpoint string `yaml:"endpoint"`
+	// Headers describes the headers sent along with the export requests (e,g: authentication headers required by the backend)
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// ServiceName describes the value of the 'service.name' resource attribute. Defaults to terraform-provider-<provider_name>
+	ServiceName string `yaml:"service_name,omitempty"`
+	// Timeout describes the maximum time in seconds to wait for the export requests to complete. Defaults to 5 seconds
+	Timeout int `yaml:"timeout,omitempty"`
+}
+
+// Validate checks whether the tracing configuration is valid
+func (t *TracingConfig) Validate() error {
+	if t.Endpoint == "" {
+		return errors.New("tracing configuration is missing a value for the 'endpoint' property")
+	}
+	if !govalidator.IsURL(t.Endpoint) {
+		return fmt.Errorf("tracing configuration does not have a valid endpoint URL '%s'", t.Endpoint)
+	}
+	if t.Timeout < 0 {
+		return fmt.Errorf("tracing configuration timeout '%d' must be a positive number", t.Timeout)
+	}
+	return nil
+}
+
+func (t *TracingConfig) getTimeout() time.Duration {
+	if t.Timeout > 0 {
+		return time.Duration(t.Timeout) * time.Second
+	}
+	return defaultTracingTimeout
+}
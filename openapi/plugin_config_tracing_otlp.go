@@ -0,0 +1,374 @@
+package openapi
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const traceparentHeader = "traceparent"
+const otlpTracesPath = "/v1/traces"
+const otlpScopeName = "terraform-provider-openapi"
+
+// otlpTracerMaxQueueSize is the maximum number of traces waiting to be exported. Traces finished when the queue is full are dropped
+const otlpTracerMaxQueueSize = 100
+
+// spanKind values as defined in the OTLP specification
+type spanKind int
+
+const (
+	spanKindInternal spanKind = 1
+	spanKindClient   spanKind = 3
+)
+
+// span status codes as defined in the OTLP specification
+const (
+	spanStatusCodeOk    = 1
+	spanStatusCodeError = 2
+)
+
+// otlpTracer creates spans and exports them to the configured OTLP/HTTP endpoint using the OTLP JSON encoding. Finished
+// spans are buffered and queued as a trace whenever a root span (e,g: a resource CRUD operation) finishes. The traces
+// queued are exported from a background worker so the resource operations never wait for the export requests; the queue
+// is flushed upon shutdown (see ShutdownTelemetry). All the methods are safe to be called on a nil tracer, in which case
+// tracing is disabled and no spans are created
+type otlpTracer struct {
+	endpoint    string
+	headers     map[string]string
+	serviceName string
+	httpClient  *http.Client
+
+	mutex         sync.Mutex
+	finishedSpans []*traceSpan
+
+	once      sync.Once
+	queue     chan []*traceSpan
+	flushChan chan chan struct{}
+}
+
+func newOTLPTracer(tracingConfig *TracingConfig, defaultServiceName string) *otlpTracer {
+	endpoint := strings.TrimSuffix(tracingConfig.Endpoint, "/")
+	if !strings.HasSuffix(endpoint, otlpTracesPath) {
+		endpoint = endpoint + otlpTracesPath
+	}
+	serviceName := tracingConfig.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	return &otlpTracer{
+		endpoint:    endpoint,
+		headers:     tracingConfig.Headers,
+		serviceName: serviceName,
+		httpClient:  &http.Client{Timeout: tracingConfig.getTimeout()},
+		queue:       make(chan []*traceSpan, otlpTracerMaxQueueSize),
+		flushChan:   make(chan chan struct{}),
+	}
+}
+
+// startSpan starts a new span. If parent is nil the span will be a root span of a new trace
+func (t *otlpTracer) startSpan(name string, kind spanKind, parent *traceSpan) *traceSpan {
+	if t == nil {
+		return nil
+	}
+	span := &traceSpan{
+		tracer:     t,
+		spanID:     newTraceID(8),
+		name:       name,
+		kind:       kind,
+		startTime:  time.Now(),
+		attributes: map[string]interface{}{},
+	}
+	if parent != nil {
+		span.traceID = parent.traceID
+		span.parentSpanID = parent.spanID
+	} else {
+		span.traceID = newTraceID(16)
+	}
+	return span
+}
+
+// finishSpan buffers the span. If the span is a root span, the spans buffered are queued to be exported by the background
+// worker; if the queue is full the spans are dropped
+func (t *otlpTracer) finishSpan(span *traceSpan) {
+	t.mutex.Lock()
+	t.finishedSpans = append(t.finishedSpans, span)
+	t.mutex.Unlock()
+	if span.parentSpanID != "" {
+		return
+	}
+	t.start()
+	spans := t.takeFinishedSpans()
+	select {
+	case t.queue <- spans:
+	default:
+		log.Printf("[WARN] traces export queue is full, dropping %d spans of the trace '%s'", len(spans), span.traceID)
+	}
+}
+
+func (t *otlpTracer) takeFinishedSpans() []*traceSpan {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	spans := t.finishedSpans
+	t.finishedSpans = nil
+	return spans
+}
+
+// start starts the background worker (only once) and registers the tracer so it gets flushed upon shutdown
+func (t *otlpTracer) start() {
+	t.once.Do(func() {
+		go t.run()
+		registerOTLPTracer(t)
+	})
+}
+
+func (t *otlpTracer) run() {
+	for {
+		select {
+		case spans := <-t.queue:
+			t.exportSpans(spans)
+		case done := <-t.flushChan:
+			// the process is about to exit so all the spans pending (including the ones of traces not finished) are
+			// exported in one request
+			var spans []*traceSpan
+			for len(t.queue) > 0 {
+				spans = append(spans, <-t.queue...)
+			}
+			t.exportSpans(append(spans, t.takeFinishedSpans()...))
+			close(done)
+		}
+	}
+}
+
+// flush exports all the spans pending and waits till they are exported or the timeout expires
+func (t *otlpTracer) flush(timeout time.Duration) error {
+	t.start()
+	deadline := time.After(timeout)
+	done := make(chan struct{})
+	select {
+	case t.flushChan <- done:
+	case <-deadline:
+		return fmt.Errorf("traces export to '%s' did not start within the expected time %s", t.endpoint, timeout)
+	}
+	select {
+	case <-done:
+		return nil
+	case <-deadline:
+		return fmt.Errorf("traces export to '%s' did not finish within the expected time %s", t.endpoint, timeout)
+	}
+}
+
+func (t *otlpTracer) exportSpans(spans []*traceSpan) {
+	if err := t.export(spans); err != nil {
+		log.Printf("[WARN] failed to export traces to '%s': %s", t.endpoint, err)
+	}
+}
+
+// export sends the spans to the OTLP/HTTP endpoint
+func (t *otlpTracer) export(spans []*traceSpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(t.buildExportRequest(spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, t.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set(contentType, "application/json")
+	req.Header.Set(userAgentHeader, version.BuildUserAgent(runtime.GOOS, runtime.GOARCH))
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("export request returned a non expected status code %d", resp.StatusCode)
+	}
+	log.Printf("[DEBUG] exported %d spans to '%s'", len(spans), t.endpoint)
+	return nil
+}
+
+var otlpTracers struct {
+	sync.Mutex
+	tracers []*otlpTracer
+}
+
+func registerOTLPTracer(tracer *otlpTracer) {
+	otlpTracers.Lock()
+	defer otlpTracers.Unlock()
+	otlpTracers.tracers = append(otlpTracers.tracers, tracer)
+}
+
+func (t *otlpTracer) buildExportRequest(spans []*traceSpan) map[string]interface{} {
+	var otlpSpans []interface{}
+	for _, span := range spans {
+		otlpSpans = append(otlpSpans, span.toOTLP())
+	}
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": toOTLPAttributes(map[string]interface{}{"service.name": t.serviceName}),
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{"name": otlpScopeName, "version": version.Version},
+						"spans": otlpSpans,
+					},
+				},
+			},
+		},
+	}
+}
+
+// traceSpan represents an operation traced. All the methods are safe to be called on a nil span so callers do not need
+// to check whether tracing is enabled
+type traceSpan struct {
+	tracer       *otlpTracer
+	traceID      string
+	spanID       string
+	parentSpanID string
+	name         string
+	kind         spanKind
+	startTime    time.Time
+	endTime      time.Time
+
+	mutex      sync.Mutex
+	attributes map[string]interface{}
+	err        error
+}
+
+// startChildSpan starts a new span within the same trace having this span as parent
+func (s *traceSpan) startChildSpan(name string, kind spanKind) *traceSpan {
+	if s == nil {
+		return nil
+	}
+	return s.tracer.startSpan(name, kind, s)
+}
+
+func (s *traceSpan) setAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.attributes[key] = value
+}
+
+// end finishes the span recording the error (if any) as the span status
+func (s *traceSpan) end(err error) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	s.endTime = time.Now()
+	s.err = err
+	s.mutex.Unlock()
+	s.tracer.finishSpan(s)
+}
+
+// traceparent returns the W3C trace context header value identifying this span
+func (s *traceSpan) traceparent() string {
+	if s == nil {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", s.traceID, s.spanID)
+}
+
+func (s *traceSpan) toOTLP() map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	otlpSpan := map[string]interface{}{
+		"traceId":           s.traceID,
+		"spanId":            s.spanID,
+		"name":              s.name,
+		"kind":              s.kind,
+		"startTimeUnixNano": strconv.FormatInt(s.startTime.UnixNano(), 10),
+		"endTimeUnixNano":   strconv.FormatInt(s.endTime.UnixNano(), 10),
+		"attributes":        toOTLPAttributes(s.attributes),
+		"status":            map[string]interface{}{"code": spanStatusCodeOk},
+	}
+	if s.parentSpanID != "" {
+		otlpSpan["parentSpanId"] = s.parentSpanID
+	}
+	if s.err != nil {
+		otlpSpan["status"] = map[string]interface{}{"code": spanStatusCodeError, "message": s.err.Error()}
+	}
+	return otlpSpan
+}
+
+func toOTLPAttributes(attributes map[string]interface{}) []interface{} {
+	otlpAttributes := []interface{}{}
+	for key, value := range attributes {
+		var otlpValue map[string]interface{}
+		switch v := value.(type) {
+		case int:
+			otlpValue = map[string]interface{}{"intValue": strconv.Itoa(v)}
+		case bool:
+			otlpValue = map[string]interface{}{"boolValue": v}
+		case float64:
+			otlpValue = map[string]interface{}{"doubleValue": v}
+		default:
+			otlpValue = map[string]interface{}{"stringValue": fmt.Sprintf("%v", v)}
+		}
+		otlpAttributes = append(otlpAttributes, map[string]interface{}{"key": key, "value": otlpValue})
+	}
+	return otlpAttributes
+}
+
+func newTraceID(size int) string {
+	id := make([]byte, size)
+	if _, err := rand.Read(id); err != nil {
+		log.Printf("[WARN] failed to generate random trace id: %s", err)
+	}
+	return hex.EncodeToString(id)
+}
+
+// clientOpenAPITracer is implemented by the ClientOpenAPI implementations that support tracing
+type clientOpenAPITracer interface {
+	// startSpan starts a new span and returns a client that will create any subsequent spans (e,g: HTTP calls) as children of it
+	startSpan(name string) (*traceSpan, ClientOpenAPI)
+}
+
+// startClientSpan starts a new span if the client supports tracing. The client returned must be used for the operations
+// that should be traced as part of the span
+func startClientSpan(client ClientOpenAPI, name string) (*traceSpan, ClientOpenAPI) {
+	if tracer, ok := client.(clientOpenAPITracer); ok {
+		return tracer.startSpan(name)
+	}
+	return nil, client
+}
+
+// traceOperation wraps the resource/data source operation so a span is created for each operation executed
+func traceOperation(spanName, resourceName string, operation TelemetryResourceOperation, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(data *schema.ResourceData, i interface{}) error {
+		providerClient, ok := i.(ClientOpenAPI)
+		if !ok {
+			return f(data, i)
+		}
+		span, tracedClient := startClientSpan(providerClient, spanName)
+		span.setAttribute("terraform.resource_name", resourceName)
+		span.setAttribute("terraform.operation", string(operation))
+		err := f(data, tracedClient)
+		span.setAttribute("terraform.resource_id", data.Id())
+		span.end(err)
+		return err
+	}
+}
@@ -0,0 +1,330 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dikhan/http_goclient"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// otlpCollectorStub is an OTLP/HTTP collector that keeps track of the spans exported
+type otlpCollectorStub struct {
+	server  *httptest.Server
+	mutex   sync.Mutex
+	headers http.Header
+	spans   []map[string]interface{}
+}
+
+func newOTLPCollectorStub() *otlpCollectorStub {
+	c := &otlpCollectorStub{}
+	c.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		if r.URL.Path != otlpTracesPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		c.headers = r.Header
+		body, _ := ioutil.ReadAll(r.Body)
+		exportRequest := map[string]interface{}{}
+		json.Unmarshal(body, &exportRequest)
+		for _, resourceSpans := range exportRequest["resourceSpans"].([]interface{}) {
+			for _, scopeSpans := range resourceSpans.(map[string]interface{})["scopeSpans"].([]interface{}) {
+				for _, span := range scopeSpans.(map[string]interface{})["spans"].([]interface{}) {
+					c.spans = append(c.spans, span.(map[string]interface{}))
+				}
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	return c
+}
+
+func (c *otlpCollectorStub) getSpan(name string) map[string]interface{} {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, span := range c.spans {
+		if span["name"] == name {
+			return span
+		}
+	}
+	return nil
+}
+
+func (c *otlpCollectorStub) getSpans(name string) []map[string]interface{} {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var spans []map[string]interface{}
+	for _, span := range c.spans {
+		if span["name"] == name {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+func getOTLPAttribute(span map[string]interface{}, key string) interface{} {
+	for _, attribute := range span["attributes"].([]interface{}) {
+		a := attribute.(map[string]interface{})
+		if a["key"] == key {
+			for _, value := range a["value"].(map[string]interface{}) {
+				return value
+			}
+		}
+	}
+	return nil
+}
+
+func TestNewOTLPTracer(t *testing.T) {
+	Convey("Given a tracing configuration with the base endpoint of the collector", t, func() {
+		tracingConfig := &TracingConfig{Endpoint: "http://otel-collector:4318/", Timeout: 10}
+		Convey("When newOTLPTracer is called", func() {
+			tracer := newOTLPTracer(tracingConfig, "terraform-provider-cdn")
+			Convey("Then the tracer should export to the traces path with the default service name and the configured timeout", func() {
+				So(tracer.endpoint, ShouldEqual, "http://otel-collector:4318/v1/traces")
+				So(tracer.serviceName, ShouldEqual, "terraform-provider-cdn")
+				So(tracer.httpClient.Timeout.Seconds(), ShouldEqual, 10)
+			})
+		})
+	})
+	Convey("Given a tracing configuration with the full traces endpoint and a service name", t, func() {
+		tracingConfig := &TracingConfig{Endpoint: "http://otel-collector:4318/v1/traces", ServiceName: "my-service"}
+		Convey("When newOTLPTracer is called", func() {
+			tracer := newOTLPTracer(tracingConfig, "terraform-provider-cdn")
+			Convey("Then the tracer should keep the endpoint and use the configured service name", func() {
+				So(tracer.endpoint, ShouldEqual, "http://otel-collector:4318/v1/traces")
+				So(tracer.serviceName, ShouldEqual, "my-service")
+				So(tracer.httpClient.Timeout, ShouldEqual, defaultTracingTimeout)
+			})
+		})
+	})
+}
+
+func TestOTLPTracerSpans(t *testing.T) {
+	Convey("Given a tracer configured to export to a collector", t, func() {
+		collector := newOTLPCollectorStub()
+		defer collector.server.Close()
+		tracer := newOTLPTracer(&TracingConfig{Endpoint: collector.server.URL, Headers: map[string]string{"X-Api-Key": "secret"}}, "terraform-provider-cdn")
+		Convey("When a root span and a child span are started and ended", func() {
+			root := tracer.startSpan("create cdn_v1", spanKindInternal, nil)
+			child := root.startChildSpan("HTTP POST", spanKindClient)
+			child.setAttribute("http.status_code", 201)
+			child.end(nil)
+			So(collector.getSpan("HTTP POST"), ShouldBeNil) // child spans are only exported when the root span finishes
+			root.end(errors.New("some error"))
+			So(tracer.flush(time.Second), ShouldBeNil)
+			Convey("Then both spans should be exported belonging to the same trace", func() {
+				rootSpan := collector.getSpan("create cdn_v1")
+				childSpan := collector.getSpan("HTTP POST")
+				So(rootSpan, ShouldNotBeNil)
+				So(childSpan, ShouldNotBeNil)
+				So(childSpan["traceId"], ShouldEqual, rootSpan["traceId"])
+				So(childSpan["parentSpanId"], ShouldEqual, rootSpan["spanId"])
+				So(rootSpan, ShouldNotContainKey, "parentSpanId")
+				So(childSpan["kind"], ShouldEqual, spanKindClient)
+				So(getOTLPAttribute(childSpan, "http.status_code"), ShouldEqual, "201")
+			})
+			Convey("And the span status should reflect the error", func() {
+				So(collector.getSpan("create cdn_v1")["status"], ShouldResemble, map[string]interface{}{"code": float64(spanStatusCodeError), "message": "some error"})
+				So(collector.getSpan("HTTP POST")["status"], ShouldResemble, map[string]interface{}{"code": float64(spanStatusCodeOk)})
+			})
+			Convey("And the configured headers should be sent to the collector", func() {
+				So(collector.headers.Get("X-Api-Key"), ShouldEqual, "secret")
+				So(collector.headers.Get(contentType), ShouldEqual, "application/json")
+			})
+			Convey("And the traceparent of the span should follow the W3C trace context format", func() {
+				So(regexp.MustCompile("^00-[0-9a-f]{32}-[0-9a-f]{16}-01$").MatchString(child.traceparent()), ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a tracer configured to export to a collector that takes longer than the tracer timeout to respond", t, func() {
+		release := make(chan struct{})
+		collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer collector.Close()
+		defer close(release)
+		tracer := newOTLPTracer(&TracingConfig{Endpoint: collector.URL, Timeout: 60}, "terraform-provider-cdn")
+		Convey("When more root spans than the export queue size are ended", func() {
+			start := time.Now()
+			for i := 0; i < otlpTracerMaxQueueSize+10; i++ {
+				tracer.startSpan("create cdn_v1", spanKindInternal, nil).end(nil)
+			}
+			Convey("Then the spans should be exported in the background without blocking the caller and the queue should stay bounded", func() {
+				So(time.Since(start), ShouldBeLessThan, time.Second)
+				So(len(tracer.queue), ShouldBeLessThanOrEqualTo, otlpTracerMaxQueueSize)
+			})
+		})
+	})
+	Convey("Given a tracer with spans pending to be exported", t, func() {
+		collector := newOTLPCollectorStub()
+		defer collector.server.Close()
+		tracer := newOTLPTracer(&TracingConfig{Endpoint: collector.server.URL}, "terraform-provider-cdn")
+		root := tracer.startSpan("create cdn_v1", spanKindInternal, nil)
+		root.startChildSpan("HTTP POST", spanKindClient).end(nil)
+		root.end(nil)
+		Convey("When ShutdownTelemetry is called", func() {
+			ShutdownTelemetry()
+			Convey("Then the spans pending should have been exported", func() {
+				So(collector.getSpan("create cdn_v1"), ShouldNotBeNil)
+				So(collector.getSpan("HTTP POST"), ShouldNotBeNil)
+			})
+		})
+	})
+	Convey("Given a nil tracer (tracing disabled)", t, func() {
+		var tracer *otlpTracer
+		Convey("When spans are started and ended", func() {
+			span := tracer.startSpan("create cdn_v1", spanKindInternal, nil)
+			child := span.startChildSpan("HTTP POST", spanKindClient)
+			child.setAttribute("http.status_code", 201)
+			child.end(nil)
+			span.end(nil)
+			Convey("Then no spans should be created", func() {
+				So(span, ShouldBeNil)
+				So(child, ShouldBeNil)
+				So(span.traceparent(), ShouldEqual, "")
+			})
+		})
+	})
+}
+
+func TestProviderClientTracing(t *testing.T) {
+	Convey("Given a providerClient configured with a tracer", t, func() {
+		collector := newOTLPCollectorStub()
+		defer collector.server.Close()
+		httpClient := &http_goclient.HttpClientStub{Response: &http.Response{StatusCode: http.StatusCreated}}
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{host: "wwww.host.com", httpScheme: "http"},
+			httpClient:                  httpClient,
+			apiAuthenticator:            &specStubAuthenticator{authContext: &authContext{headers: map[string]string{}}},
+			tracer:                      newOTLPTracer(&TracingConfig{Endpoint: collector.server.URL}, "terraform-provider-cdn"),
+		}
+		r := newResourceFactory(&specStubResource{name: "cdn_v1"})
+		Convey("When an operation wrapped with traceOperation performs a request", func() {
+			operation := traceOperation("create cdn_v1", "cdn_v1", TelemetryResourceOperationCreate, func(data *schema.ResourceData, i interface{}) error {
+				data.SetId("1234")
+				_, err := i.(*ProviderClient).performRequest(httpPost, "http://wwww.host.com/v1/cdns", &specResourceOperation{}, nil, nil)
+				return err
+			})
+			data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+			err := operation(data, providerClient)
+			So(err, ShouldBeNil)
+			So(providerClient.tracer.flush(time.Second), ShouldBeNil)
+			Convey("Then the operation span should be exported with the resource attributes", func() {
+				operationSpan := collector.getSpan("create cdn_v1")
+				So(operationSpan, ShouldNotBeNil)
+				So(getOTLPAttribute(operationSpan, "terraform.resource_name"), ShouldEqual, "cdn_v1")
+				So(getOTLPAttribute(operationSpan, "terraform.operation"), ShouldEqual, "create")
+				So(getOTLPAttribute(operationSpan, "terraform.resource_id"), ShouldEqual, "1234")
+			})
+			Convey("And the HTTP call span should be a child of the operation span", func() {
+				operationSpan := collector.getSpan("create cdn_v1")
+				httpSpan := collector.getSpan("HTTP POST")
+				So(httpSpan, ShouldNotBeNil)
+				So(httpSpan["parentSpanId"], ShouldEqual, operationSpan["spanId"])
+				So(getOTLPAttribute(httpSpan, "http.status_code"), ShouldEqual, "201")
+				So(getOTLPAttribute(httpSpan, "http.url"), ShouldEqual, "http://wwww.host.com/v1/cdns")
+				So(getOTLPAttribute(httpSpan, "http.retry_count"), ShouldEqual, "0")
+			})
+			Convey("And the traceparent header identifying the HTTP call span should be propagated to the API", func() {
+				httpSpan := collector.getSpan("HTTP POST")
+				So(httpClient.Headers[traceparentHeader], ShouldEqual, "00-"+httpSpan["traceId"].(string)+"-"+httpSpan["spanId"].(string)+"-01")
+			})
+			Convey("And the original client should not be modified", func() {
+				So(providerClient.parentSpan, ShouldBeNil)
+			})
+		})
+		Convey("When a request sent with an idempotency key is retried", func() {
+			defaultRetryBackoff := idempotentRequestRetryBackoff
+			idempotentRequestRetryBackoff = time.Millisecond
+			defer func() { idempotentRequestRetryBackoff = defaultRetryBackoff }()
+			providerClient.httpClient = &httpClientNoResponseStub{failures: 1, HttpClientStub: http_goclient.HttpClientStub{Response: &http.Response{StatusCode: http.StatusCreated}}}
+			_, err := providerClient.performRequest(httpPost, "http://wwww.host.com/v1/cdns", &specResourceOperation{IdempotencyKeyHeader: idempotencyKeyHeader}, nil, nil)
+			So(err, ShouldBeNil)
+			So(providerClient.tracer.flush(time.Second), ShouldBeNil)
+			Convey("Then a span should be exported per attempt with the number of retries performed", func() {
+				httpSpans := collector.getSpans("HTTP POST")
+				So(httpSpans, ShouldHaveLength, 2)
+				So(getOTLPAttribute(httpSpans[0], "http.retry_count"), ShouldEqual, "0")
+				So(getOTLPAttribute(httpSpans[1], "http.retry_count"), ShouldEqual, "1")
+				So(getOTLPAttribute(httpSpans[1], "http.status_code"), ShouldEqual, "201")
+			})
+		})
+		Convey("When the resource state is polled", func() {
+			data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+			data.SetId("1234")
+			_, tracedClient := providerClient.startSpan("update cdn_v1")
+			refresh := r.resourceStateRefreshFunc(data, tracedClient)
+			refresh()
+			refresh()
+			Convey("Then a span should be created for each polling iteration with the attempt number", func() {
+				So(tracedClient.(*ProviderClient).parentSpan, ShouldNotBeNil)
+				tracedClient.(*ProviderClient).parentSpan.end(nil)
+				So(providerClient.tracer.flush(time.Second), ShouldBeNil)
+				var attempts []interface{}
+				collector.mutex.Lock()
+				for _, span := range collector.spans {
+					if span["name"] == "poll cdn_v1" {
+						attempts = append(attempts, getOTLPAttribute(span, "terraform.poll.attempt"))
+					}
+				}
+				collector.mutex.Unlock()
+				So(attempts, ShouldResemble, []interface{}{"1", "2"})
+			})
+		})
+	})
+	Convey("Given a providerClient without a tracer", t, func() {
+		httpClient := &http_goclient.HttpClientStub{Response: &http.Response{StatusCode: http.StatusOK}}
+		providerClient := &ProviderClient{
+			httpClient:       httpClient,
+			apiAuthenticator: &specStubAuthenticator{authContext: &authContext{headers: map[string]string{}}},
+		}
+		Convey("When startClientSpan and performRequest are called", func() {
+			span, client := startClientSpan(providerClient, "create cdn_v1")
+			_, err := client.(*ProviderClient).performRequest(httpGet, "http://wwww.host.com/v1/cdns/1234", &specResourceOperation{}, nil, nil)
+			Convey("Then no span should be created, the same client should be returned and no traceparent header should be sent", func() {
+				So(err, ShouldBeNil)
+				So(span, ShouldBeNil)
+				So(client, ShouldEqual, providerClient)
+				So(httpClient.Headers, ShouldNotContainKey, traceparentHeader)
+			})
+		})
+	})
+}
+
+func TestRefreshTokenTracing(t *testing.T) {
+	Convey("Given a refresh token authenticator and an auth context with a parent span", t, func() {
+		collector := newOTLPCollectorStub()
+		defer collector.server.Close()
+		tracer := newOTLPTracer(&TracingConfig{Endpoint: collector.server.URL}, "terraform-provider-cdn")
+		parentSpan := tracer.startSpan("HTTP POST", spanKindClient, nil)
+		httpClient := &http_goclient.HttpClientStub{Response: &http.Response{StatusCode: http.StatusOK, Header: http.Header{authorizationHeader: []string{"Bearer access-token"}}}}
+		refreshTokenAuthenticator := apiRefreshTokenAuthenticator{
+			apiKey:          apiKey{name: authorizationHeader, value: "refresh-token"},
+			refreshTokenURL: "https://auth.host.com/refresh",
+			httpClient:      httpClient,
+		}
+		Convey("When prepareAuth is called", func() {
+			err := refreshTokenAuthenticator.prepareAuth(&authContext{parentSpan: parentSpan})
+			So(err, ShouldBeNil)
+			parentSpan.end(nil)
+			So(tracer.flush(time.Second), ShouldBeNil)
+			Convey("Then a token refresh span child of the parent span should be exported and its traceparent propagated", func() {
+				refreshSpan := collector.getSpan("token refresh")
+				So(refreshSpan, ShouldNotBeNil)
+				So(refreshSpan["parentSpanId"], ShouldEqual, parentSpan.spanID)
+				So(getOTLPAttribute(refreshSpan, "http.url"), ShouldEqual, "https://auth.host.com/refresh")
+				So(httpClient.Headers[traceparentHeader], ShouldEqual, "00-"+parentSpan.traceID+"-"+refreshSpan["spanId"].(string)+"-01")
+			})
+		})
+	})
+}
@@ -0,0 +1,34 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTracingConfigValidate(t *testing.T) {
+	Convey("Given a list of tracing configurations", t, func() {
+		testCases := []struct {
+			name          string
+			config        TracingConfig
+			expectedError string
+		}{
+			{name: "valid configuration", config: TracingConfig{Endpoint: "http://otel-collector:4318"}},
+			{name: "configuration missing the endpoint", config: TracingConfig{}, expectedError: "tracing configuration is missing a value for the 'endpoint' property"},
+			{name: "configuration with a wrong endpoint", config: TracingConfig{Endpoint: "htop://otel-collector"}, expectedError: "tracing configuration does not have a valid endpoint URL 'htop://otel-collector'"},
+			{name: "configuration with a negative timeout", config: TracingConfig{Endpoint: "http://otel-collector:4318", Timeout: -1}, expectedError: "tracing configuration timeout '-1' must be a positive number"},
+		}
+		for _, tc := range testCases {
+			Convey("When Validate is called with a "+tc.name, func() {
+				err := tc.config.Validate()
+				Convey("Then the error returned should be the expected one", func() {
+					if tc.expectedError == "" {
+						So(err, ShouldBeNil)
+					} else {
+						So(err.Error(), ShouldEqual, tc.expectedError)
+					}
+				})
+			})
+		}
+	})
+}
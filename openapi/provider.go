@@ -38,14 +38,9 @@ func (p *ProviderOpenAPI) CreateSchemaProviderFromServiceConfiguration(serviceCo
 
 	log.Printf("[DEBUG] service configuration = %+v", serviceConfiguration)
 
-	openAPISpecAnalyser, err := CreateSpecAnalyser(specAnalyserV2, serviceConfiguration.GetSwaggerURL())
+	providerFactory, err := newProviderFactoryFromServiceConfiguration(p.ProviderName, serviceConfiguration)
 	if err != nil {
-		return nil, fmt.Errorf("plugin OpenAPI spec analyser error: %s", err)
-	}
-
-	providerFactory, err := newProviderFactory(p.ProviderName, openAPISpecAnalyser, serviceConfiguration)
-	if err != nil {
-		return nil, fmt.Errorf("plugin provider factory init error: %s", err)
+		return nil, err
 	}
 
 	p.provider, err = providerFactory.createProvider()
@@ -41,6 +41,19 @@ func newProviderFactory(name string, specAnalyser SpecAnalyser, serviceConfigura
 	}, nil
 }
 
+// newProviderFactoryFromServiceConfiguration creates the provider factory for the OpenAPI document configured in the service configuration
+func newProviderFactoryFromServiceConfiguration(name string, serviceConfiguration ServiceConfiguration) (*providerFactory, error) {
+	openAPISpecAnalyser, err := CreateSpecAnalyserWithOverrides(specAnalyserV2, serviceConfiguration.GetSwaggerURL(), serviceConfiguration.GetOverrides())
+	if err != nil {
+		return nil, fmt.Errorf("plugin OpenAPI spec analyser error: %s", err)
+	}
+	providerFactory, err := newProviderFactory(name, openAPISpecAnalyser, serviceConfiguration)
+	if err != nil {
+		return nil, fmt.Errorf("plugin provider factory init error: %s", err)
+	}
+	return providerFactory, nil
+}
+
 func (p providerFactory) createProvider() (*schema.Provider, error) {
 	var providerSchema map[string]*schema.Schema
 	var resourceMap map[string]*schema.Resource
@@ -155,15 +168,43 @@ func (p providerFactory) configureProviderPropertyFromPluginConfig(providerSchem
 		if err != nil {
 			log.Printf("[ERROR] %s", err)
 		}
+		// the value of properties whose profile is selected by another provider property is resolved when the provider
+		// is configured (see configureProviderPropertiesFromProfile) so the values provided by the user can be told apart
+		// from the default ones. Hence, the property is registered as optional without a default value
+		if profileSchemaPropertyName := schemaPropertyConfiguration.GetProfileSchemaPropertyName(); profileSchemaPropertyName != "" {
+			providerSchema[schemaPropertyName] = terraformutils.CreateStringSchemaProperty(schemaPropertyName, false, "")
+			log.Printf("[DEBUG] registered new property '%s' (required=%t, resolved at configure time) into provider schema", schemaPropertyName, required)
+			// register the property used to select the external configuration profile if not already part of the provider schema
+			if _, exists := providerSchema[profileSchemaPropertyName]; !exists {
+				providerSchema[profileSchemaPropertyName] = terraformutils.CreateStringSchemaProperty(profileSchemaPropertyName, false, "")
+				log.Printf("[DEBUG] registered new profile property '%s' into provider schema", profileSchemaPropertyName)
+			}
+			return
+		}
 		defaultValue, err = schemaPropertyConfiguration.GetDefaultValue()
 		if err != nil {
 			log.Printf("[ERROR] %s", err)
 		}
+		// the value provided by the credential helper (if configured) takes preference over the default value
+		credentialHelperValue, err := p.getCredentialHelperValue(schemaPropertyConfiguration, schemaPropertyName)
+		if err != nil {
+			log.Printf("[ERROR] %s", err)
+		} else if credentialHelperValue != "" {
+			defaultValue = credentialHelperValue
+		}
 	}
 	providerSchema[schemaPropertyName] = terraformutils.CreateStringSchemaProperty(schemaPropertyName, required, defaultValue)
 	log.Printf("[DEBUG] registered new property '%s' (required=%t) into provider schema", schemaPropertyName, required)
 }
 
+func (p providerFactory) getCredentialHelperValue(schemaPropertyConfiguration ServiceSchemaPropertyConfiguration, schemaPropertyName string) (string, error) {
+	return schemaPropertyConfiguration.GetCredentialHelperValue(CredentialHelperRequest{
+		ProviderName: p.name,
+		PropertyName: schemaPropertyName,
+		SpecURL:      p.serviceConfiguration.GetSwaggerURL(),
+	})
+}
+
 func (p providerFactory) configureProviderProperty(providerSchema map[string]*schema.Schema, schemaPropertyName string, defaultValue string, required bool, allowedValues []string) error {
 	providerSchema[schemaPropertyName] = terraformutils.CreateStringSchemaProperty(schemaPropertyName, required, defaultValue)
 	providerSchema[schemaPropertyName].ValidateFunc = p.createValidateFunc(allowedValues)
@@ -278,27 +319,42 @@ func (p providerFactory) configureProvider(openAPIBackendConfiguration SpecBacke
 			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
 			providerConfiguration:       *config,
 			telemetryHandler:            telemetryHandler,
+			tracer:                      p.getTracer(),
+			idempotencyKeys:             newIdempotencyKeyStore(p.name),
 		}
 		return openAPIClient, nil
 	}
 }
 
+// getTracer returns a tracer configured with the service tracing configuration; nil is returned if tracing is not configured
+func (p providerFactory) getTracer() *otlpTracer {
+	if p.serviceConfiguration == nil {
+		return nil
+	}
+	tracingConfig := p.serviceConfiguration.GetTracingConfiguration()
+	if tracingConfig == nil {
+		return nil
+	}
+	return newOTLPTracer(tracingConfig, fmt.Sprintf("terraform-provider-%s", p.name))
+}
+
 // GetTelemetryHandler returns a handler containing validated telemetry providers
 func (p providerFactory) GetTelemetryHandler(data *schema.ResourceData) TelemetryHandler {
-	telemetryProvider := p.serviceConfiguration.GetTelemetryConfiguration()
-	if telemetryProvider != nil {
+	var telemetryProviders []TelemetryProvider
+	for _, telemetryProvider := range p.serviceConfiguration.GetTelemetryConfiguration() {
 		err := telemetryProvider.Validate()
 		if err != nil {
-			log.Printf("[WARN] telemetry validation failed: %s, ignoring telemetry", err)
-			return nil
+			log.Printf("[WARN] %s telemetry validation failed: %s, ignoring telemetry provider", getTelemetryProviderName(telemetryProvider), err)
+			continue
 		}
+		telemetryProviders = append(telemetryProviders, telemetryProvider)
 	}
 	return telemetryHandlerTimeoutSupport{
-		timeout:           telemetryTimeout,
-		providerName:      p.name,
-		openAPIVersion:    version.Version,
-		telemetryProvider: telemetryProvider,
-		data:              data,
+		timeout:            telemetryTimeout,
+		providerName:       p.name,
+		openAPIVersion:     version.Version,
+		telemetryProviders: telemetryProviders,
+		data:               data,
 	}
 }
 
@@ -307,6 +363,9 @@ func (p providerFactory) GetTelemetryHandler(data *schema.ResourceData) Telemetr
 // - Security definition values that might be required by API operations (or globally)
 // configuration mapped to the corresponding
 func (p providerFactory) createProviderConfig(data *schema.ResourceData, providerConfigurationEndPoints *providerConfigurationEndPoints) (*providerConfiguration, error) {
+	if err := p.configureProviderPropertiesFromProfile(data); err != nil {
+		return nil, err
+	}
 	providerConfiguration, err := newProviderConfiguration(p.specAnalyser, data, providerConfigurationEndPoints)
 	if err != nil {
 		return nil, err
@@ -314,6 +373,82 @@ func (p providerFactory) createProviderConfig(data *schema.ResourceData, provide
 	return providerConfiguration, nil
 }
 
+// configureProviderPropertiesFromProfile resolves the values of the provider properties whose external configuration profile
+// is selected by another provider property (profile_schema_property). The value is resolved in the following order:
+// - the value set by the user in the provider configuration (or the property's environment variable)
+// - the value returned by the credential helper (if configured)
+// - the value looked up in the profile selected by the user in the profile_schema_property (if set)
+// - the value looked up in the profile selected by profile_env_var or profile, or the default_value otherwise
+// An error is returned if the selected profile can not be looked up or if a required property is left without value
+func (p providerFactory) configureProviderPropertiesFromProfile(data *schema.ResourceData) error {
+	if p.serviceConfiguration == nil {
+		return nil
+	}
+	properties := map[string]bool{}
+	securityDefinitions, err := p.specAnalyser.GetSecurity().GetAPIKeySecurityDefinitions()
+	if err != nil {
+		return err
+	}
+	if securityDefinitions != nil {
+		globalSecuritySchemes, err := p.specAnalyser.GetSecurity().GetGlobalSecuritySchemes()
+		if err != nil {
+			return err
+		}
+		for _, securityDefinition := range *securityDefinitions {
+			properties[securityDefinition.GetTerraformConfigurationName()] = globalSecuritySchemes.securitySchemeExists(securityDefinition)
+		}
+	}
+	for _, headerParam := range p.specAnalyser.GetAllHeaderParameters() {
+		properties[headerParam.GetHeaderTerraformConfigurationName()] = false
+	}
+	for propertyName, required := range properties {
+		schemaPropertyConfiguration := p.serviceConfiguration.GetSchemaPropertyConfiguration(propertyName)
+		if schemaPropertyConfiguration == nil || schemaPropertyConfiguration.GetProfileSchemaPropertyName() == "" {
+			continue
+		}
+		// the property is registered without default value so any value present was provided by the user
+		if value, exists := data.GetOk(propertyName); exists && value.(string) != "" {
+			log.Printf("[DEBUG] provider property '%s' value provided by the user", propertyName)
+			continue
+		}
+		value, err := p.getProviderPropertyValueFromProfile(data, schemaPropertyConfiguration, propertyName)
+		if err != nil {
+			return err
+		}
+		if value == "" && required {
+			return fmt.Errorf("provider property '%s' is required but no value was provided in the provider configuration nor resolved from the plugin configuration", propertyName)
+		}
+		if err := data.Set(propertyName, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p providerFactory) getProviderPropertyValueFromProfile(data *schema.ResourceData, schemaPropertyConfiguration ServiceSchemaPropertyConfiguration, propertyName string) (string, error) {
+	credentialHelperValue, err := p.getCredentialHelperValue(schemaPropertyConfiguration, propertyName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the value for provider property '%s' from the credential helper: %s", propertyName, err)
+	}
+	if credentialHelperValue != "" {
+		log.Printf("[DEBUG] provider property '%s' value provided by the credential helper", propertyName)
+		return credentialHelperValue, nil
+	}
+	profileSchemaPropertyName := schemaPropertyConfiguration.GetProfileSchemaPropertyName()
+	if profile, exists := data.GetOk(profileSchemaPropertyName); exists && profile.(string) != "" {
+		value, err := schemaPropertyConfiguration.GetDefaultValueForProfile(profile.(string))
+		if err != nil {
+			return "", fmt.Errorf("failed to get the value for provider property '%s' from profile '%s' (selected in '%s'): %s", propertyName, profile, profileSchemaPropertyName, err)
+		}
+		return value, nil
+	}
+	value, err := schemaPropertyConfiguration.GetDefaultValue()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the default value for provider property '%s': %s", propertyName, err)
+	}
+	return value, nil
+}
+
 func (p providerFactory) getProviderResourceName(resourceName string) (string, error) {
 	if resourceName == "" {
 		return "", fmt.Errorf("resource name can not be empty")
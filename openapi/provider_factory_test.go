@@ -8,6 +8,7 @@ import (
 	"github.com/dikhan/terraform-provider-openapi/openapi/version"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -693,6 +694,208 @@ func TestConfigureProviderPropertyFromPluginConfig(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a provider factory with a service configuration containing a credential helper for a schema property", t, func() {
+		serviceConfig := &ServiceConfigStub{
+			SwaggerURL: "http://host.com/swagger.yaml",
+			SchemaConfiguration: []*ServiceSchemaPropertyConfigurationStub{
+				{
+					SchemaPropertyName:    "apikey_auth",
+					DefaultValue:          "someDefaultValue",
+					CredentialHelperValue: "someValueFromCredentialHelper",
+				},
+			},
+		}
+		p := providerFactory{
+			name:                 "provider",
+			specAnalyser:         &specAnalyserStub{},
+			serviceConfiguration: serviceConfig,
+		}
+		Convey("When configureProviderPropertyFromPluginConfig is called with an empty provider schema", func() {
+			providerSchema := map[string]*schema.Schema{}
+			p.configureProviderPropertyFromPluginConfig(providerSchema, "apikey_auth", true)
+			Convey("Then the provider schema property default value should be the one returned by the credential helper", func() {
+				So(providerSchema, ShouldContainKey, "apikey_auth")
+				defaultValue, err := providerSchema["apikey_auth"].DefaultFunc()
+				So(err, ShouldBeNil)
+				So(defaultValue, ShouldEqual, "someValueFromCredentialHelper")
+			})
+			Convey("And the credential helper should have received the expected request", func() {
+				So(*serviceConfig.SchemaConfiguration[0].CredentialHelperRequest, ShouldResemble, CredentialHelperRequest{ProviderName: "provider", PropertyName: "apikey_auth", SpecURL: "http://host.com/swagger.yaml"})
+			})
+		})
+	})
+
+	Convey("Given a provider factory with a service configuration containing a credential helper that fails", t, func() {
+		serviceConfig := &ServiceConfigStub{
+			SchemaConfiguration: []*ServiceSchemaPropertyConfigurationStub{
+				{
+					SchemaPropertyName:  "apikey_auth",
+					DefaultValue:        "someDefaultValue",
+					CredentialHelperErr: errors.New("credential helper failed"),
+				},
+			},
+		}
+		p := providerFactory{
+			name:                 "provider",
+			specAnalyser:         &specAnalyserStub{},
+			serviceConfiguration: serviceConfig,
+		}
+		Convey("When configureProviderPropertyFromPluginConfig is called with an empty provider schema", func() {
+			providerSchema := map[string]*schema.Schema{}
+			p.configureProviderPropertyFromPluginConfig(providerSchema, "apikey_auth", true)
+			Convey("Then the provider schema property default value should fall back to the configured default value", func() {
+				defaultValue, err := providerSchema["apikey_auth"].DefaultFunc()
+				So(err, ShouldBeNil)
+				So(defaultValue, ShouldEqual, "someDefaultValue")
+			})
+		})
+	})
+}
+
+func TestConfigureProviderPropertiesFromProfile(t *testing.T) {
+	Convey("Given a provider factory with a security definition whose external configuration profile is selected by the 'profile' provider property", t, func() {
+		apiKeyAuthProperty := newStringSchemaDefinitionPropertyWithDefaults("apikey_auth", "", true, false, nil)
+		serviceConfig := &ServiceConfigStub{
+			SchemaConfiguration: []*ServiceSchemaPropertyConfigurationStub{
+				{
+					SchemaPropertyName:        apiKeyAuthProperty.Name,
+					DefaultValue:              "defaultToken",
+					ProfileSchemaPropertyName: "profile",
+					ProfileDefaultValues:      map[string]string{"production": "productionToken"},
+				},
+			},
+		}
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				security: &specSecurityStub{
+					securityDefinitions: &SpecSecurityDefinitions{
+						newAPIKeyHeaderSecurityDefinition(apiKeyAuthProperty.Name, authorizationHeader),
+					},
+					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+				},
+			},
+			serviceConfiguration: serviceConfig,
+		}
+		providerSchema := map[string]*schema.Schema{}
+		p.configureProviderPropertyFromPluginConfig(providerSchema, apiKeyAuthProperty.Name, false)
+		Convey("When configureProviderPropertyFromPluginConfig is called", func() {
+			Convey("Then the provider schema should contain the profile property", func() {
+				So(providerSchema, ShouldContainKey, "profile")
+				So(providerSchema["profile"].Optional, ShouldBeTrue)
+			})
+			Convey("And the property should not have a default value as it is resolved when the provider is configured", func() {
+				defaultValue, err := providerSchema[apiKeyAuthProperty.Name].DefaultValue()
+				So(err, ShouldBeNil)
+				So(defaultValue, ShouldBeNil)
+			})
+		})
+		Convey("When configureProviderPropertiesFromProfile is called with the user providing the profile", func() {
+			data := schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{"profile": "production"})
+			err := p.configureProviderPropertiesFromProfile(data)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the property value should be the one from the profile", func() {
+				So(data.Get(apiKeyAuthProperty.Name), ShouldEqual, "productionToken")
+			})
+		})
+		Convey("When configureProviderPropertiesFromProfile is called with the user providing the profile and the property value", func() {
+			data := schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{"profile": "production", apiKeyAuthProperty.Name: "userToken"})
+			err := p.configureProviderPropertiesFromProfile(data)
+			Convey("Then the property value should be the one provided by the user", func() {
+				So(err, ShouldBeNil)
+				So(data.Get(apiKeyAuthProperty.Name), ShouldEqual, "userToken")
+			})
+		})
+		Convey("When configureProviderPropertiesFromProfile is called with the user providing the profile and a property value equal to the default value", func() {
+			data := schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{"profile": "production", apiKeyAuthProperty.Name: "defaultToken"})
+			err := p.configureProviderPropertiesFromProfile(data)
+			Convey("Then the property value should be the one provided by the user", func() {
+				So(err, ShouldBeNil)
+				So(data.Get(apiKeyAuthProperty.Name), ShouldEqual, "defaultToken")
+			})
+		})
+		Convey("When configureProviderPropertiesFromProfile is called without the user providing the profile", func() {
+			data := schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{})
+			err := p.configureProviderPropertiesFromProfile(data)
+			Convey("Then the property value should be the default value", func() {
+				So(err, ShouldBeNil)
+				So(data.Get(apiKeyAuthProperty.Name), ShouldEqual, "defaultToken")
+			})
+		})
+		Convey("When configureProviderPropertiesFromProfile is called without the user providing the profile and the default value can not be resolved", func() {
+			serviceConfig.SchemaConfiguration[0].GetDefaultValueFunc = func() (string, error) { return "", errors.New("profile file not found") }
+			data := schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{})
+			err := p.configureProviderPropertiesFromProfile(data)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "failed to get the default value for provider property 'apikey_auth': profile file not found")
+			})
+		})
+		Convey("When configureProviderPropertiesFromProfile is called with the user providing a profile that does not exist", func() {
+			data := schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{"profile": "staging"})
+			err := p.configureProviderPropertiesFromProfile(data)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "failed to get the value for provider property 'apikey_auth' from profile 'staging' (selected in 'profile'): profile 'staging' not found")
+			})
+		})
+		Convey("When configureProviderPropertiesFromProfile is called with the user providing the profile and the property has a credential helper that fails", func() {
+			serviceConfig.SchemaConfiguration[0].CredentialHelperErr = errors.New("credential helper failed")
+			data := schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{"profile": "production"})
+			err := p.configureProviderPropertiesFromProfile(data)
+			Convey("Then the error returned should be the credential helper one instead of falling back to the profile value", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "failed to get the value for provider property 'apikey_auth' from the credential helper: credential helper failed")
+			})
+		})
+		Convey("When configureProviderPropertiesFromProfile is called with the user providing the profile and the property has a credential helper", func() {
+			serviceConfig.SchemaConfiguration[0].CredentialHelperValue = "credentialHelperToken"
+			data := schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{"profile": "production"})
+			err := p.configureProviderPropertiesFromProfile(data)
+			Convey("Then the property value should be the one provided by the credential helper", func() {
+				So(err, ShouldBeNil)
+				So(data.Get(apiKeyAuthProperty.Name), ShouldEqual, "credentialHelperToken")
+			})
+		})
+	})
+
+	Convey("Given a provider factory with a required security definition whose external configuration profile is selected by the 'profile' provider property", t, func() {
+		apiKeyAuthProperty := newStringSchemaDefinitionPropertyWithDefaults("apikey_auth", "", true, false, nil)
+		serviceConfig := &ServiceConfigStub{
+			SchemaConfiguration: []*ServiceSchemaPropertyConfigurationStub{
+				{
+					SchemaPropertyName:        apiKeyAuthProperty.Name,
+					ProfileSchemaPropertyName: "profile",
+					ProfileDefaultValues:      map[string]string{"production": "productionToken"},
+				},
+			},
+		}
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				security: &specSecurityStub{
+					securityDefinitions: &SpecSecurityDefinitions{
+						newAPIKeyHeaderSecurityDefinition(apiKeyAuthProperty.Name, authorizationHeader),
+					},
+					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{{apiKeyAuthProperty.Name: []string{}}}),
+				},
+			},
+			serviceConfiguration: serviceConfig,
+		}
+		providerSchema := map[string]*schema.Schema{}
+		p.configureProviderPropertyFromPluginConfig(providerSchema, apiKeyAuthProperty.Name, true)
+		Convey("When configureProviderPropertiesFromProfile is called without any value being resolved for the property", func() {
+			data := schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{})
+			err := p.configureProviderPropertiesFromProfile(data)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "provider property 'apikey_auth' is required but no value was provided in the provider configuration nor resolved from the plugin configuration")
+			})
+		})
+	})
 }
 
 func TestConfigureProvider(t *testing.T) {
@@ -723,10 +926,12 @@ func TestConfigureProvider(t *testing.T) {
 				},
 			},
 			serviceConfiguration: &ServiceConfigStub{
-				Telemetry: &TelemetryProviderGraphite{
-					Port:   port,
-					Host:   telemetryHost,
-					Prefix: "openapi",
+				Telemetry: []TelemetryProvider{
+					&TelemetryProviderGraphite{
+						Port:   port,
+						Host:   telemetryHost,
+						Prefix: "openapi",
+					},
 				},
 			},
 		}
@@ -782,10 +987,12 @@ func TestConfigureProvider(t *testing.T) {
 				},
 			},
 			serviceConfiguration: &ServiceConfigStub{
-				Telemetry: &TelemetryProviderHTTPEndpoint{
-					URL:                      fmt.Sprintf("%s/v1/metrics", api.URL),
-					Prefix:                   "openapi",
-					ProviderSchemaProperties: []string{"header_name"},
+				Telemetry: []TelemetryProvider{
+					&TelemetryProviderHTTPEndpoint{
+						URL:                      fmt.Sprintf("%s/v1/metrics", api.URL),
+						Prefix:                   "openapi",
+						ProviderSchemaProperties: []string{"header_name"},
+					},
 				},
 			},
 		}
@@ -1154,7 +1361,7 @@ func TestGetTelemetryHandler(t *testing.T) {
 	providerFactory := providerFactory{
 		name: expectedProviderName,
 		serviceConfiguration: &ServiceConfigStub{
-			Telemetry: expectedTelemetryProvider,
+			Telemetry: []TelemetryProvider{expectedTelemetryProvider},
 		},
 	}
 
@@ -1165,22 +1372,33 @@ func TestGetTelemetryHandler(t *testing.T) {
 	assert.Equal(t, expectedProviderName, telemetryHandler.(telemetryHandlerTimeoutSupport).providerName)
 	assert.Equal(t, version.Version, telemetryHandler.(telemetryHandlerTimeoutSupport).openAPIVersion)
 	assert.Equal(t, telemetryTimeout, telemetryHandler.(telemetryHandlerTimeoutSupport).timeout)
-	assert.Equal(t, expectedTelemetryProvider, telemetryHandler.(telemetryHandlerTimeoutSupport).telemetryProvider)
+	assert.Equal(t, expectedTelemetryProvider, telemetryHandler.(telemetryHandlerTimeoutSupport).telemetryProviders[0])
 	assert.Equal(t, expectedResourceData, telemetryHandler.(telemetryHandlerTimeoutSupport).data)
 
 }
 
-func TestGetTelemetryHandlerReturnsNilTelemetryProviderDueToTelemetryValidationError(t *testing.T) {
+func TestGetTelemetryHandlerIgnoresTelemetryProviderDueToTelemetryValidationError(t *testing.T) {
+	var logging bytes.Buffer
+	log.SetOutput(&logging)
 	expectedResourceData := &schema.ResourceData{}
 	expectedProviderName := "provider_name"
+	expectedTelemetryProvider := &TelemetryProviderGraphite{
+		Host: "telemetry.myhost.com",
+		Port: 8125,
+	}
 	providerFactory := providerFactory{
 		name: expectedProviderName,
 		serviceConfiguration: &ServiceConfigStub{
-			Telemetry: &TelemetryProviderHTTPEndpoint{
-				URL: "",
+			Telemetry: []TelemetryProvider{
+				&TelemetryProviderHTTPEndpoint{
+					URL: "",
+				},
+				expectedTelemetryProvider,
 			},
 		},
 	}
 	telemetryHandler := providerFactory.GetTelemetryHandler(expectedResourceData)
-	assert.Nil(t, telemetryHandler)
+	assert.NotNil(t, telemetryHandler)
+	assert.Equal(t, []TelemetryProvider{expectedTelemetryProvider}, telemetryHandler.(telemetryHandlerTimeoutSupport).telemetryProviders)
+	assert.Contains(t, logging.String(), "[WARN] http_endpoint telemetry validation failed: http endpoint telemetry configuration is missing a value for the 'url property', ignoring telemetry provider")
 }
@@ -0,0 +1,430 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/hashicorp/hcl2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// exportedResourceLabelProperties contains the properties (in order of preference) used to name the exported resource
+// blocks. If the remote object does not contain any of them, the resource id is used instead
+var exportedResourceLabelProperties = []string{"name", "label"}
+
+var exportedResourceLabelInvalidCharsRegex = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// ExportedResource contains the HCL configuration of a remote object exported from the API along with the information
+// needed to import the remote object into the Terraform state
+type ExportedResource struct {
+	// Address is the Terraform address of the resource (e,g: openapi_cdns_v1.my_cdn)
+	Address string
+	// ImportID is the ID to use when importing the resource. Sub-resources use the keyed format (e,g: cdns_v1_id=1234,id=567)
+	ImportID string
+	// Config is the HCL resource block populated with the remote object values
+	Config string
+	// Variables contains the input variables referenced by the required sensitive properties in Config
+	Variables []ExportedVariable
+
+	id        string
+	parentIDs []string
+	parents   []*ExportedResource
+}
+
+// ExportedVariable describes the input variable that holds the value of a required sensitive property. The values of the
+// sensitive properties are never exported so the configuration references the variable instead
+type ExportedVariable struct {
+	// Name is the name of the variable (e,g: openapi_cdns_v1_my_cdn_password)
+	Name string
+	// Description describes the property the variable is used for
+	Description string
+}
+
+// VariableBlocks returns the HCL variable blocks of the input variables referenced in the resource configuration
+func (e ExportedResource) VariableBlocks() string {
+	var b strings.Builder
+	for idx, variable := range e.Variables {
+		if idx > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "variable %q {\n  description = %s\n  sensitive   = true\n}\n", variable.Name, terraformutils.QuoteHCLString(variable.Description))
+	}
+	return b.String()
+}
+
+// ImportBlock returns the HCL import block that imports the remote object into the Terraform state
+func (e ExportedResource) ImportBlock() string {
+	return fmt.Sprintf("import {\n  to = %s\n  id = %s\n}\n", e.Address, terraformutils.QuoteHCLString(e.ImportID))
+}
+
+// ImportCommand returns the terraform import command that imports the remote object into the Terraform state
+func (e ExportedResource) ImportCommand() string {
+	return fmt.Sprintf("terraform import %s '%s'", e.Address, strings.Replace(e.ImportID, "'", `'\''`, -1))
+}
+
+// addSensitiveVariable registers the input variable for the required sensitive property given its path within the resource
+// (e,g: origin.secret) and returns it
+func (e *ExportedResource) addSensitiveVariable(propertyPath string) ExportedVariable {
+	variable := ExportedVariable{
+		Name:        strings.Replace(e.Address+"."+propertyPath, ".", "_", -1),
+		Description: fmt.Sprintf("Value of the sensitive property '%s' of %s", propertyPath, e.Address),
+	}
+	e.Variables = append(e.Variables, variable)
+	return variable
+}
+
+// ExportRemoteResources configures the provider with the given provider configuration (e,g: map[string]interface{}{"apikey_auth": "secret"})
+// and walks through the List operation of every terraform compliant resource, sub-resources included, returning the remote
+// objects found as HCL resource blocks
+func ExportRemoteResources(providerName string, serviceConfiguration ServiceConfiguration, providerConfig map[string]interface{}) ([]*ExportedResource, error) {
+	providerFactory, err := newProviderFactoryFromServiceConfiguration(providerName, serviceConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := providerFactory.createProvider()
+	if err != nil {
+		return nil, err
+	}
+	resourceConfig := terraform.NewResourceConfigRaw(providerConfig)
+	if _, errs := provider.Validate(resourceConfig); len(errs) > 0 {
+		var msgs []string
+		for _, err := range errs {
+			msgs = append(msgs, err.Error())
+		}
+		return nil, fmt.Errorf("invalid provider configuration: %s", strings.Join(msgs, ", "))
+	}
+	if err := provider.Configure(resourceConfig); err != nil {
+		return nil, fmt.Errorf("failed to configure the provider: %s", err)
+	}
+	providerClient, ok := provider.Meta().(ClientOpenAPI)
+	if !ok {
+		return nil, fmt.Errorf("failed to configure the provider: unexpected provider client")
+	}
+	return providerFactory.exportRemoteResources(provider, providerClient)
+}
+
+// exportRemoteResources exports the remote objects of the root level resources first and then the remote objects of the
+// sub-resources under each of the parent remote objects exported
+func (p providerFactory) exportRemoteResources(provider *schema.Provider, providerClient ClientOpenAPI) ([]*ExportedResource, error) {
+	openAPIResources, err := p.specAnalyser.GetTerraformCompliantResources()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(openAPIResources, func(i, j int) bool {
+		iDepth, jDepth := getResourceParentsDepth(openAPIResources[i]), getResourceParentsDepth(openAPIResources[j])
+		if iDepth != jDepth {
+			return iDepth < jDepth
+		}
+		return openAPIResources[i].GetResourceName() < openAPIResources[j].GetResourceName()
+	})
+
+	exportedResources := []*ExportedResource{}
+	exportedResourcesByName := map[string][]*ExportedResource{}
+	usedAddresses := map[string]bool{}
+	for _, openAPIResource := range openAPIResources {
+		if openAPIResource.ShouldIgnoreResource() {
+			continue
+		}
+		resourceName, err := p.getProviderResourceName(openAPIResource.GetResourceName())
+		if err != nil {
+			return nil, err
+		}
+		if openAPIResource.getResourceOperations().List == nil {
+			log.Printf("[WARN] resource '%s' does not support listing its instances, skipping export", resourceName)
+			continue
+		}
+		resource, exists := provider.ResourcesMap[resourceName]
+		if !exists {
+			continue
+		}
+		// root level resources are listed once whereas sub-resources are listed under each of the parents exported
+		parents := []*ExportedResource{nil}
+		if parentResourceInfo := openAPIResource.GetParentResourceInfo(); parentResourceInfo != nil {
+			parents = exportedResourcesByName[parentResourceInfo.fullParentResourceName]
+		}
+		for _, parent := range parents {
+			exported, err := exportResourceInstances(resourceName, resource, openAPIResource, providerClient, parent, usedAddresses)
+			if err != nil {
+				return nil, err
+			}
+			exportedResources = append(exportedResources, exported...)
+			exportedResourcesByName[openAPIResource.GetResourceName()] = append(exportedResourcesByName[openAPIResource.GetResourceName()], exported...)
+		}
+	}
+	return exportedResources, nil
+}
+
+func getResourceParentsDepth(openAPIResource SpecResource) int {
+	if parentResourceInfo := openAPIResource.GetParentResourceInfo(); parentResourceInfo != nil {
+		return len(parentResourceInfo.parentResourceNames)
+	}
+	return 0
+}
+
+// exportResourceInstances lists the remote objects of the resource (under the parent if the resource is a sub-resource)
+// and returns them as exported resources
+func exportResourceInstances(resourceName string, resource *schema.Resource, openAPIResource SpecResource, providerClient ClientOpenAPI, parent *ExportedResource, usedAddresses map[string]bool) ([]*ExportedResource, error) {
+	var parentIDs []string
+	var parents []*ExportedResource
+	if parent != nil {
+		parentIDs = append(append(parentIDs, parent.parentIDs...), parent.id)
+		parents = append(append(parents, parent.parents...), parent)
+	}
+	responsePayload := []map[string]interface{}{}
+	resp, err := providerClient.List(openAPIResource, &responsePayload, parentIDs...)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkHTTPStatusCode(openAPIResource, resp, []int{http.StatusOK}); err != nil {
+		return nil, err
+	}
+	r := newResourceFactory(openAPIResource)
+	exportedResources := []*ExportedResource{}
+	for _, item := range responsePayload {
+		data := resource.Data(nil)
+		if err := setStateID(openAPIResource, data, item); err != nil {
+			return nil, fmt.Errorf("[resource='%s'] failed to export remote object: %s", resourceName, err)
+		}
+		// the remote object is read again as the items returned by the List operation may not contain all the properties
+		remoteData, err := r.readRemote(data.Id(), providerClient, parentIDs...)
+		if err != nil {
+			return nil, fmt.Errorf("[resource='%s'] failed to export remote object '%s': %s", resourceName, data.Id(), err)
+		}
+		if err := dataSourceUpdateStateWithPayloadData(openAPIResource, remoteData, data); err != nil {
+			return nil, fmt.Errorf("[resource='%s'] failed to export remote object '%s': %s", resourceName, data.Id(), err)
+		}
+		exportedResource := &ExportedResource{
+			Address:   getExportedResourceAddress(resourceName, data, usedAddresses),
+			ImportID:  data.Id(),
+			id:        data.Id(),
+			parentIDs: parentIDs,
+			parents:   parents,
+		}
+		var parentPropertyNames []string
+		if parentResourceInfo := openAPIResource.GetParentResourceInfo(); parentResourceInfo != nil {
+			parentPropertyNames = parentResourceInfo.GetParentPropertiesNames()
+			var keyedIDs []string
+			for idx, parentPropertyName := range parentPropertyNames {
+				keyedIDs = append(keyedIDs, fmt.Sprintf("%s=%s", parentPropertyName, parentIDs[idx]))
+			}
+			exportedResource.ImportID = strings.Join(append(keyedIDs, fmt.Sprintf("%s=%s", idDefaultPropertyName, data.Id())), ",")
+		}
+		config, err := buildExportedResourceConfig(exportedResource, openAPIResource, resource, data, parentPropertyNames)
+		if err != nil {
+			return nil, fmt.Errorf("[resource='%s'] failed to export remote object '%s': %s", resourceName, data.Id(), err)
+		}
+		exportedResource.Config = config
+		log.Printf("[INFO] remote object '%s' exported as '%s'", data.Id(), exportedResource.Address)
+		exportedResources = append(exportedResources, exportedResource)
+	}
+	return exportedResources, nil
+}
+
+// getExportedResourceAddress returns a unique address for the exported resource. The resource label is built from the
+// name or label properties if present, falling back to the resource id
+func getExportedResourceAddress(resourceName string, data *schema.ResourceData, usedAddresses map[string]bool) string {
+	label := data.Id()
+	for _, labelProperty := range exportedResourceLabelProperties {
+		if value, ok := data.GetOk(labelProperty); ok {
+			if stringValue, isString := value.(string); isString {
+				label = stringValue
+				break
+			}
+		}
+	}
+	label = strings.Trim(exportedResourceLabelInvalidCharsRegex.ReplaceAllString(strings.ToLower(label), "_"), "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "r_" + label
+	}
+	address := fmt.Sprintf("%s.%s", resourceName, label)
+	for idx := 2; usedAddresses[address]; idx++ {
+		address = fmt.Sprintf("%s.%s_%d", resourceName, label, idx)
+	}
+	usedAddresses[address] = true
+	return address
+}
+
+// buildExportedResourceConfig returns the HCL resource block for the exported resource. Read-only and optional sensitive
+// properties are omitted, required sensitive properties reference an input variable and the parent properties reference
+// the exported parent resources
+func buildExportedResourceConfig(exportedResource *ExportedResource, openAPIResource SpecResource, resource *schema.Resource, data *schema.ResourceData, parentPropertyNames []string) (string, error) {
+	resourceSchema, err := openAPIResource.GetResourceSchema()
+	if err != nil {
+		return "", err
+	}
+	addressParts := strings.SplitN(exportedResource.Address, ".", 2)
+	var b strings.Builder
+	fmt.Fprintf(&b, "resource %q %q {\n", addressParts[0], addressParts[1])
+	for idx, parentPropertyName := range parentPropertyNames {
+		if idx < len(exportedResource.parents) {
+			fmt.Fprintf(&b, "%s = %s.id\n", parentPropertyName, exportedResource.parents[idx].Address)
+		}
+	}
+	values := map[string]interface{}{}
+	for name := range resource.Schema {
+		values[name] = data.Get(name)
+	}
+	writeExportedProperties(&b, exportedResource, "", resourceSchema, resource.Schema, values, parentPropertyNames)
+	b.WriteString("}\n")
+	formatted := hclwrite.Format([]byte(b.String()))
+	if _, diags := hclsyntax.ParseConfig(formatted, "export.tf", hcl.Pos{Line: 1, Column: 1}); diags.HasErrors() {
+		return "", fmt.Errorf("configuration generated is not valid HCL: %s", diags.Error())
+	}
+	return string(formatted), nil
+}
+
+func writeExportedProperties(b *strings.Builder, exportedResource *ExportedResource, pathPrefix string, specSchemaDefinition *SpecSchemaDefinition, schemaMap map[string]*schema.Schema, values map[string]interface{}, excludedProperties []string) {
+	for _, name := range getSortedSchemaNames(schemaMap) {
+		s := schemaMap[name]
+		if isParentPropertyName(name, excludedProperties) || (s.Computed && !s.Optional) {
+			continue
+		}
+		if s.Sensitive {
+			// the API does not expose the values of the sensitive properties; the required ones are still needed for the
+			// configuration to be valid so they are read from an input variable
+			if s.Required {
+				variable := exportedResource.addSensitiveVariable(pathPrefix + name)
+				fmt.Fprintf(b, "# %s is sensitive and is not exported, set its value in the variable %s\n", name, variable.Name)
+				fmt.Fprintf(b, "%s = var.%s\n", name, variable.Name)
+			}
+			continue
+		}
+		var property *SpecSchemaDefinitionProperty
+		if specSchemaDefinition != nil {
+			property, _ = specSchemaDefinition.getPropertyBasedOnTerraformName(name)
+		}
+		if property != nil && property.isReadOnly() {
+			continue
+		}
+		value, exists := values[name]
+		if !exists || value == nil || (!s.Required && s.Default == nil && isZeroExportedValue(value)) {
+			continue
+		}
+		var objectSpecSchemaDefinition *SpecSchemaDefinition
+		if property != nil {
+			objectSpecSchemaDefinition = property.SpecSchemaDefinition
+		}
+		if elem, isObject := s.Elem.(*schema.Resource); isObject {
+			// objects are configured as maps whereas lists of objects (and legacy objects) are configured as blocks
+			if s.Type == schema.TypeMap {
+				objectValues, _ := value.(map[string]interface{})
+				fmt.Fprintf(b, "%s = %s\n", name, getHCLValue(getExportedObjectValues(exportedResource, pathPrefix+name+".", objectSpecSchemaDefinition, elem.Schema, objectValues)))
+				continue
+			}
+			for idx, item := range getExportedListItems(value) {
+				itemValues, _ := item.(map[string]interface{})
+				fmt.Fprintf(b, "%s {\n", name)
+				writeExportedProperties(b, exportedResource, fmt.Sprintf("%s%s.%d.", pathPrefix, name, idx), objectSpecSchemaDefinition, elem.Schema, itemValues, nil)
+				b.WriteString("}\n")
+			}
+			continue
+		}
+		fmt.Fprintf(b, "%s = %s\n", name, getHCLValue(value))
+	}
+}
+
+// getExportedObjectValues returns the object values that should be part of the configuration, skipping read-only and
+// optional sensitive properties. Required sensitive properties reference an input variable
+func getExportedObjectValues(exportedResource *ExportedResource, pathPrefix string, specSchemaDefinition *SpecSchemaDefinition, schemaMap map[string]*schema.Schema, values map[string]interface{}) map[string]interface{} {
+	objectValues := map[string]interface{}{}
+	for _, name := range getSortedSchemaNames(schemaMap) {
+		s := schemaMap[name]
+		if s.Sensitive && s.Required && !s.Computed {
+			objectValues[name] = hclExpression("var." + exportedResource.addSensitiveVariable(pathPrefix+name).Name)
+			continue
+		}
+		value, exists := values[name]
+		if !exists || (s.Computed && !s.Optional) || s.Sensitive || isZeroExportedValue(value) {
+			continue
+		}
+		if specSchemaDefinition != nil {
+			if property, err := specSchemaDefinition.getPropertyBasedOnTerraformName(name); err == nil && property.isReadOnly() {
+				continue
+			}
+		}
+		objectValues[name] = value
+	}
+	return objectValues
+}
+
+func getExportedListItems(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case *schema.Set:
+		return v.List()
+	}
+	return nil
+}
+
+func isZeroExportedValue(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	case *schema.Set:
+		return v.Len() == 0
+	}
+	return false
+}
+
+// hclExpression is an HCL expression (e,g: a variable reference) written as is in the configuration
+type hclExpression string
+
+// getHCLValue returns the HCL representation of the value
+func getHCLValue(value interface{}) string {
+	switch v := value.(type) {
+	case hclExpression:
+		return string(v)
+	case string:
+		return terraformutils.QuoteHCLString(v)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case *schema.Set:
+		return getHCLValue(v.List())
+	case []interface{}:
+		var items []string
+		for _, item := range v {
+			items = append(items, getHCLValue(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]interface{}:
+		var keys []string
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var items []string
+		for _, key := range keys {
+			hclKey := key
+			if !hclsyntax.ValidIdentifier(key) {
+				hclKey = terraformutils.QuoteHCLString(key)
+			}
+			items = append(items, fmt.Sprintf("%s = %s", hclKey, getHCLValue(v[key])))
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	}
+	return terraformutils.QuoteHCLString(fmt.Sprintf("%v", value))
+}
@@ -0,0 +1,304 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const exportTestSwaggerTemplate = `swagger: "2.0"
+host: "%s"
+schemes:
+- "http"
+security:
+  - apikey_auth: []
+securityDefinitions:
+  apikey_auth:
+    type: "apiKey"
+    name: "Authorization"
+    in: "header"
+paths:
+  /v1/cdns:
+    get:
+      responses:
+        200:
+          schema:
+            type: "array"
+            items:
+              $ref: "#/definitions/ContentDeliveryNetworkV1"
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/cdns/{cdn_id}:
+    get:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+    delete:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        204:
+          description: "successful operation, no content is returned"
+  /v1/cdns/{cdn_id}/v1/firewalls:
+    get:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            type: "array"
+            items:
+              $ref: "#/definitions/ContentDeliveryNetworkFirewallV1"
+    post:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        required: true
+        type: "string"
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkFirewallV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkFirewallV1"
+  /v1/cdns/{cdn_id}/v1/firewalls/{id}:
+    get:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        required: true
+        type: "string"
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkFirewallV1"
+    delete:
+      parameters:
+      - name: "cdn_id"
+        in: "path"
+        required: true
+        type: "string"
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        204:
+          description: "successful operation, no content is returned"
+definitions:
+  ContentDeliveryNetworkV1:
+    type: "object"
+    required:
+      - label
+      - api_key
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"
+      api_key:
+        type: "string"
+        x-terraform-sensitive: true
+      ips:
+        type: "array"
+        items:
+          type: "string"
+      port:
+        type: "integer"
+      enabled:
+        type: "boolean"
+      status:
+        type: "string"
+        readOnly: true
+      password:
+        type: "string"
+        x-terraform-sensitive: true
+      origin:
+        type: "object"
+        required:
+          - secret
+        properties:
+          secret:
+            type: "string"
+            x-terraform-sensitive: true
+          host:
+            type: "string"
+          origin_id:
+            type: "string"
+            readOnly: true
+  ContentDeliveryNetworkFirewallV1:
+    type: "object"
+    required:
+      - name
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      name:
+        type: "string"`
+
+func TestExportRemoteResources(t *testing.T) {
+	Convey("Given a local server that exposes a swagger file containing a resource (cdn) and sub-resource (firewall) and the API with existing remote objects", t, func() {
+		cdns := map[string]map[string]interface{}{
+			"cdn-1": {"id": "cdn-1", "label": "My CDN", "ips": []string{"127.0.0.1"}, "port": 80, "enabled": true, "status": "deployed", "password": "secret", "origin": map[string]interface{}{"host": "example.com", "origin_id": "origin-1"}},
+			"cdn-2": {"id": "cdn-2", "label": "My CDN"},
+		}
+		firewalls := map[string]map[string]interface{}{
+			"fw-1": {"id": "fw-1", "name": "my-firewall"},
+		}
+		var apiKeyReceived string
+		var server *httptest.Server
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var response interface{}
+			switch r.URL.Path {
+			case "/swagger.yaml":
+				w.Write([]byte(fmt.Sprintf(exportTestSwaggerTemplate, strings.TrimPrefix(server.URL, "http://"))))
+				return
+			case "/v1/cdns":
+				apiKeyReceived = r.Header.Get("Authorization")
+				// items returned by the list operation only contain the id and label
+				response = []map[string]interface{}{{"id": "cdn-1", "label": "My CDN"}, {"id": "cdn-2", "label": "My CDN"}}
+			case "/v1/cdns/cdn-1", "/v1/cdns/cdn-2":
+				response = cdns[strings.TrimPrefix(r.URL.Path, "/v1/cdns/")]
+			case "/v1/cdns/cdn-1/v1/firewalls":
+				response = []map[string]interface{}{firewalls["fw-1"]}
+			case "/v1/cdns/cdn-2/v1/firewalls":
+				response = []map[string]interface{}{}
+			case "/v1/cdns/cdn-1/v1/firewalls/fw-1":
+				response = firewalls["fw-1"]
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			b, _ := json.Marshal(response)
+			w.Write(b)
+		}))
+		defer server.Close()
+		serviceConfiguration := &ServiceConfigStub{SwaggerURL: server.URL + "/swagger.yaml"}
+		Convey("When ExportRemoteResources is called with the provider configuration", func() {
+			exportedResources, err := ExportRemoteResources("openapi", serviceConfiguration, map[string]interface{}{"apikey_auth": "apiKeyValue"})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the API should have been called with the provider configuration", func() {
+				So(apiKeyReceived, ShouldEqual, "apiKeyValue")
+			})
+			Convey("And the remote objects of the resource and sub-resource should have been exported", func() {
+				So(len(exportedResources), ShouldEqual, 3)
+				So(exportedResources[0].Address, ShouldEqual, "openapi_cdns_v1.my_cdn")
+				So(exportedResources[0].ImportID, ShouldEqual, "cdn-1")
+				So(exportedResources[0].Config, ShouldEqual, `resource "openapi_cdns_v1" "my_cdn" {
+  # api_key is sensitive and is not exported, set its value in the variable openapi_cdns_v1_my_cdn_api_key
+  api_key = var.openapi_cdns_v1_my_cdn_api_key
+  enabled = true
+  ips     = ["127.0.0.1"]
+  label   = "My CDN"
+  origin  = { host = "example.com", secret = var.openapi_cdns_v1_my_cdn_origin_secret }
+  port    = 80
+}
+`)
+				So(exportedResources[0].Variables, ShouldResemble, []ExportedVariable{
+					{Name: "openapi_cdns_v1_my_cdn_api_key", Description: "Value of the sensitive property 'api_key' of openapi_cdns_v1.my_cdn"},
+					{Name: "openapi_cdns_v1_my_cdn_origin_secret", Description: "Value of the sensitive property 'origin.secret' of openapi_cdns_v1.my_cdn"},
+				})
+				So(exportedResources[1].Address, ShouldEqual, "openapi_cdns_v1.my_cdn_2")
+				So(exportedResources[1].ImportID, ShouldEqual, "cdn-2")
+				So(exportedResources[1].Config, ShouldEqual, `resource "openapi_cdns_v1" "my_cdn_2" {
+  # api_key is sensitive and is not exported, set its value in the variable openapi_cdns_v1_my_cdn_2_api_key
+  api_key = var.openapi_cdns_v1_my_cdn_2_api_key
+  label   = "My CDN"
+}
+`)
+				So(exportedResources[2].Address, ShouldEqual, "openapi_cdns_v1_firewalls_v1.my_firewall")
+				So(exportedResources[2].ImportID, ShouldEqual, "cdns_v1_id=cdn-1,id=fw-1")
+				So(exportedResources[2].Config, ShouldEqual, `resource "openapi_cdns_v1_firewalls_v1" "my_firewall" {
+  cdns_v1_id = openapi_cdns_v1.my_cdn.id
+  name       = "my-firewall"
+}
+`)
+			})
+			Convey("And the variable blocks of the required sensitive properties should be the expected ones", func() {
+				So(exportedResources[0].VariableBlocks(), ShouldEqual, `variable "openapi_cdns_v1_my_cdn_api_key" {
+  description = "Value of the sensitive property 'api_key' of openapi_cdns_v1.my_cdn"
+  sensitive   = true
+}
+
+variable "openapi_cdns_v1_my_cdn_origin_secret" {
+  description = "Value of the sensitive property 'origin.secret' of openapi_cdns_v1.my_cdn"
+  sensitive   = true
+}
+`)
+				So(exportedResources[2].VariableBlocks(), ShouldEqual, "")
+			})
+			Convey("And the import block and command of the exported resources should be the expected ones", func() {
+				So(exportedResources[2].ImportBlock(), ShouldEqual, `import {
+  to = openapi_cdns_v1_firewalls_v1.my_firewall
+  id = "cdns_v1_id=cdn-1,id=fw-1"
+}
+`)
+				So(exportedResources[2].ImportCommand(), ShouldEqual, `terraform import openapi_cdns_v1_firewalls_v1.my_firewall 'cdns_v1_id=cdn-1,id=fw-1'`)
+			})
+		})
+		Convey("When ExportRemoteResources is called with a provider configuration missing required properties", func() {
+			_, err := ExportRemoteResources("openapi", serviceConfiguration, map[string]interface{}{})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, `invalid provider configuration: "apikey_auth": required field is not set`)
+			})
+		})
+	})
+}
+
+func TestGetHCLValue(t *testing.T) {
+	Convey("Given different values", t, func() {
+		testCases := []struct {
+			value         interface{}
+			expectedValue string
+		}{
+			{value: "some ${value}", expectedValue: `"some $${value}"`},
+			{value: 12, expectedValue: "12"},
+			{value: 12.5, expectedValue: "12.5"},
+			{value: false, expectedValue: "false"},
+			{value: []interface{}{"a", "b"}, expectedValue: `["a", "b"]`},
+			{value: map[string]interface{}{"b": "2", "a": "1", "some key": "3"}, expectedValue: `{a = "1", b = "2", "some key" = "3"}`},
+		}
+		Convey("When getHCLValue is called", func() {
+			Convey("Then the HCL value returned should be the expected one", func() {
+				for _, tc := range testCases {
+					So(getHCLValue(tc.value), ShouldEqual, tc.expectedValue)
+				}
+			})
+		})
+	})
+}
@@ -0,0 +1,268 @@
+package openapi
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// Kinds of changes detected between two versions of the provider schema
+const (
+	schemaChangeResourceRemoved       = "resource_removed"
+	schemaChangeResourceAdded         = "resource_added"
+	schemaChangeDataSourceRemoved     = "data_source_removed"
+	schemaChangeDataSourceAdded       = "data_source_added"
+	schemaChangeAttributeRemoved      = "attribute_removed"
+	schemaChangeAttributeAdded        = "attribute_added"
+	schemaChangeTypeChanged           = "type_changed"
+	schemaChangeRequiredAdded         = "required_added"
+	schemaChangeRequiredRemoved       = "required_removed"
+	schemaChangeForceNewAdded         = "force_new_added"
+	schemaChangeForceNewRemoved       = "force_new_removed"
+	schemaChangeSensitiveChanged      = "sensitive_changed"
+	schemaChangeComputedChanged       = "computed_changed"
+	schemaChangeComputedOnly          = "computed_only"
+	schemaChangeIdentifierChanged     = "identifier_changed"
+	providerSchemaDiffAddressPrefix   = "provider"
+	dataSourceSchemaDiffAddressPrefix = "data"
+)
+
+// SchemaChange describes a change found between two versions of the provider schema. The address identifies the element
+// changed following Terraform's addressing (e,g: openapi_cdn_v1.label, data.openapi_cdn_v1_instance.label or provider.openapi.apikey_auth)
+type SchemaChange struct {
+	Kind        string `json:"kind"`
+	Address     string `json:"address"`
+	Description string `json:"description"`
+}
+
+// ProviderSchemaDiff contains the changes found between two versions of the provider schema classified as breaking (the
+// change may break existing configurations or force the replacement of existing resources) and non-breaking
+type ProviderSchemaDiff struct {
+	Breaking    []SchemaChange `json:"breaking"`
+	NonBreaking []SchemaChange `json:"non_breaking"`
+}
+
+// HasBreakingChanges returns true if any breaking change was found
+func (d *ProviderSchemaDiff) HasBreakingChanges() bool {
+	return len(d.Breaking) > 0
+}
+
+func (d *ProviderSchemaDiff) addBreaking(kind, address, description string, args ...interface{}) {
+	d.Breaking = append(d.Breaking, SchemaChange{Kind: kind, Address: address, Description: fmt.Sprintf(description, args...)})
+}
+
+func (d *ProviderSchemaDiff) addNonBreaking(kind, address, description string, args ...interface{}) {
+	d.NonBreaking = append(d.NonBreaking, SchemaChange{Kind: kind, Address: address, Description: fmt.Sprintf(description, args...)})
+}
+
+// DiffProviderSchemas builds the provider for the old and the new OpenAPI documents and returns the changes found between
+// the two provider schemas
+func DiffProviderSchemas(providerName string, oldServiceConfiguration, newServiceConfiguration ServiceConfiguration) (*ProviderSchemaDiff, error) {
+	oldProviderFactory, err := newProviderFactoryFromServiceConfiguration(providerName, oldServiceConfiguration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the old OpenAPI document: %s", err)
+	}
+	newProviderFactory, err := newProviderFactoryFromServiceConfiguration(providerName, newServiceConfiguration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the new OpenAPI document: %s", err)
+	}
+	return diffProviderFactories(*oldProviderFactory, *newProviderFactory)
+}
+
+func diffProviderFactories(oldProviderFactory, newProviderFactory providerFactory) (*ProviderSchemaDiff, error) {
+	oldProvider, err := oldProviderFactory.createProvider()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the provider for the old OpenAPI document: %s", err)
+	}
+	oldIdentifiers, err := oldProviderFactory.getResourceIdentifiers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the provider for the old OpenAPI document: %s", err)
+	}
+	newProvider, err := newProviderFactory.createProvider()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the provider for the new OpenAPI document: %s", err)
+	}
+	newIdentifiers, err := newProviderFactory.getResourceIdentifiers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the provider for the new OpenAPI document: %s", err)
+	}
+
+	diff := &ProviderSchemaDiff{Breaking: []SchemaChange{}, NonBreaking: []SchemaChange{}}
+	diffSchemaMaps(diff, fmt.Sprintf("%s.%s", providerSchemaDiffAddressPrefix, newProviderFactory.name), oldProvider.Schema, newProvider.Schema)
+	for _, name := range getSortedResourceNames(oldProvider.ResourcesMap) {
+		newResource, exists := newProvider.ResourcesMap[name]
+		if !exists {
+			diff.addBreaking(schemaChangeResourceRemoved, name, "resource removed")
+			continue
+		}
+		if oldIdentifiers[name] != newIdentifiers[name] {
+			diff.addBreaking(schemaChangeIdentifierChanged, name, "resource identifier changed from '%s' to '%s'", oldIdentifiers[name], newIdentifiers[name])
+		}
+		diffSchemaMaps(diff, name, oldProvider.ResourcesMap[name].Schema, newResource.Schema)
+	}
+	for _, name := range getSortedResourceNames(newProvider.ResourcesMap) {
+		if _, exists := oldProvider.ResourcesMap[name]; !exists {
+			diff.addNonBreaking(schemaChangeResourceAdded, name, "resource added")
+		}
+	}
+	for _, name := range getSortedResourceNames(oldProvider.DataSourcesMap) {
+		address := fmt.Sprintf("%s.%s", dataSourceSchemaDiffAddressPrefix, name)
+		newDataSource, exists := newProvider.DataSourcesMap[name]
+		if !exists {
+			diff.addBreaking(schemaChangeDataSourceRemoved, address, "data source removed")
+			continue
+		}
+		diffSchemaMaps(diff, address, oldProvider.DataSourcesMap[name].Schema, newDataSource.Schema)
+	}
+	for _, name := range getSortedResourceNames(newProvider.DataSourcesMap) {
+		if _, exists := oldProvider.DataSourcesMap[name]; !exists {
+			diff.addNonBreaking(schemaChangeDataSourceAdded, fmt.Sprintf("%s.%s", dataSourceSchemaDiffAddressPrefix, name), "data source added")
+		}
+	}
+	return diff, nil
+}
+
+// getResourceIdentifiers returns the identifier property of each of the resources registered in the provider keyed by the
+// provider resource name
+func (p providerFactory) getResourceIdentifiers() (map[string]string, error) {
+	identifiers := map[string]string{}
+	openAPIResources, err := p.specAnalyser.GetTerraformCompliantResources()
+	if err != nil {
+		return nil, err
+	}
+	for _, openAPIResource := range openAPIResources {
+		if openAPIResource.ShouldIgnoreResource() {
+			continue
+		}
+		resourceName, err := p.getProviderResourceName(openAPIResource.GetResourceName())
+		if err != nil {
+			return nil, err
+		}
+		resourceSchema, err := openAPIResource.GetResourceSchema()
+		if err != nil {
+			return nil, err
+		}
+		identifier, err := resourceSchema.getResourceIdentifier()
+		if err != nil {
+			return nil, err
+		}
+		identifiers[resourceName] = identifier
+	}
+	return identifiers, nil
+}
+
+// diffSchemaMaps compares the properties of the old and new schemas, nested object properties included
+func diffSchemaMaps(diff *ProviderSchemaDiff, address string, oldSchemaMap, newSchemaMap map[string]*schema.Schema) {
+	for _, name := range getSortedSchemaNames(oldSchemaMap) {
+		propertyAddress := fmt.Sprintf("%s.%s", address, name)
+		oldSchema := oldSchemaMap[name]
+		newSchema, exists := newSchemaMap[name]
+		if !exists {
+			diff.addBreaking(schemaChangeAttributeRemoved, propertyAddress, "attribute removed")
+			continue
+		}
+		diffSchemas(diff, propertyAddress, oldSchema, newSchema)
+	}
+	for _, name := range getSortedSchemaNames(newSchemaMap) {
+		if _, exists := oldSchemaMap[name]; exists {
+			continue
+		}
+		propertyAddress := fmt.Sprintf("%s.%s", address, name)
+		if newSchemaMap[name].Required {
+			diff.addBreaking(schemaChangeRequiredAdded, propertyAddress, "new required attribute added")
+			continue
+		}
+		diff.addNonBreaking(schemaChangeAttributeAdded, propertyAddress, "attribute added")
+	}
+}
+
+func diffSchemas(diff *ProviderSchemaDiff, address string, oldSchema, newSchema *schema.Schema) {
+	oldType := getSchemaTypeDescription(oldSchema)
+	newType := getSchemaTypeDescription(newSchema)
+	if oldType != newType {
+		diff.addBreaking(schemaChangeTypeChanged, address, "type changed from %s to %s", oldType, newType)
+		return
+	}
+	// attributes that can no longer be configured (Optional/Required to Computed only) break the configurations that set them
+	computedOnly := isConfigurableSchema(oldSchema) && !isConfigurableSchema(newSchema)
+	if computedOnly {
+		diff.addBreaking(schemaChangeComputedOnly, address, "attribute is now computed only and can no longer be configured")
+	}
+	if !oldSchema.Required && newSchema.Required {
+		diff.addBreaking(schemaChangeRequiredAdded, address, "attribute is now required")
+	}
+	if oldSchema.Required && !newSchema.Required && !computedOnly {
+		diff.addNonBreaking(schemaChangeRequiredRemoved, address, "attribute is no longer required")
+	}
+	if !oldSchema.ForceNew && newSchema.ForceNew {
+		diff.addBreaking(schemaChangeForceNewAdded, address, "changes to the attribute now force the replacement of the resource")
+	}
+	if oldSchema.ForceNew && !newSchema.ForceNew {
+		diff.addNonBreaking(schemaChangeForceNewRemoved, address, "changes to the attribute no longer force the replacement of the resource")
+	}
+	if oldSchema.Computed != newSchema.Computed && !computedOnly {
+		diff.addNonBreaking(schemaChangeComputedChanged, address, "computed changed from %t to %t", oldSchema.Computed, newSchema.Computed)
+	}
+	if oldSchema.Sensitive != newSchema.Sensitive {
+		diff.addNonBreaking(schemaChangeSensitiveChanged, address, "sensitive changed from %t to %t", oldSchema.Sensitive, newSchema.Sensitive)
+	}
+	oldObject, isOldObject := oldSchema.Elem.(*schema.Resource)
+	newObject, isNewObject := newSchema.Elem.(*schema.Resource)
+	if isOldObject && isNewObject {
+		diffSchemaMaps(diff, address, oldObject.Schema, newObject.Schema)
+	}
+}
+
+// isConfigurableSchema returns true if the attribute can be set in the Terraform configuration
+func isConfigurableSchema(s *schema.Schema) bool {
+	return s.Required || s.Optional
+}
+
+// getSchemaTypeDescription returns the human readable description of the schema type (e,g: string, list(string) or list(object))
+func getSchemaTypeDescription(s *schema.Schema) string {
+	switch s.Type {
+	case schema.TypeString:
+		return "string"
+	case schema.TypeInt:
+		return "integer"
+	case schema.TypeFloat:
+		return "number"
+	case schema.TypeBool:
+		return "bool"
+	case schema.TypeList, schema.TypeSet, schema.TypeMap:
+		elemType := "string"
+		switch elem := s.Elem.(type) {
+		case *schema.Schema:
+			elemType = getSchemaTypeDescription(elem)
+		case *schema.Resource:
+			elemType = "object"
+		}
+		switch s.Type {
+		case schema.TypeSet:
+			return fmt.Sprintf("set(%s)", elemType)
+		case schema.TypeMap:
+			return fmt.Sprintf("map(%s)", elemType)
+		}
+		return fmt.Sprintf("list(%s)", elemType)
+	}
+	return s.Type.String()
+}
+
+func getSortedResourceNames(resourceMap map[string]*schema.Resource) []string {
+	var names []string
+	for name := range resourceMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func getSortedSchemaNames(schemaMap map[string]*schema.Schema) []string {
+	var names []string
+	for name := range schemaMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
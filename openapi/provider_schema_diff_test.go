@@ -0,0 +1,286 @@
+package openapi
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newDiffTestProviderFactory(resources ...SpecResource) providerFactory {
+	return providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			resources: resources,
+			security: &specSecurityStub{
+				securityDefinitions:   &SpecSecurityDefinitions{},
+				globalSecuritySchemes: SpecSecuritySchemes{},
+			},
+			backendConfiguration: &specStubBackendConfiguration{},
+		},
+		serviceConfiguration: &ServiceConfigStub{},
+	}
+}
+
+func TestDiffProviderFactories(t *testing.T) {
+	Convey("Given an old and a new provider factory with different resources and schemas", t, func() {
+		idProperty := &SpecSchemaDefinitionProperty{Name: "id", Type: TypeString, ReadOnly: true}
+		oldProviderFactory := newDiffTestProviderFactory(
+			newSpecStubResource("cdn_v1", "/v1/cdns", false, &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					idProperty,
+					&SpecSchemaDefinitionProperty{Name: "label", Type: TypeString, Required: true},
+					&SpecSchemaDefinitionProperty{Name: "port", Type: TypeInt},
+					&SpecSchemaDefinitionProperty{Name: "name", Type: TypeString},
+					&SpecSchemaDefinitionProperty{Name: "obj", Type: TypeObject, SpecSchemaDefinition: &SpecSchemaDefinition{
+						Properties: SpecSchemaDefinitionProperties{
+							&SpecSchemaDefinitionProperty{Name: "a", Type: TypeString},
+						},
+					}},
+				},
+			}),
+			newSpecStubResource("legacy_v1", "/v1/legacies", false, &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{idProperty}}),
+			newSpecStubResource("firewall_v1", "/v1/firewalls", false, &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					idProperty,
+					&SpecSchemaDefinitionProperty{Name: "name", Type: TypeString},
+				},
+			}),
+		)
+		newProviderFactory := newDiffTestProviderFactory(
+			newSpecStubResource("cdn_v1", "/v1/cdns", false, &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					idProperty,
+					&SpecSchemaDefinitionProperty{Name: "label", Type: TypeInt, Required: true},
+					&SpecSchemaDefinitionProperty{Name: "name", Type: TypeString, Required: true, ForceNew: true},
+					&SpecSchemaDefinitionProperty{Name: "region", Type: TypeString, Required: true},
+					&SpecSchemaDefinitionProperty{Name: "description", Type: TypeString},
+					&SpecSchemaDefinitionProperty{Name: "obj", Type: TypeObject, SpecSchemaDefinition: &SpecSchemaDefinition{
+						Properties: SpecSchemaDefinitionProperties{
+							&SpecSchemaDefinitionProperty{Name: "a", Type: TypeString},
+							&SpecSchemaDefinitionProperty{Name: "b", Type: TypeString, Required: true},
+						},
+					}},
+				},
+			}),
+			newSpecStubResource("network_v1", "/v1/networks", false, &SpecSchemaDefinition{Properties: SpecSchemaDefinitionProperties{idProperty}}),
+			newSpecStubResource("firewall_v1", "/v1/firewalls", false, &SpecSchemaDefinition{
+				Properties: SpecSchemaDefinitionProperties{
+					idProperty,
+					&SpecSchemaDefinitionProperty{Name: "name", Type: TypeString, IsIdentifier: true},
+				},
+			}),
+		)
+		Convey("When diffProviderFactories is called", func() {
+			diff, err := diffProviderFactories(oldProviderFactory, newProviderFactory)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the diff should contain breaking changes", func() {
+				So(diff.HasBreakingChanges(), ShouldBeTrue)
+			})
+			Convey("And the breaking changes should contain the removed resources and attributes", func() {
+				So(diff.Breaking, ShouldContain, SchemaChange{Kind: schemaChangeResourceRemoved, Address: "provider_legacy_v1", Description: "resource removed"})
+				So(diff.Breaking, ShouldContain, SchemaChange{Kind: schemaChangeDataSourceRemoved, Address: "data.provider_legacy_v1_instance", Description: "data source removed"})
+				So(diff.Breaking, ShouldContain, SchemaChange{Kind: schemaChangeAttributeRemoved, Address: "provider_cdn_v1.port", Description: "attribute removed"})
+				So(diff.Breaking, ShouldContain, SchemaChange{Kind: schemaChangeAttributeRemoved, Address: "provider.provider.endpoints.legacy_v1", Description: "attribute removed"})
+			})
+			Convey("And the breaking changes should contain the type changes", func() {
+				So(diff.Breaking, ShouldContain, SchemaChange{Kind: schemaChangeTypeChanged, Address: "provider_cdn_v1.label", Description: "type changed from string to integer"})
+			})
+			Convey("And the breaking changes should contain the new required arguments", func() {
+				So(diff.Breaking, ShouldContain, SchemaChange{Kind: schemaChangeRequiredAdded, Address: "provider_cdn_v1.name", Description: "attribute is now required"})
+				So(diff.Breaking, ShouldContain, SchemaChange{Kind: schemaChangeRequiredAdded, Address: "provider_cdn_v1.region", Description: "new required attribute added"})
+				So(diff.Breaking, ShouldContain, SchemaChange{Kind: schemaChangeRequiredAdded, Address: "provider_cdn_v1.obj.b", Description: "new required attribute added"})
+			})
+			Convey("And the breaking changes should contain the attributes that now force new", func() {
+				So(diff.Breaking, ShouldContain, SchemaChange{Kind: schemaChangeForceNewAdded, Address: "provider_cdn_v1.name", Description: "changes to the attribute now force the replacement of the resource"})
+			})
+			Convey("And the breaking changes should contain the identifier changes", func() {
+				So(diff.Breaking, ShouldContain, SchemaChange{Kind: schemaChangeIdentifierChanged, Address: "provider_firewall_v1", Description: "resource identifier changed from 'id' to 'name'"})
+			})
+			Convey("And the non breaking changes should contain the added resources and optional attributes", func() {
+				So(diff.NonBreaking, ShouldContain, SchemaChange{Kind: schemaChangeResourceAdded, Address: "provider_network_v1", Description: "resource added"})
+				So(diff.NonBreaking, ShouldContain, SchemaChange{Kind: schemaChangeDataSourceAdded, Address: "data.provider_network_v1_instance", Description: "data source added"})
+				So(diff.NonBreaking, ShouldContain, SchemaChange{Kind: schemaChangeAttributeAdded, Address: "provider_cdn_v1.description", Description: "attribute added"})
+			})
+			Convey("And the unchanged attributes should not be reported", func() {
+				for _, change := range append(diff.Breaking, diff.NonBreaking...) {
+					So(change.Address, ShouldNotEqual, "provider_cdn_v1.obj.a")
+					So(change.Address, ShouldNotEqual, "provider_cdn_v1.id")
+				}
+			})
+		})
+		Convey("When diffProviderFactories is called with the same provider factory", func() {
+			diff, err := diffProviderFactories(oldProviderFactory, oldProviderFactory)
+			Convey("Then the diff should be empty", func() {
+				So(err, ShouldBeNil)
+				So(diff.HasBreakingChanges(), ShouldBeFalse)
+				So(diff.Breaking, ShouldBeEmpty)
+				So(diff.NonBreaking, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestDiffSchemas(t *testing.T) {
+	Convey("Given an attribute that is no longer required, no longer forces new and changes computed and sensitive", t, func() {
+		oldSchema := &schema.Schema{Type: schema.TypeString, Required: true, ForceNew: true}
+		newSchema := &schema.Schema{Type: schema.TypeString, Optional: true, Computed: true, Sensitive: true}
+		Convey("When diffSchemas is called", func() {
+			diff := &ProviderSchemaDiff{}
+			diffSchemas(diff, "provider_cdn_v1.label", oldSchema, newSchema)
+			Convey("Then all the changes should be non breaking", func() {
+				So(diff.Breaking, ShouldBeEmpty)
+				So(diff.NonBreaking, ShouldResemble, []SchemaChange{
+					{Kind: schemaChangeRequiredRemoved, Address: "provider_cdn_v1.label", Description: "attribute is no longer required"},
+					{Kind: schemaChangeForceNewRemoved, Address: "provider_cdn_v1.label", Description: "changes to the attribute no longer force the replacement of the resource"},
+					{Kind: schemaChangeComputedChanged, Address: "provider_cdn_v1.label", Description: "computed changed from false to true"},
+					{Kind: schemaChangeSensitiveChanged, Address: "provider_cdn_v1.label", Description: "sensitive changed from false to true"},
+				})
+			})
+		})
+	})
+}
+
+func TestDiffSchemasComputedOnly(t *testing.T) {
+	Convey("Given attributes that go from optional or required to computed only", t, func() {
+		testCases := []struct {
+			oldSchema *schema.Schema
+		}{
+			{oldSchema: &schema.Schema{Type: schema.TypeString, Optional: true}},
+			{oldSchema: &schema.Schema{Type: schema.TypeString, Optional: true, Computed: true}},
+			{oldSchema: &schema.Schema{Type: schema.TypeString, Required: true}},
+		}
+		newSchema := &schema.Schema{Type: schema.TypeString, Computed: true}
+		Convey("When diffSchemas is called", func() {
+			Convey("Then the change should be reported as breaking", func() {
+				for _, tc := range testCases {
+					diff := &ProviderSchemaDiff{}
+					diffSchemas(diff, "provider_cdn_v1.label", tc.oldSchema, newSchema)
+					So(diff.Breaking, ShouldResemble, []SchemaChange{
+						{Kind: schemaChangeComputedOnly, Address: "provider_cdn_v1.label", Description: "attribute is now computed only and can no longer be configured"},
+					})
+					So(diff.NonBreaking, ShouldBeEmpty)
+				}
+			})
+		})
+	})
+	Convey("Given an attribute that goes from computed only to optional", t, func() {
+		oldSchema := &schema.Schema{Type: schema.TypeString, Computed: true}
+		newSchema := &schema.Schema{Type: schema.TypeString, Optional: true}
+		Convey("When diffSchemas is called", func() {
+			diff := &ProviderSchemaDiff{}
+			diffSchemas(diff, "provider_cdn_v1.label", oldSchema, newSchema)
+			Convey("Then the change should be reported as non breaking", func() {
+				So(diff.Breaking, ShouldBeEmpty)
+				So(diff.NonBreaking, ShouldResemble, []SchemaChange{
+					{Kind: schemaChangeComputedChanged, Address: "provider_cdn_v1.label", Description: "computed changed from true to false"},
+				})
+			})
+		})
+	})
+}
+
+func TestGetSchemaTypeDescription(t *testing.T) {
+	Convey("Given a set of schemas", t, func() {
+		testCases := []struct {
+			schema              *schema.Schema
+			expectedDescription string
+		}{
+			{schema: &schema.Schema{Type: schema.TypeString}, expectedDescription: "string"},
+			{schema: &schema.Schema{Type: schema.TypeInt}, expectedDescription: "integer"},
+			{schema: &schema.Schema{Type: schema.TypeFloat}, expectedDescription: "number"},
+			{schema: &schema.Schema{Type: schema.TypeBool}, expectedDescription: "bool"},
+			{schema: &schema.Schema{Type: schema.TypeList, Elem: &schema.Schema{Type: schema.TypeInt}}, expectedDescription: "list(integer)"},
+			{schema: &schema.Schema{Type: schema.TypeSet, Elem: &schema.Schema{Type: schema.TypeString}}, expectedDescription: "set(string)"},
+			{schema: &schema.Schema{Type: schema.TypeMap}, expectedDescription: "map(string)"},
+			{schema: &schema.Schema{Type: schema.TypeList, Elem: &schema.Resource{}}, expectedDescription: "list(object)"},
+		}
+		Convey("When getSchemaTypeDescription is called", func() {
+			Convey("Then the description returned should be the expected one", func() {
+				for _, tc := range testCases {
+					So(getSchemaTypeDescription(tc.schema), ShouldEqual, tc.expectedDescription)
+				}
+			})
+		})
+	})
+}
+
+func TestDiffProviderSchemas(t *testing.T) {
+	Convey("Given an old and a new swagger file where the new one removes an attribute", t, func() {
+		swaggerTemplate := `swagger: "2.0"
+host: "localhost:8443"
+schemes:
+  - "https"
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+    delete:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        204:
+          description: "successful operation, no content is returned"
+definitions:
+  ContentDeliveryNetworkV1:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"`
+		oldSwagger := initAPISpecFile(swaggerTemplate + `
+      port:
+        type: "integer"`)
+		defer os.Remove(oldSwagger.Name())
+		newSwagger := initAPISpecFile(swaggerTemplate)
+		defer os.Remove(newSwagger.Name())
+		Convey("When DiffProviderSchemas is called", func() {
+			diff, err := DiffProviderSchemas("openapi", &ServiceConfigStub{SwaggerURL: oldSwagger.Name()}, &ServiceConfigStub{SwaggerURL: newSwagger.Name()})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the diff should contain the attribute removed in both the resource and the data source", func() {
+				So(diff.Breaking, ShouldResemble, []SchemaChange{
+					{Kind: schemaChangeAttributeRemoved, Address: "openapi_cdns_v1.port", Description: "attribute removed"},
+					{Kind: schemaChangeAttributeRemoved, Address: "data.openapi_cdns_v1_instance.port", Description: "attribute removed"},
+				})
+				So(diff.NonBreaking, ShouldBeEmpty)
+			})
+		})
+		Convey("When DiffProviderSchemas is called with a new swagger file that does not exist", func() {
+			_, err := DiffProviderSchemas("openapi", &ServiceConfigStub{SwaggerURL: oldSwagger.Name()}, &ServiceConfigStub{SwaggerURL: oldSwagger.Name() + "_missing"})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldStartWith, "failed to load the new OpenAPI document")
+			})
+		})
+	})
+}
@@ -0,0 +1,257 @@
+package openapi
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// providerSchemasFormatVersion is the version of the format produced by 'terraform providers schema -json'
+const providerSchemasFormatVersion = "0.1"
+
+const descriptionKindPlain = "plain"
+
+// Nesting modes supported by the block types
+const (
+	nestingModeSingle = "single"
+	nestingModeList   = "list"
+	nestingModeSet    = "set"
+)
+
+// ProviderSchemasJSON defines the provider schemas following the same structure as the output of 'terraform providers schema -json'
+// so tooling (e,g: linters, IDE plugins, policy engines) can consume the provider schema without running Terraform
+type ProviderSchemasJSON struct {
+	FormatVersion string                         `json:"format_version"`
+	Schemas       map[string]*ProviderSchemaJSON `json:"provider_schemas,omitempty"`
+}
+
+// ProviderSchemaJSON defines the schema of the provider configuration, the resources and the data sources
+type ProviderSchemaJSON struct {
+	Provider          *SchemaJSON            `json:"provider,omitempty"`
+	ResourceSchemas   map[string]*SchemaJSON `json:"resource_schemas,omitempty"`
+	DataSourceSchemas map[string]*SchemaJSON `json:"data_source_schemas,omitempty"`
+}
+
+// SchemaJSON defines the schema of the provider configuration, a resource or a data source
+type SchemaJSON struct {
+	Version int              `json:"version"`
+	Block   *SchemaBlockJSON `json:"block,omitempty"`
+}
+
+// SchemaBlockJSON defines the attributes and nested block types of a block
+type SchemaBlockJSON struct {
+	Attributes      map[string]*SchemaAttributeJSON `json:"attributes,omitempty"`
+	BlockTypes      map[string]*SchemaBlockTypeJSON `json:"block_types,omitempty"`
+	Description     string                          `json:"description,omitempty"`
+	DescriptionKind string                          `json:"description_kind,omitempty"`
+	Deprecated      bool                            `json:"deprecated,omitempty"`
+}
+
+// SchemaAttributeJSON defines an attribute of a block. Type contains the JSON representation of the attribute's type (e,g:
+// "string", ["list","string"] or ["object",{"name":"string"}])
+type SchemaAttributeJSON struct {
+	Type            interface{} `json:"type,omitempty"`
+	Description     string      `json:"description,omitempty"`
+	DescriptionKind string      `json:"description_kind,omitempty"`
+	Deprecated      bool        `json:"deprecated,omitempty"`
+	Required        bool        `json:"required,omitempty"`
+	Optional        bool        `json:"optional,omitempty"`
+	Computed        bool        `json:"computed,omitempty"`
+	Sensitive       bool        `json:"sensitive,omitempty"`
+}
+
+// SchemaBlockTypeJSON defines a nested block type of a block
+type SchemaBlockTypeJSON struct {
+	NestingMode string           `json:"nesting_mode,omitempty"`
+	Block       *SchemaBlockJSON `json:"block,omitempty"`
+	MinItems    int              `json:"min_items,omitempty"`
+	MaxItems    int              `json:"max_items,omitempty"`
+}
+
+// ExportProviderSchemas creates the provider for the OpenAPI document configured in the service configuration and returns its
+// schemas following the same structure as the output of 'terraform providers schema -json'
+func ExportProviderSchemas(providerName string, serviceConfiguration ServiceConfiguration) (*ProviderSchemasJSON, error) {
+	providerFactory, err := newProviderFactoryFromServiceConfiguration(providerName, serviceConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	return providerFactory.createProviderSchemas()
+}
+
+// createProviderSchemas creates the provider and returns its schemas
+func (p providerFactory) createProviderSchemas() (*ProviderSchemasJSON, error) {
+	provider, err := p.createProvider()
+	if err != nil {
+		return nil, err
+	}
+	return newProviderSchemasJSON(p.name, provider), nil
+}
+
+// newProviderSchemasJSON lowers the provider schemas the same way the Terraform SDK does when Terraform core requests the
+// provider schema (see schema.Provider.GetSchema)
+func newProviderSchemasJSON(providerName string, provider *schema.Provider) *ProviderSchemasJSON {
+	providerSchema := &ProviderSchemaJSON{
+		Provider:          &SchemaJSON{Block: newSchemaBlockJSON(provider.Schema)},
+		ResourceSchemas:   map[string]*SchemaJSON{},
+		DataSourceSchemas: map[string]*SchemaJSON{},
+	}
+	for name, resource := range provider.ResourcesMap {
+		providerSchema.ResourceSchemas[name] = newResourceSchemaJSON(resource)
+	}
+	for name, dataSource := range provider.DataSourcesMap {
+		providerSchema.DataSourceSchemas[name] = newResourceSchemaJSON(dataSource)
+	}
+	return &ProviderSchemasJSON{
+		FormatVersion: providerSchemasFormatVersion,
+		Schemas:       map[string]*ProviderSchemaJSON{providerName: providerSchema},
+	}
+}
+
+// newResourceSchemaJSON returns the schema of the resource (or data source) including the implicit id attribute and the
+// timeouts block when the resource supports timeouts
+func newResourceSchemaJSON(resource *schema.Resource) *SchemaJSON {
+	block := newSchemaBlockJSON(resource.Schema)
+	block.Deprecated = resource.DeprecationMessage != ""
+	if _, exists := block.Attributes["id"]; !exists {
+		block.Attributes["id"] = &SchemaAttributeJSON{Type: "string", DescriptionKind: descriptionKindPlain, Optional: true, Computed: true}
+	}
+	_, timeoutsAttribute := block.Attributes[schema.TimeoutsConfigKey]
+	_, timeoutsBlock := block.BlockTypes[schema.TimeoutsConfigKey]
+	if resource.Timeouts != nil && !timeoutsAttribute && !timeoutsBlock {
+		timeouts := &SchemaBlockJSON{Attributes: map[string]*SchemaAttributeJSON{}, DescriptionKind: descriptionKindPlain}
+		timeoutValues := map[string]bool{
+			schema.TimeoutCreate:  resource.Timeouts.Create != nil,
+			schema.TimeoutRead:    resource.Timeouts.Read != nil,
+			schema.TimeoutUpdate:  resource.Timeouts.Update != nil,
+			schema.TimeoutDelete:  resource.Timeouts.Delete != nil,
+			schema.TimeoutDefault: resource.Timeouts.Default != nil,
+		}
+		for timeout, configured := range timeoutValues {
+			if configured {
+				timeouts.Attributes[timeout] = &SchemaAttributeJSON{Type: "string", DescriptionKind: descriptionKindPlain, Optional: true}
+			}
+		}
+		block.BlockTypes[schema.TimeoutsConfigKey] = &SchemaBlockTypeJSON{NestingMode: nestingModeSingle, Block: timeouts}
+	}
+	return &SchemaJSON{Version: resource.SchemaVersion, Block: block}
+}
+
+func newSchemaBlockJSON(schemaMap map[string]*schema.Schema) *SchemaBlockJSON {
+	block := &SchemaBlockJSON{
+		Attributes:      map[string]*SchemaAttributeJSON{},
+		BlockTypes:      map[string]*SchemaBlockTypeJSON{},
+		DescriptionKind: descriptionKindPlain,
+	}
+	for name, s := range schemaMap {
+		if isSchemaBlockType(s) {
+			block.BlockTypes[name] = newSchemaBlockTypeJSON(s)
+			continue
+		}
+		block.Attributes[name] = newSchemaAttributeJSON(s)
+	}
+	return block
+}
+
+// isSchemaBlockType returns true if the schema is represented as a nested block. Collections of objects are represented
+// as blocks unless they are computed only (as they never appear in the configuration) or they are maps
+func isSchemaBlockType(s *schema.Schema) bool {
+	if _, isResource := s.Elem.(*schema.Resource); !isResource || s.Type == schema.TypeMap {
+		return false
+	}
+	switch s.ConfigMode {
+	case schema.SchemaConfigModeAttr:
+		return false
+	case schema.SchemaConfigModeBlock:
+		return true
+	}
+	return !s.Computed || s.Optional
+}
+
+func newSchemaAttributeJSON(s *schema.Schema) *SchemaAttributeJSON {
+	required := s.Required
+	optional := s.Optional
+	// required properties with a default function returning a value (e,g: env default) are not required in the configuration
+	if required && s.DefaultFunc != nil {
+		if v, err := s.DefaultFunc(); err != nil || v != nil {
+			required = false
+			optional = true
+		}
+	}
+	return &SchemaAttributeJSON{
+		Type:            getSchemaAttributeType(s),
+		Description:     s.Description,
+		DescriptionKind: descriptionKindPlain,
+		Deprecated:      s.Deprecated != "",
+		Required:        required,
+		Optional:        optional,
+		Computed:        s.Computed,
+		Sensitive:       s.Sensitive,
+	}
+}
+
+func newSchemaBlockTypeJSON(s *schema.Schema) *SchemaBlockTypeJSON {
+	blockType := &SchemaBlockTypeJSON{
+		Block:    newSchemaBlockJSON(s.Elem.(*schema.Resource).Schema),
+		MinItems: s.MinItems,
+		MaxItems: s.MaxItems,
+	}
+	blockType.NestingMode = nestingModeList
+	if s.Type == schema.TypeSet {
+		blockType.NestingMode = nestingModeSet
+	}
+	// blocks have no required representation so at least one item is required instead
+	if s.Required && s.MinItems == 0 {
+		blockType.MinItems = 1
+	}
+	if s.Optional && s.MinItems > 0 {
+		blockType.MinItems = 0
+	}
+	if s.Computed && !s.Optional {
+		blockType.MinItems = 0
+		blockType.MaxItems = 0
+	}
+	return blockType
+}
+
+// getSchemaAttributeType returns the JSON representation of the schema type
+func getSchemaAttributeType(s *schema.Schema) interface{} {
+	switch s.Type {
+	case schema.TypeBool:
+		return "bool"
+	case schema.TypeInt, schema.TypeFloat:
+		return "number"
+	case schema.TypeList, schema.TypeSet, schema.TypeMap:
+		var elemType interface{} = "string"
+		switch elem := s.Elem.(type) {
+		case *schema.Schema:
+			elemType = getSchemaAttributeType(elem)
+		case schema.ValueType:
+			elemType = getSchemaAttributeType(&schema.Schema{Type: elem})
+		case *schema.Resource:
+			// maps of objects are treated as maps of strings by the Terraform SDK
+			if s.Type != schema.TypeMap {
+				elemType = getSchemaObjectType(elem.Schema)
+			}
+		}
+		switch s.Type {
+		case schema.TypeSet:
+			return []interface{}{"set", elemType}
+		case schema.TypeMap:
+			return []interface{}{"map", elemType}
+		}
+		return []interface{}{"list", elemType}
+	}
+	return "string"
+}
+
+// getSchemaObjectType returns the JSON representation of the object type implied by the schema, nested blocks included
+func getSchemaObjectType(schemaMap map[string]*schema.Schema) interface{} {
+	block := newSchemaBlockJSON(schemaMap)
+	attributeTypes := map[string]interface{}{}
+	for name, attribute := range block.Attributes {
+		attributeTypes[name] = attribute.Type
+	}
+	// nested blocks are collections (list or set) of objects named after their nesting mode
+	for name, blockType := range block.BlockTypes {
+		attributeTypes[name] = []interface{}{blockType.NestingMode, getSchemaObjectType(schemaMap[name].Elem.(*schema.Resource).Schema)}
+	}
+	return []interface{}{"object", attributeTypes}
+}
@@ -0,0 +1,250 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExportProviderSchemas(t *testing.T) {
+	Convey("Given a swagger file containing a resource and a provider name", t, func() {
+		swaggerContent := `swagger: "2.0"
+host: "localhost:8443"
+schemes:
+  - "https"
+security:
+  - apikey_auth: []
+securityDefinitions:
+  apikey_auth:
+    type: "apiKey"
+    name: "Authorization"
+    in: "header"
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+    delete:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        204:
+          description: "successful operation, no content is returned"
+definitions:
+  ContentDeliveryNetworkV1:
+    type: "object"
+    required:
+      - label
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"
+      secret:
+        type: "string"
+        x-terraform-sensitive: true`
+		swaggerServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(swaggerContent))
+		}))
+		defer swaggerServer.Close()
+		Convey("When ExportProviderSchemas is called", func() {
+			providerSchemas, err := ExportProviderSchemas("openapi", &ServiceConfigStub{SwaggerURL: swaggerServer.URL})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the provider schemas returned should contain the provider configuration, resources and data sources", func() {
+				So(providerSchemas.FormatVersion, ShouldEqual, "0.1")
+				So(providerSchemas.Schemas, ShouldContainKey, "openapi")
+				providerSchema := providerSchemas.Schemas["openapi"]
+				So(providerSchema.Provider.Block.Attributes["apikey_auth"].Required, ShouldBeTrue)
+				So(providerSchema.ResourceSchemas, ShouldContainKey, "openapi_cdns_v1")
+				So(providerSchema.DataSourceSchemas, ShouldContainKey, "openapi_cdns_v1_instance")
+			})
+			Convey("And the resource schema should contain the expected attributes", func() {
+				attributes := providerSchemas.Schemas["openapi"].ResourceSchemas["openapi_cdns_v1"].Block.Attributes
+				So(*attributes["label"], ShouldResemble, SchemaAttributeJSON{Type: "string", DescriptionKind: "plain", Required: true})
+				So(*attributes["secret"], ShouldResemble, SchemaAttributeJSON{Type: "string", DescriptionKind: "plain", Optional: true, Sensitive: true})
+				So(attributes["id"].Computed, ShouldBeTrue)
+			})
+		})
+		Convey("When ExportProviderSchemas is called with a provider name that is not terraform compliant", func() {
+			_, err := ExportProviderSchemas("openAPI", &ServiceConfigStub{SwaggerURL: swaggerServer.URL})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "plugin provider factory init error: provider name 'openAPI' not terraform name compliant, please consider renaming provider to 'open_api'")
+			})
+		})
+	})
+}
+
+func TestCreateProviderSchemas(t *testing.T) {
+	Convey("Given a provider factory", t, func() {
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				resources: []SpecResource{newSpecStubResource("resource_v1", "/v1/resource", false, &SpecSchemaDefinition{})},
+				security: &specSecurityStub{
+					securityDefinitions:   &SpecSecurityDefinitions{},
+					globalSecuritySchemes: SpecSecuritySchemes{},
+				},
+				backendConfiguration: &specStubBackendConfiguration{},
+			},
+			serviceConfiguration: &ServiceConfigStub{},
+		}
+		Convey("When createProviderSchemas is called", func() {
+			providerSchemas, err := p.createProviderSchemas()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the provider schemas should contain the schemas of the provider created", func() {
+				So(providerSchemas.Schemas["provider"].Provider.Block.BlockTypes, ShouldContainKey, providerPropertyEndPoints)
+				So(providerSchemas.Schemas["provider"].ResourceSchemas, ShouldContainKey, "provider_resource_v1")
+				So(providerSchemas.Schemas["provider"].DataSourceSchemas, ShouldContainKey, "provider_resource_v1_instance")
+			})
+		})
+	})
+	Convey("Given a provider factory with a spec analyser that returns an error", t, func() {
+		p := providerFactory{
+			name:                 "provider",
+			specAnalyser:         &specAnalyserStub{error: errors.New("some error")},
+			serviceConfiguration: &ServiceConfigStub{},
+		}
+		Convey("When createProviderSchemas is called", func() {
+			_, err := p.createProviderSchemas()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestNewProviderSchemasJSON(t *testing.T) {
+	Convey("Given a provider with attributes, nested blocks and resources", t, func() {
+		objectSchema := &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {Type: schema.TypeString, Required: true},
+				"tags": {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			},
+		}
+		provider := &schema.Provider{
+			Schema: map[string]*schema.Schema{
+				"token":  {Type: schema.TypeString, Required: true, Sensitive: true, Description: "The token"},
+				"region": {Type: schema.TypeString, Required: true, DefaultFunc: schema.EnvDefaultFunc("EXPORT_TEST_UNSET_REGION", "rst1")},
+			},
+			ResourcesMap: map[string]*schema.Resource{
+				"provider_cdn_v1": {
+					SchemaVersion: 1,
+					Schema: map[string]*schema.Schema{
+						"label":          {Type: schema.TypeString, Required: true},
+						"port":           {Type: schema.TypeInt, Optional: true, Computed: true},
+						"ratio":          {Type: schema.TypeFloat, Optional: true, Deprecated: "use port instead"},
+						"enabled":        {Type: schema.TypeBool, Optional: true},
+						"labels":         {Type: schema.TypeMap, Optional: true},
+						"ips":            {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+						"object":         {Type: schema.TypeList, Required: true, MaxItems: 1, Elem: objectSchema},
+						"objects":        {Type: schema.TypeSet, Optional: true, MinItems: 2, Elem: objectSchema},
+						"computed_items": {Type: schema.TypeList, Computed: true, Elem: objectSchema},
+					},
+					Timeouts: &schema.ResourceTimeout{Create: schema.DefaultTimeout(1 * time.Minute), Delete: schema.DefaultTimeout(1 * time.Minute)},
+				},
+				"provider_legacy_v1": {
+					Schema: map[string]*schema.Schema{
+						"label": {Type: schema.TypeString, Required: true},
+					},
+					DeprecationMessage: "use provider_cdn_v1 instead",
+				},
+			},
+			DataSourcesMap: map[string]*schema.Resource{
+				"provider_cdn_v1_instance": {
+					Schema: map[string]*schema.Schema{
+						"id":    {Type: schema.TypeString, Required: true},
+						"label": {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+		}
+		Convey("When newProviderSchemasJSON is called", func() {
+			providerSchemas := newProviderSchemasJSON("provider", provider)
+			providerSchema := providerSchemas.Schemas["provider"]
+			Convey("Then the provider configuration schema should be the expected one", func() {
+				So(*providerSchema.Provider.Block.Attributes["token"], ShouldResemble, SchemaAttributeJSON{Type: "string", Description: "The token", DescriptionKind: "plain", Required: true, Sensitive: true})
+				So(*providerSchema.Provider.Block.Attributes["region"], ShouldResemble, SchemaAttributeJSON{Type: "string", DescriptionKind: "plain", Optional: true})
+				So(providerSchema.Provider.Block.Attributes, ShouldNotContainKey, "id")
+			})
+			Convey("And the resource schema should be rendered as the output of 'terraform providers schema -json'", func() {
+				b, err := json.Marshal(providerSchema.ResourceSchemas["provider_cdn_v1"])
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, `{"version":1,"block":{`+
+					`"attributes":{`+
+					`"computed_items":{"type":["list",["object",{"name":"string","tags":["list","string"]}]],"description_kind":"plain","computed":true},`+
+					`"enabled":{"type":"bool","description_kind":"plain","optional":true},`+
+					`"id":{"type":"string","description_kind":"plain","optional":true,"computed":true},`+
+					`"ips":{"type":["set","string"],"description_kind":"plain","optional":true},`+
+					`"label":{"type":"string","description_kind":"plain","required":true},`+
+					`"labels":{"type":["map","string"],"description_kind":"plain","optional":true},`+
+					`"port":{"type":"number","description_kind":"plain","optional":true,"computed":true},`+
+					`"ratio":{"type":"number","description_kind":"plain","deprecated":true,"optional":true}},`+
+					`"block_types":{`+
+					`"object":{"nesting_mode":"list","block":{"attributes":{"name":{"type":"string","description_kind":"plain","required":true},"tags":{"type":["list","string"],"description_kind":"plain","optional":true}},"description_kind":"plain"},"min_items":1,"max_items":1},`+
+					`"objects":{"nesting_mode":"set","block":{"attributes":{"name":{"type":"string","description_kind":"plain","required":true},"tags":{"type":["list","string"],"description_kind":"plain","optional":true}},"description_kind":"plain"}},`+
+					`"timeouts":{"nesting_mode":"single","block":{"attributes":{"create":{"type":"string","description_kind":"plain","optional":true},"delete":{"type":"string","description_kind":"plain","optional":true}},"description_kind":"plain"}}},`+
+					`"description_kind":"plain"}}`)
+			})
+			Convey("And the deprecated resource schema should be marked as deprecated", func() {
+				So(providerSchema.ResourceSchemas["provider_legacy_v1"].Block.Deprecated, ShouldBeTrue)
+				So(providerSchema.ResourceSchemas["provider_cdn_v1"].Block.Deprecated, ShouldBeFalse)
+			})
+			Convey("And the data source schema should keep the id attribute defined", func() {
+				So(*providerSchema.DataSourceSchemas["provider_cdn_v1_instance"].Block.Attributes["id"], ShouldResemble, SchemaAttributeJSON{Type: "string", DescriptionKind: "plain", Required: true})
+			})
+			Convey("And the schemas should match the schemas returned by the Terraform SDK to Terraform core", func() {
+				sdkSchema, err := provider.GetSchema(&terraform.ProviderSchemaRequest{ResourceTypes: []string{"provider_cdn_v1"}, DataSources: []string{"provider_cdn_v1_instance"}})
+				So(err, ShouldBeNil)
+				So(len(providerSchema.Provider.Block.Attributes), ShouldEqual, len(sdkSchema.Provider.Attributes))
+				So(len(providerSchema.ResourceSchemas["provider_cdn_v1"].Block.Attributes), ShouldEqual, len(sdkSchema.ResourceTypes["provider_cdn_v1"].Attributes))
+				So(len(providerSchema.ResourceSchemas["provider_cdn_v1"].Block.BlockTypes), ShouldEqual, len(sdkSchema.ResourceTypes["provider_cdn_v1"].BlockTypes))
+				for name, attribute := range sdkSchema.ResourceTypes["provider_cdn_v1"].Attributes {
+					actual := providerSchema.ResourceSchemas["provider_cdn_v1"].Block.Attributes[name]
+					So(actual, ShouldNotBeNil)
+					expectedType, err := json.Marshal(attribute.Type)
+					So(err, ShouldBeNil)
+					actualType, err := json.Marshal(actual.Type)
+					So(err, ShouldBeNil)
+					So(string(actualType), ShouldEqual, string(expectedType))
+					So(actual.Required, ShouldEqual, attribute.Required)
+					So(actual.Optional, ShouldEqual, attribute.Optional)
+					So(actual.Computed, ShouldEqual, attribute.Computed)
+				}
+			})
+		})
+	})
+}
@@ -52,15 +52,40 @@ func (r resourceFactory) createTerraformResource() (*schema.Resource, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &schema.Resource{
+	resourceName := r.openAPIResource.GetResourceName()
+	resource := &schema.Resource{
 		Schema:   s,
-		Create:   r.create,
-		Read:     r.read,
-		Delete:   r.delete,
-		Update:   r.update,
+		Create:   traceOperation(fmt.Sprintf("create %s", resourceName), resourceName, TelemetryResourceOperationCreate, r.create),
+		Read:     traceOperation(fmt.Sprintf("read %s", resourceName), resourceName, TelemetryResourceOperationRead, r.read),
+		Delete:   traceOperation(fmt.Sprintf("delete %s", resourceName), resourceName, TelemetryResourceOperationDelete, r.delete),
+		Update:   traceOperation(fmt.Sprintf("update %s", resourceName), resourceName, TelemetryResourceOperationUpdate, r.update),
 		Importer: r.importer(),
 		Timeouts: timeouts,
-	}, nil
+		// Validates the attribute relationships that are not natively supported by the terraform schema (e,g: exactly one of)
+		CustomizeDiff: r.customizeDiff,
+		// Users will be warned when using a deprecated resource (e,g: any of its operations is marked as deprecated)
+		DeprecationMessage: r.openAPIResource.GetDeprecationMessage(),
+	}
+	if err := r.configureStateMigrations(resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// configureStateMigrations sets the schema version of the resource and the state upgraders that migrate the states created
+// with previous versions of the resource schema (if the resource schema is versioned)
+func (r resourceFactory) configureStateMigrations(resource *schema.Resource) error {
+	stateMigrations, err := r.openAPIResource.getStateMigrations()
+	if err != nil || stateMigrations == nil {
+		return err
+	}
+	stateUpgraders, err := stateMigrations.createStateUpgraders(resource.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		return err
+	}
+	resource.SchemaVersion = stateMigrations.SchemaVersion
+	resource.StateUpgraders = stateUpgraders
+	return nil
 }
 
 func (r resourceFactory) createSchemaResourceTimeout() (*schema.ResourceTimeout, error) {
@@ -87,7 +112,17 @@ func (r resourceFactory) createTerraformResourceSchema() (map[string]*schema.Sch
 	return schemaDefinition.createResourceSchema()
 }
 
-func (r resourceFactory) create(data *schema.ResourceData, i interface{}) error {
+// customizeDiff validates the resource configuration against the required with, exactly one of and at least one of
+// relationships of the resource properties
+func (r resourceFactory) customizeDiff(diff *schema.ResourceDiff, i interface{}) error {
+	schemaDefinition, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return err
+	}
+	return schemaDefinition.validateAttributeRelationships(diff, "")
+}
+
+func (r resourceFactory) create(data *schema.ResourceData, i interface{}) (err error) {
 	providerClient := i.(ClientOpenAPI)
 
 	if r.openAPIResource == nil {
@@ -96,6 +131,7 @@ func (r resourceFactory) create(data *schema.ResourceData, i interface{}) error
 	resourceName := r.openAPIResource.GetResourceName()
 
 	submitTelemetryMetric(providerClient, TelemetryResourceOperationCreate, resourceName, "")
+	defer submitTelemetryOperationDurationMetric(providerClient, TelemetryResourceOperationCreate, resourceName, "", time.Now(), &err)
 
 	parentIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
 	if err != nil {
@@ -107,6 +143,7 @@ func (r resourceFactory) create(data *schema.ResourceData, i interface{}) error
 	responsePayload := map[string]interface{}{}
 
 	res, err := providerClient.Post(r.openAPIResource, requestPayload, &responsePayload, parentIDs...)
+	submitTelemetryHTTPStatusMetric(providerClient, TelemetryResourceOperationCreate, resourceName, "", res)
 	if err != nil {
 		return err
 	}
@@ -128,7 +165,7 @@ func (r resourceFactory) create(data *schema.ResourceData, i interface{}) error
 	return updateStateWithPayloadData(r.openAPIResource, responsePayload, data)
 }
 
-func (r resourceFactory) readWithOptions(data *schema.ResourceData, i interface{}, handleNotFoundErr bool) error {
+func (r resourceFactory) readWithOptions(data *schema.ResourceData, i interface{}, handleNotFoundErr bool) (err error) {
 	openAPIClient := i.(ClientOpenAPI)
 
 	if r.openAPIResource == nil {
@@ -137,13 +174,17 @@ func (r resourceFactory) readWithOptions(data *schema.ResourceData, i interface{
 	resourceName := r.openAPIResource.GetResourceName()
 
 	submitTelemetryMetric(openAPIClient, TelemetryResourceOperationRead, resourceName, "")
+	defer submitTelemetryOperationDurationMetric(openAPIClient, TelemetryResourceOperationRead, resourceName, "", time.Now(), &err)
 
 	parentsIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
 	if err != nil {
 		return err
 	}
 
-	remoteData, err := r.readRemote(data.Id(), openAPIClient, parentsIDs...)
+	remoteData, resp, err := r.getRemote(data.Id(), openAPIClient, parentsIDs...)
+	// the HTTP status metric is only submitted for the read operation, the GET requests performed by other operations
+	// (e,g: polling and immutable fields checks) are not reads from the user's point of view
+	submitTelemetryHTTPStatusMetric(openAPIClient, TelemetryResourceOperationRead, resourceName, "", resp)
 
 	if err != nil {
 		if openapiErr, ok := err.(openapierr.Error); ok {
@@ -162,19 +203,25 @@ func (r resourceFactory) read(data *schema.ResourceData, i interface{}) error {
 }
 
 func (r resourceFactory) readRemote(id string, providerClient ClientOpenAPI, parentIDs ...string) (map[string]interface{}, error) {
-	var err error
+	remoteData, _, err := r.getRemote(id, providerClient, parentIDs...)
+	return remoteData, err
+}
+
+// getRemote performs the GET request for the resource and returns the response payload along with the HTTP response
+// (which might be nil if the request failed)
+func (r resourceFactory) getRemote(id string, providerClient ClientOpenAPI, parentIDs ...string) (map[string]interface{}, *http.Response, error) {
 	responsePayload := map[string]interface{}{}
 	resp, err := providerClient.Get(r.openAPIResource, id, &responsePayload, parentIDs...)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
 	if err := checkHTTPStatusCode(r.openAPIResource, resp, []int{http.StatusOK}); err != nil {
-		return nil, err
+		return nil, resp, err
 	}
 
 	log.Printf("[DEBUG] GET '%s' response received", r.openAPIResource.GetResourceName())
-	return responsePayload, nil
+	return responsePayload, resp, nil
 }
 
 func (r resourceFactory) getParentIDs(data *schema.ResourceData) ([]string, error) {
@@ -200,7 +247,7 @@ func (r resourceFactory) getParentIDs(data *schema.ResourceData) ([]string, erro
 	return []string{}, nil
 }
 
-func (r resourceFactory) update(data *schema.ResourceData, i interface{}) error {
+func (r resourceFactory) update(data *schema.ResourceData, i interface{}) (err error) {
 	providerClient := i.(ClientOpenAPI)
 
 	if r.openAPIResource == nil {
@@ -209,6 +256,7 @@ func (r resourceFactory) update(data *schema.ResourceData, i interface{}) error
 	resourceName := r.openAPIResource.GetResourceName()
 
 	submitTelemetryMetric(providerClient, TelemetryResourceOperationUpdate, resourceName, "")
+	defer submitTelemetryOperationDurationMetric(providerClient, TelemetryResourceOperationUpdate, resourceName, "", time.Now(), &err)
 
 	parentsIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
 	if err != nil {
@@ -225,6 +273,7 @@ func (r resourceFactory) update(data *schema.ResourceData, i interface{}) error
 		return err
 	}
 	res, err := providerClient.Put(r.openAPIResource, data.Id(), requestPayload, &responsePayload, parentsIDs...)
+	submitTelemetryHTTPStatusMetric(providerClient, TelemetryResourceOperationUpdate, resourceName, "", res)
 	if err != nil {
 		return err
 	}
@@ -240,7 +289,7 @@ func (r resourceFactory) update(data *schema.ResourceData, i interface{}) error
 	return updateStateWithPayloadData(r.openAPIResource, responsePayload, data)
 }
 
-func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) error {
+func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) (err error) {
 	providerClient := i.(ClientOpenAPI)
 
 	if r.openAPIResource == nil {
@@ -249,6 +298,7 @@ func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) error
 	resourceName := r.openAPIResource.GetResourceName()
 
 	submitTelemetryMetric(providerClient, TelemetryResourceOperationDelete, resourceName, "")
+	defer submitTelemetryOperationDurationMetric(providerClient, TelemetryResourceOperationDelete, resourceName, "", time.Now(), &err)
 
 	parentsIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
 	if err != nil {
@@ -260,6 +310,7 @@ func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) error
 		return fmt.Errorf("[resource='%s'] resource does not support DELETE operation, check the swagger file exposed on '%s'", r.openAPIResource.GetResourceName(), resourcePath)
 	}
 	res, err := providerClient.Delete(r.openAPIResource, data.Id(), parentsIDs...)
+	submitTelemetryHTTPStatusMetric(providerClient, TelemetryResourceOperationDelete, resourceName, "", res)
 	if err != nil {
 		return err
 	}
@@ -297,30 +348,33 @@ func (r resourceFactory) importer() *schema.ResourceImporter {
 			parentResourceInfo := r.openAPIResource.GetParentResourceInfo()
 			if parentResourceInfo != nil {
 				parentPropertyNames := parentResourceInfo.GetParentPropertiesNames()
-
-				// The expected format for the ID provided when importing a sub-resource is 1234/567 where 1234 would be the parentID and 567 the instance ID
-				ids := strings.Split(data.Id(), "/")
-				if len(ids) < 2 {
-					return results, fmt.Errorf("can not import a subresource without providing all the parent IDs (%d) and the instance ID", len(parentPropertyNames))
-				}
-				parentIDsLen := len(ids) - 1
-				if len(parentPropertyNames) < parentIDsLen {
-					return results, fmt.Errorf("the number of parent IDs provided %d is greater than the expected number of parent IDs %d", parentIDsLen, len(parentPropertyNames))
-				}
-				if len(parentPropertyNames) > parentIDsLen {
-					return results, fmt.Errorf("can not import a subresource without all the parent ids, expected %d and got %d parent IDs", len(parentPropertyNames), parentIDsLen)
+				parentIDs, instanceID, err := r.parseSubResourceImportID(data.Id(), parentPropertyNames)
+				if err != nil {
+					return results, err
 				}
 				for idx, parentPropertyName := range parentPropertyNames {
-					err := data.Set(parentPropertyName, ids[idx])
+					err := data.Set(parentPropertyName, parentIDs[idx])
 					if err != nil {
 						return nil, err
 					}
 				}
-				data.SetId(ids[len(ids)-1])
+				data.SetId(instanceID)
 			}
 			// If the resources is NOT a sub-resource and just a top level resource then the array passed in will just contain
 			// 	the data object we get from terraform core without any updates.
-			err := r.readWithOptions(data, i, true)
+			span, tracedClient := startClientSpan(providerClient, fmt.Sprintf("import %s", resourceName))
+			span.setAttribute("terraform.resource_name", resourceName)
+			span.setAttribute("terraform.operation", string(TelemetryResourceOperationImport))
+			// The ID provided might be a natural key (e,g: name=my-cdn) in which case the actual resource ID is resolved first
+			if naturalKeyFilters, isNaturalKey := r.getImportNaturalKeyFilters(data.Id()); isNaturalKey {
+				if err := r.resolveImportNaturalKey(data, tracedClient, naturalKeyFilters); err != nil {
+					span.end(err)
+					return nil, err
+				}
+			}
+			span.setAttribute("terraform.resource_id", data.Id())
+			err := r.readWithOptions(data, tracedClient, true)
+			span.end(err)
 			if err != nil {
 				return nil, err
 			}
@@ -329,6 +383,212 @@ func (r resourceFactory) importer() *schema.ResourceImporter {
 	}
 }
 
+// parseSubResourceImportID returns the parent IDs (in the same order as the parent property names) and the instance ID
+// contained in the ID provided when importing a sub-resource. The following formats are supported:
+// - Positional: 1234/567 where 1234 would be the parentID and 567 the instance ID
+// - Keyed: cdns_v1_id=1234,id=567 where the keys are the parent property names and id the instance ID
+// - URL path: /v1/cdns/1234/v1/firewalls/567 which is parsed against the resource path
+func (r resourceFactory) parseSubResourceImportID(importID string, parentPropertyNames []string) ([]string, string, error) {
+	if parentIDs, instanceID, isURLPath, err := r.parseSubResourceImportURLPath(importID, parentPropertyNames); isURLPath {
+		return parentIDs, instanceID, err
+	}
+	if parentIDs, instanceID, isKeyed, err := parseSubResourceImportKeyedID(importID, parentPropertyNames); isKeyed {
+		return parentIDs, instanceID, err
+	}
+	ids := strings.Split(importID, "/")
+	if len(ids) < 2 {
+		return nil, "", fmt.Errorf("can not import a subresource without providing all the parent IDs (%d) and the instance ID", len(parentPropertyNames))
+	}
+	parentIDsLen := len(ids) - 1
+	if len(parentPropertyNames) < parentIDsLen {
+		return nil, "", fmt.Errorf("the number of parent IDs provided %d is greater than the expected number of parent IDs %d", parentIDsLen, len(parentPropertyNames))
+	}
+	if len(parentPropertyNames) > parentIDsLen {
+		return nil, "", fmt.Errorf("can not import a subresource without all the parent ids, expected %d and got %d parent IDs", len(parentPropertyNames), parentIDsLen)
+	}
+	return ids[:parentIDsLen], ids[parentIDsLen], nil
+}
+
+// parseSubResourceImportKeyedID parses import IDs in the keyed format (e,g: cdns_v1_id=1234,id=567). The import ID is only
+// considered keyed if at least one of the keys is a parent property name. Any pairs other than the parent IDs and the id are
+// returned as the instance ID so they can be resolved as a natural key (e,g: cdns_v1_id=1234,name=my-firewall)
+func parseSubResourceImportKeyedID(importID string, parentPropertyNames []string) ([]string, string, bool, error) {
+	if !strings.Contains(importID, "=") {
+		return nil, "", false, nil
+	}
+	keyedIDs := map[string]string{}
+	var remainingPairs []string
+	for _, pair := range strings.Split(importID, ",") {
+		keyValue := strings.SplitN(pair, "=", 2)
+		if len(keyValue) != 2 {
+			return nil, "", false, nil
+		}
+		key := strings.TrimSpace(keyValue[0])
+		if key == idDefaultPropertyName || isParentPropertyName(key, parentPropertyNames) {
+			keyedIDs[key] = keyValue[1]
+			continue
+		}
+		remainingPairs = append(remainingPairs, pair)
+	}
+	isKeyed := false
+	for _, parentPropertyName := range parentPropertyNames {
+		if _, exists := keyedIDs[parentPropertyName]; exists {
+			isKeyed = true
+		}
+	}
+	if !isKeyed {
+		return nil, "", false, nil
+	}
+	parentIDs := []string{}
+	for _, parentPropertyName := range parentPropertyNames {
+		parentID, exists := keyedIDs[parentPropertyName]
+		if !exists || parentID == "" {
+			return nil, "", true, fmt.Errorf("can not import a subresource without all the parent ids, missing the parent ID for '%s'", parentPropertyName)
+		}
+		parentIDs = append(parentIDs, parentID)
+	}
+	instanceID, hasInstanceID := keyedIDs[idDefaultPropertyName]
+	switch {
+	case hasInstanceID && len(remainingPairs) > 0:
+		return nil, "", true, fmt.Errorf("can not import a subresource providing both the instance ID and a natural key (%s)", strings.Join(remainingPairs, ","))
+	case !hasInstanceID && len(remainingPairs) > 0:
+		instanceID = strings.Join(remainingPairs, ",")
+	}
+	if instanceID == "" {
+		return nil, "", true, fmt.Errorf("can not import a subresource without providing the instance ID ('%s' key)", idDefaultPropertyName)
+	}
+	return parentIDs, instanceID, true, nil
+}
+
+func isParentPropertyName(name string, parentPropertyNames []string) bool {
+	for _, parentPropertyName := range parentPropertyNames {
+		if name == parentPropertyName {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSubResourceImportURLPath parses import IDs in the URL path format (e,g: /v1/cdns/1234/v1/firewalls/567) against the
+// resource path (e,g: /v1/cdns/{cdn_id}/v1/firewalls). The import ID is only considered a URL path if it starts with a forward
+// slash followed by the first segment of the resource path. The instance ID is the remaining of the path after the resource path
+func (r resourceFactory) parseSubResourceImportURLPath(importID string, parentPropertyNames []string) ([]string, string, bool, error) {
+	if !strings.HasPrefix(importID, "/") {
+		return nil, "", false, nil
+	}
+	// The path template is resolved using the parent property names as placeholders (e,g: /v1/cdns/:cdns_v1_id/v1/firewalls)
+	placeholders := []string{}
+	for _, parentPropertyName := range parentPropertyNames {
+		placeholders = append(placeholders, fmt.Sprintf(":%s", parentPropertyName))
+	}
+	pathTemplate, err := r.openAPIResource.getResourcePath(placeholders)
+	if err != nil {
+		return nil, "", false, err
+	}
+	templateSegments := strings.Split(strings.Trim(pathTemplate, "/"), "/")
+	importSegments := strings.Split(strings.Trim(importID, "/"), "/")
+	if importSegments[0] != templateSegments[0] {
+		return nil, "", false, nil
+	}
+	parentIDs := map[string]string{}
+	for idx, templateSegment := range templateSegments {
+		isPlaceholder := strings.HasPrefix(templateSegment, ":") && isParentPropertyName(strings.TrimPrefix(templateSegment, ":"), parentPropertyNames)
+		if idx >= len(importSegments) || importSegments[idx] == "" {
+			if isPlaceholder {
+				return nil, "", true, fmt.Errorf("import path '%s' does not match the resource path '%s': missing the parent ID for '%s'", importID, pathTemplate, strings.TrimPrefix(templateSegment, ":"))
+			}
+			return nil, "", true, fmt.Errorf("import path '%s' does not match the resource path '%s': missing the path segment '%s'", importID, pathTemplate, templateSegment)
+		}
+		if isPlaceholder {
+			parentIDs[strings.TrimPrefix(templateSegment, ":")] = importSegments[idx]
+			continue
+		}
+		if importSegments[idx] != templateSegment {
+			return nil, "", true, fmt.Errorf("import path '%s' does not match the resource path '%s': expected path segment '%s' but got '%s'", importID, pathTemplate, templateSegment, importSegments[idx])
+		}
+	}
+	instanceID := strings.Join(importSegments[len(templateSegments):], "/")
+	if instanceID == "" {
+		return nil, "", true, fmt.Errorf("import path '%s' does not contain the instance ID after the resource path '%s'", importID, pathTemplate)
+	}
+	orderedParentIDs := []string{}
+	for _, parentPropertyName := range parentPropertyNames {
+		parentID, exists := parentIDs[parentPropertyName]
+		if !exists {
+			return nil, "", true, fmt.Errorf("import path '%s' does not match the resource path '%s': missing the parent ID for '%s'", importID, pathTemplate, parentPropertyName)
+		}
+		orderedParentIDs = append(orderedParentIDs, parentID)
+	}
+	return orderedParentIDs, instanceID, true, nil
+}
+
+// getImportNaturalKeyFilters returns the filters to look up the resource being imported when the import ID is a natural
+// key made of comma separated attribute=value pairs (e,g: name=my-cdn,region=rst1). The import ID is only considered a natural
+// key if all the attributes match primitive properties of the resource schema; otherwise the import ID is treated as the
+// resource ID (e,g: IDs containing '=' characters)
+func (r resourceFactory) getImportNaturalKeyFilters(importID string) (filters, bool) {
+	if !strings.Contains(importID, "=") {
+		return nil, false
+	}
+	specSchemaDefinition, err := r.openAPIResource.GetResourceSchema()
+	if err != nil {
+		return nil, false
+	}
+	naturalKeyFilters := filters{}
+	for _, pair := range strings.Split(importID, ",") {
+		keyValue := strings.SplitN(pair, "=", 2)
+		if len(keyValue) != 2 || keyValue[0] == "" {
+			return nil, false
+		}
+		property, err := specSchemaDefinition.getPropertyBasedOnTerraformName(strings.TrimSpace(keyValue[0]))
+		if err != nil || !property.isPrimitiveProperty() || property.IsParentProperty {
+			return nil, false
+		}
+		naturalKeyFilters = append(naturalKeyFilters, filter{name: property.Name, value: keyValue[1]})
+	}
+	return naturalKeyFilters, true
+}
+
+// resolveImportNaturalKey looks up the resource matching the natural key filters using the resource collection (same matching
+// as the data sources) and sets the ID of the resource found. An error is returned if none or more than one resource match
+func (r resourceFactory) resolveImportNaturalKey(data *schema.ResourceData, providerClient ClientOpenAPI, naturalKeyFilters filters) error {
+	resourceName := r.openAPIResource.GetResourceName()
+	naturalKey := data.Id()
+	if r.openAPIResource.getResourceOperations().List == nil {
+		return fmt.Errorf("[resource='%s'] import by natural key '%s' not supported: the resource does not support listing its instances", resourceName, naturalKey)
+	}
+	parentIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
+	if err != nil {
+		return err
+	}
+	responsePayload := []map[string]interface{}{}
+	resp, err := providerClient.List(r.openAPIResource, &responsePayload, parentIDs...)
+	if err != nil {
+		return err
+	}
+	if err := checkHTTPStatusCode(r.openAPIResource, resp, []int{http.StatusOK}); err != nil {
+		return fmt.Errorf("[resource='%s'] GET %s failed: %s", resourceName, resourcePath, err)
+	}
+	d := newDataSourceFactory(r.openAPIResource)
+	var matches []map[string]interface{}
+	for _, payloadItem := range responsePayload {
+		if d.filterMatch(naturalKeyFilters, payloadItem) {
+			matches = append(matches, payloadItem)
+		}
+	}
+	if len(matches) == 0 {
+		return fmt.Errorf("[resource='%s'] import by natural key '%s' failed: no resource matched the natural key", resourceName, naturalKey)
+	}
+	if len(matches) > 1 {
+		return fmt.Errorf("[resource='%s'] import by natural key '%s' failed: %d resources matched the natural key, please make the natural key more specific or import using the resource ID", resourceName, naturalKey, len(matches))
+	}
+	if err := setStateID(r.openAPIResource, data, matches[0]); err != nil {
+		return err
+	}
+	log.Printf("[INFO] [resource='%s'] natural key '%s' resolved to resource ID '%s'", resourceName, naturalKey, data.Id())
+	return nil
+}
+
 func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]interface{}, resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI, operation *specResourceOperation, responseStatusCode int, timeoutFor string) error {
 	response := operation.responses.getResponse(responseStatusCode)
 
@@ -366,7 +626,9 @@ func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]i
 	}
 
 	// Wait, catching any errors
+	pollingStartTime := time.Now()
 	remoteData, err := stateConf.WaitForState()
+	submitTelemetryPollingWaitMetric(providerClient, TelemetryResourceOperation(timeoutFor), r.openAPIResource.GetResourceName(), time.Since(pollingStartTime))
 	if err != nil {
 		return fmt.Errorf("error waiting for resource to reach a completion status (%s) [valid pending statuses (%s)]: %s", targetStatuses, pendingStatuses, err)
 	}
@@ -382,26 +644,37 @@ func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]i
 }
 
 func (r resourceFactory) resourceStateRefreshFunc(resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI) resource.StateRefreshFunc {
+	attempt := 0
 	return func() (interface{}, string, error) {
+		attempt++
+		span, tracedClient := startClientSpan(providerClient, fmt.Sprintf("poll %s", r.openAPIResource.GetResourceName()))
+		span.setAttribute("terraform.resource_name", r.openAPIResource.GetResourceName())
+		span.setAttribute("terraform.poll.attempt", attempt)
+		remoteData, status, err := r.refreshResourceState(resourceLocalData, tracedClient)
+		span.setAttribute("terraform.poll.status", status)
+		span.end(err)
+		return remoteData, status, err
+	}
+}
 
-		remoteData, err := r.readRemote(resourceLocalData.Id(), providerClient)
-		if err != nil {
-			if openapiErr, ok := err.(openapierr.Error); ok {
-				if openapierr.NotFound == openapiErr.Code() {
-					return 0, defaultDestroyStatus, nil
-				}
+func (r resourceFactory) refreshResourceState(resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI) (interface{}, string, error) {
+	remoteData, err := r.readRemote(resourceLocalData.Id(), providerClient)
+	if err != nil {
+		if openapiErr, ok := err.(openapierr.Error); ok {
+			if openapierr.NotFound == openapiErr.Code() {
+				return 0, defaultDestroyStatus, nil
 			}
-			return nil, "", fmt.Errorf("error on retrieving resource '%s' (%s) when waiting: %s", r.openAPIResource.GetResourceName(), resourceLocalData.Id(), err)
-		}
-
-		newStatus, err := r.getStatusValueFromPayload(remoteData)
-		if err != nil {
-			return nil, "", fmt.Errorf("error occurred while retrieving status identifier value from payload for resource '%s' (%s): %s", r.openAPIResource.GetResourceName(), resourceLocalData.Id(), err)
 		}
+		return nil, "", fmt.Errorf("error on retrieving resource '%s' (%s) when waiting: %s", r.openAPIResource.GetResourceName(), resourceLocalData.Id(), err)
+	}
 
-		log.Printf("[DEBUG] resource status '%s' (%s): %s", r.openAPIResource.GetResourceName(), resourceLocalData.Id(), newStatus)
-		return remoteData, newStatus, nil
+	newStatus, err := r.getStatusValueFromPayload(remoteData)
+	if err != nil {
+		return nil, "", fmt.Errorf("error occurred while retrieving status identifier value from payload for resource '%s' (%s): %s", r.openAPIResource.GetResourceName(), resourceLocalData.Id(), err)
 	}
+
+	log.Printf("[DEBUG] resource status '%s' (%s): %s", r.openAPIResource.GetResourceName(), resourceLocalData.Id(), newStatus)
+	return remoteData, newStatus, nil
 }
 
 func (r resourceFactory) checkImmutableFields(updatedResourceLocalData *schema.ResourceData, openAPIClient ClientOpenAPI, parentIDs ...string) error {
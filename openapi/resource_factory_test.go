@@ -15,6 +15,7 @@ import (
 
 	"encoding/json"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -82,6 +83,86 @@ func TestCreateTerraformResource(t *testing.T) {
 	})
 }
 
+func TestCreateTerraformResourceDeprecated(t *testing.T) {
+	Convey("Given a resource factory initialised with a spec resource that is deprecated", t, func() {
+		r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+		r.openAPIResource.(*specStubResource).deprecationMessage = "use cdns_v2 instead"
+		Convey("When createTerraformResource is called", func() {
+			schemaResource, err := r.createTerraformResource()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the schema resource should be deprecated with the deprecation message", func() {
+				So(schemaResource.DeprecationMessage, ShouldEqual, "use cdns_v2 instead")
+			})
+		})
+	})
+}
+
+func TestCreateTerraformResourceAttributeRelationships(t *testing.T) {
+	Convey("Given a resource factory initialised with a spec resource that has properties with attribute relationships", t, func() {
+		certificateArnProperty := &SpecSchemaDefinitionProperty{Name: "certificate_arn", Type: TypeString, ConflictsWith: []string{"certificate_body"}, AtLeastOneOf: []string{"certificate_arn", "certificate_body"}}
+		certificateBodyProperty := &SpecSchemaDefinitionProperty{Name: "certificate_body", Type: TypeString}
+		r, _ := testCreateResourceFactory(t, idProperty, certificateArnProperty, certificateBodyProperty)
+		Convey("When createTerraformResource is called", func() {
+			schemaResource, err := r.createTerraformResource()
+			Convey("Then the error returned should be nil and the schema resource should be valid", func() {
+				So(err, ShouldBeNil)
+				So(schemaResource.InternalValidate(nil, true), ShouldBeNil)
+			})
+			Convey("And the schema resource validation should fail if conflicting properties are configured", func() {
+				_, errs := schemaResource.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{"certificate_arn": "arn", "certificate_body": "body"}))
+				So(errs, ShouldNotBeEmpty)
+				So(errs[0].Error(), ShouldEqual, "\"certificate_arn\": conflicts with certificate_body")
+			})
+			Convey("And the schema resource diff should fail if none of the at least one of properties is configured", func() {
+				_, err := schemaResource.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{}), nil)
+				So(err.Error(), ShouldEqual, "certificate_arn: one of `certificate_arn,certificate_body` must be specified")
+			})
+		})
+	})
+}
+
+func TestCreateTerraformResourceStateMigrations(t *testing.T) {
+	Convey("Given a resource factory initialised with a spec resource which schema is versioned and has state migrations", t, func() {
+		labelProperty := &SpecSchemaDefinitionProperty{Name: "label", Type: TypeString}
+		r, _ := testCreateResourceFactory(t, idProperty, labelProperty)
+		r.openAPIResource.(*specStubResource).stateMigrations = &specStateMigrations{
+			SchemaVersion: 2,
+			Migrations:    []*specStateMigration{{Version: 1, Action: stateMigrationRename, Attribute: "name", To: "label"}},
+		}
+		Convey("When createTerraformResource is called", func() {
+			schemaResource, err := r.createTerraformResource()
+			Convey("Then the error returned should be nil and the schema resource should be valid", func() {
+				So(err, ShouldBeNil)
+				So(schemaResource.InternalValidate(nil, true), ShouldBeNil)
+			})
+			Convey("And the schema resource should have the schema version and one state upgrader per previous version", func() {
+				So(schemaResource.SchemaVersion, ShouldEqual, 2)
+				So(len(schemaResource.StateUpgraders), ShouldEqual, 2)
+			})
+			Convey("And the state upgrader should migrate the state created with the previous version", func() {
+				rawState, err := schemaResource.StateUpgraders[1].Upgrade(map[string]interface{}{"id": "1234", "name": "some name"}, nil)
+				So(err, ShouldBeNil)
+				So(rawState, ShouldResemble, map[string]interface{}{"id": "1234", "label": "some name"})
+			})
+		})
+	})
+	Convey("Given a resource factory initialised with a spec resource with a state migration that references a non existing attribute", t, func() {
+		r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
+		r.openAPIResource.(*specStubResource).stateMigrations = &specStateMigrations{
+			SchemaVersion: 1,
+			Migrations:    []*specStateMigration{{Version: 0, Action: stateMigrationRename, Attribute: "name", To: "label"}},
+		}
+		Convey("When createTerraformResource is called", func() {
+			_, err := r.createTerraformResource()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "failed to create the state upgrader for version 0: renamed attribute 'label' not found")
+			})
+		})
+	})
+}
+
 func TestCreateTerraformResourceSchema(t *testing.T) {
 	Convey("Given a resource factory", t, func() {
 		r, _ := testCreateResourceFactory(t, idProperty, stringProperty)
@@ -1045,6 +1126,186 @@ func TestImporter(t *testing.T) {
 	})
 }
 
+func TestImporterNaturalKey(t *testing.T) {
+	Convey("Given a resource factory configured with a root resource that supports listing (and the natural key provided by the user as the import ID)", t, func() {
+		importedIDProperty := newStringSchemaDefinitionProperty("id", "", true, true, false, false, false, true, false, false, "string_property=someStringValue")
+		r, resourceData := testCreateResourceFactoryWithID(t, importedIDProperty, stringProperty)
+		r.openAPIResource.(*specStubResource).resourceListOperation = &specResourceOperation{}
+		Convey("When the resourceImporter State method is invoked and the API returns one resource matching the natural key", func() {
+			client := &clientOpenAPIStub{
+				responseListPayload: []map[string]interface{}{
+					{idProperty.Name: "someID", stringProperty.Name: "someStringValue"},
+					{idProperty.Name: "someOtherID", stringProperty.Name: "someOtherStringValue"},
+				},
+				responsePayload: map[string]interface{}{
+					idProperty.Name:     "someID",
+					stringProperty.Name: "someStringValue",
+				},
+			}
+			data, err := r.importer().State(resourceData, client)
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the data returned should contain the ID of the resource matching the natural key", func() {
+				So(len(data), ShouldEqual, 1)
+				So(data[0].Id(), ShouldEqual, "someID")
+			})
+			Convey("And the data returned should contain the values returned by the API when reading the resource", func() {
+				So(data[0].Get(stringProperty.Name), ShouldEqual, "someStringValue")
+				So(client.idReceived, ShouldEqual, "someID")
+			})
+		})
+		Convey("When the resourceImporter State method is invoked and the API returns no resource matching the natural key", func() {
+			client := &clientOpenAPIStub{
+				responseListPayload: []map[string]interface{}{
+					{idProperty.Name: "someOtherID", stringProperty.Name: "someOtherStringValue"},
+				},
+			}
+			data, err := r.importer().State(resourceData, client)
+			Convey("Then the err returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] import by natural key 'string_property=someStringValue' failed: no resource matched the natural key")
+				So(data, ShouldBeNil)
+			})
+		})
+		Convey("When the resourceImporter State method is invoked and the API returns more than one resource matching the natural key", func() {
+			client := &clientOpenAPIStub{
+				responseListPayload: []map[string]interface{}{
+					{idProperty.Name: "someID", stringProperty.Name: "someStringValue"},
+					{idProperty.Name: "someOtherID", stringProperty.Name: "someStringValue"},
+				},
+			}
+			_, err := r.importer().State(resourceData, client)
+			Convey("Then the err returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] import by natural key 'string_property=someStringValue' failed: 2 resources matched the natural key, please make the natural key more specific or import using the resource ID")
+			})
+		})
+		Convey("When the resourceImporter State method is invoked and the API returns a non expected status code", func() {
+			client := &clientOpenAPIStub{returnHTTPCode: http.StatusInternalServerError}
+			_, err := r.importer().State(resourceData, client)
+			Convey("Then the err returned should be the expected one", func() {
+				So(err.Error(), ShouldStartWith, "[resource='resourceName'] GET /v1/resource failed: ")
+			})
+		})
+	})
+
+	Convey("Given a resource factory configured with a root resource that does not support listing (and the natural key provided by the user as the import ID)", t, func() {
+		importedIDProperty := newStringSchemaDefinitionProperty("id", "", true, true, false, false, false, true, false, false, "string_property=someStringValue")
+		r, resourceData := testCreateResourceFactoryWithID(t, importedIDProperty, stringProperty)
+		Convey("When the resourceImporter State method is invoked", func() {
+			_, err := r.importer().State(resourceData, &clientOpenAPIStub{})
+			Convey("Then the err returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] import by natural key 'string_property=someStringValue' not supported: the resource does not support listing its instances")
+			})
+		})
+	})
+
+	Convey("Given a resource factory configured with a sub-resource that supports listing (and the parent ID and natural key provided by the user as the import ID)", t, func() {
+		resourceParentName := "cdns_v1"
+		expectedParentPropertyName := fmt.Sprintf("%s_id", resourceParentName)
+		importedIDProperty := newStringSchemaDefinitionProperty("id", "", true, true, false, false, false, true, false, false, "32/string_property=someStringValue")
+		expectedParentProperty := newStringSchemaDefinitionProperty(expectedParentPropertyName, "", true, true, false, false, false, true, false, false, "")
+		r, resourceData := testCreateSubResourceFactory(t, "/v1/cdns/{id}/firewall", []string{resourceParentName}, "cdns_v1", importedIDProperty, idProperty, stringProperty, expectedParentProperty)
+		r.openAPIResource.(*specStubResource).resourceListOperation = &specResourceOperation{}
+		Convey("When the resourceImporter State method is invoked and the API returns one resource matching the natural key", func() {
+			client := &clientOpenAPIStub{
+				responseListPayload: []map[string]interface{}{
+					{idProperty.Name: "159", stringProperty.Name: "someStringValue"},
+				},
+				responsePayload: map[string]interface{}{
+					idProperty.Name:     "159",
+					stringProperty.Name: "someStringValue",
+				},
+			}
+			data, err := r.importer().State(resourceData, client)
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the data returned should contain the parent ID and the ID of the resource matching the natural key", func() {
+				So(data[0].Get(expectedParentPropertyName), ShouldEqual, "32")
+				So(data[0].Id(), ShouldEqual, "159")
+				So(client.parentIDsReceived, ShouldResemble, []string{"32"})
+			})
+		})
+	})
+}
+
+func TestGetImportNaturalKeyFilters(t *testing.T) {
+	Convey("Given a resource factory", t, func() {
+		intProperty := newIntSchemaDefinitionPropertyWithDefaults("int_property", "", false, false, nil)
+		objectProperty := newObjectSchemaDefinitionPropertyWithDefaults("object_property", "", false, false, false, nil, &SpecSchemaDefinition{})
+		r, _ := testCreateResourceFactory(t, idProperty, stringProperty, intProperty, objectProperty)
+		testCases := []struct {
+			importID        string
+			expectedFilters filters
+			expectedOK      bool
+		}{
+			{importID: "someID", expectedOK: false},
+			{importID: "string_property=someValue", expectedFilters: filters{{name: "string_property", value: "someValue"}}, expectedOK: true},
+			{importID: "string_property=some=Value,int_property=12", expectedFilters: filters{{name: "string_property", value: "some=Value"}, {name: "int_property", value: "12"}}, expectedOK: true},
+			{importID: "string_property=", expectedFilters: filters{{name: "string_property", value: ""}}, expectedOK: true},
+			{importID: "unknown_property=someValue", expectedOK: false},
+			{importID: "object_property=someValue", expectedOK: false},
+			{importID: "c29tZUlE==", expectedOK: false},
+			{importID: "=someValue", expectedOK: false},
+		}
+		Convey("When getImportNaturalKeyFilters is called with different import IDs", func() {
+			Convey("Then the filters returned should be the expected ones", func() {
+				for _, tc := range testCases {
+					naturalKeyFilters, ok := r.getImportNaturalKeyFilters(tc.importID)
+					So(ok, ShouldEqual, tc.expectedOK)
+					So(naturalKeyFilters, ShouldResemble, tc.expectedFilters)
+				}
+			})
+		})
+	})
+}
+
+func TestParseSubResourceImportID(t *testing.T) {
+	Convey("Given a resource factory configured with a sub-resource with two parents", t, func() {
+		r, _ := testCreateSubResourceFactory(t, "/v1/cdns/{cdn_id}/v1/firewalls/{firewall_id}/v1/rules", []string{"cdns_v1", "firewalls_v1"}, "cdns_v1_firewalls_v1", idProperty, idProperty)
+		r.openAPIResource.(*specStubResource).funcGetResourcePath = (&SpecV2Resource{Path: "/v1/cdns/{cdn_id}/v1/firewalls/{firewall_id}/v1/rules"}).getResourcePath
+		parentPropertyNames := []string{"cdns_v1_id", "firewalls_v1_id"}
+		testCases := []struct {
+			name               string
+			importID           string
+			expectedParentIDs  []string
+			expectedInstanceID string
+			expectedErr        string
+		}{
+			{name: "positional format", importID: "abc/def/ghi", expectedParentIDs: []string{"abc", "def"}, expectedInstanceID: "ghi"},
+			{name: "positional format with natural key", importID: "abc/def/name=my-rule", expectedParentIDs: []string{"abc", "def"}, expectedInstanceID: "name=my-rule"},
+			{name: "positional format missing parent IDs", importID: "def/ghi", expectedErr: "can not import a subresource without all the parent ids, expected 2 and got 1 parent IDs"},
+			{name: "keyed format", importID: "cdns_v1_id=abc,firewalls_v1_id=def,id=ghi", expectedParentIDs: []string{"abc", "def"}, expectedInstanceID: "ghi"},
+			{name: "keyed format in any order and with slashes in the instance ID", importID: "id=g/h/i,firewalls_v1_id=def,cdns_v1_id=abc", expectedParentIDs: []string{"abc", "def"}, expectedInstanceID: "g/h/i"},
+			{name: "keyed format with natural key", importID: "cdns_v1_id=abc,firewalls_v1_id=def,name=my-rule,port=80", expectedParentIDs: []string{"abc", "def"}, expectedInstanceID: "name=my-rule,port=80"},
+			{name: "keyed format missing parent", importID: "cdns_v1_id=abc,id=ghi", expectedErr: "can not import a subresource without all the parent ids, missing the parent ID for 'firewalls_v1_id'"},
+			{name: "keyed format missing instance ID", importID: "cdns_v1_id=abc,firewalls_v1_id=def", expectedErr: "can not import a subresource without providing the instance ID ('id' key)"},
+			{name: "keyed format with instance ID and natural key", importID: "cdns_v1_id=abc,firewalls_v1_id=def,id=ghi,name=my-rule", expectedErr: "can not import a subresource providing both the instance ID and a natural key (name=my-rule)"},
+			{name: "URL path format", importID: "/v1/cdns/abc/v1/firewalls/def/v1/rules/ghi", expectedParentIDs: []string{"abc", "def"}, expectedInstanceID: "ghi"},
+			{name: "URL path format with slashes in the instance ID", importID: "/v1/cdns/abc/v1/firewalls/def/v1/rules/g/h/i", expectedParentIDs: []string{"abc", "def"}, expectedInstanceID: "g/h/i"},
+			{name: "URL path format missing parent", importID: "/v1/cdns/abc/v1/firewalls", expectedErr: "import path '/v1/cdns/abc/v1/firewalls' does not match the resource path '/v1/cdns/:cdns_v1_id/v1/firewalls/:firewalls_v1_id/v1/rules': missing the parent ID for 'firewalls_v1_id'"},
+			{name: "URL path format missing instance ID", importID: "/v1/cdns/abc/v1/firewalls/def/v1/rules/", expectedErr: "import path '/v1/cdns/abc/v1/firewalls/def/v1/rules/' does not contain the instance ID after the resource path '/v1/cdns/:cdns_v1_id/v1/firewalls/:firewalls_v1_id/v1/rules'"},
+			{name: "URL path format not matching the resource path", importID: "/v1/cdns/abc/v2/firewalls/def/v1/rules/ghi", expectedErr: "import path '/v1/cdns/abc/v2/firewalls/def/v1/rules/ghi' does not match the resource path '/v1/cdns/:cdns_v1_id/v1/firewalls/:firewalls_v1_id/v1/rules': expected path segment 'v1' but got 'v2'"},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When parseSubResourceImportID is called with the %s: %s", tc.name, tc.importID), func() {
+				parentIDs, instanceID, err := r.parseSubResourceImportID(tc.importID, parentPropertyNames)
+				if tc.expectedErr != "" {
+					Convey("Then the error returned should be the expected one", func() {
+						So(err.Error(), ShouldEqual, tc.expectedErr)
+					})
+					return
+				}
+				Convey("Then the parent IDs and instance ID returned should be the expected ones", func() {
+					So(err, ShouldBeNil)
+					So(parentIDs, ShouldResemble, tc.expectedParentIDs)
+					So(instanceID, ShouldEqual, tc.expectedInstanceID)
+				})
+			})
+		}
+	})
+}
+
 func TestHandlePollingIfConfigured(t *testing.T) {
 	Convey("Given a resource factory configured with a resource which has a schema definition containing a status property", t, func() {
 		r, resourceData := testCreateResourceFactoryWithID(t, idProperty, stringProperty, statusProperty)
@@ -2494,3 +2755,93 @@ func testCreateSubResourceFactory(t *testing.T, path string, parentResourceNames
 	specResource.fullParentResourceName = fullParentResourceName
 	return newResourceFactory(specResource), resourceData
 }
+
+func TestCreateSubmitsOperationTelemetry(t *testing.T) {
+	Convey("Given a resource factory", t, func() {
+		r, resourceData := testCreateResourceFactory(t, idProperty, stringProperty)
+		var outcomeReceived TelemetryResourceOperationOutcome
+		var durationTFOperationReceived TelemetryResourceOperation
+		var statusCodeReceived int
+		telemetryHandler := &telemetryHandlerStub{
+			submitResourceOperationDurationMetricsFunc: func(resourceName string, tfOperation TelemetryResourceOperation, outcome TelemetryResourceOperationOutcome, duration time.Duration) {
+				durationTFOperationReceived = tfOperation
+				outcomeReceived = outcome
+			},
+			submitResourceHTTPStatusMetricsFunc: func(resourceName string, tfOperation TelemetryResourceOperation, statusCode int) {
+				statusCodeReceived = statusCode
+			},
+		}
+		Convey("When create is called with a client that returns a successful response", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name: "someID",
+				},
+				telemetryHandler: telemetryHandler,
+			}
+			err := r.create(resourceData, client)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the operation duration should have been submitted with a success outcome", func() {
+				So(durationTFOperationReceived, ShouldEqual, TelemetryResourceOperationCreate)
+				So(outcomeReceived, ShouldEqual, TelemetryResourceOperationOutcomeSuccess)
+			})
+			Convey("And the HTTP status code returned by the API should have been submitted", func() {
+				So(statusCodeReceived, ShouldEqual, http.StatusCreated)
+			})
+		})
+		Convey("When create is called with a client that returns a non expected http code", func() {
+			client := &clientOpenAPIStub{
+				responsePayload:  map[string]interface{}{},
+				returnHTTPCode:   http.StatusInternalServerError,
+				telemetryHandler: telemetryHandler,
+			}
+			err := r.create(resourceData, client)
+			Convey("Then the error returned should NOT be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+			Convey("And the operation duration should have been submitted with a failure outcome", func() {
+				So(durationTFOperationReceived, ShouldEqual, TelemetryResourceOperationCreate)
+				So(outcomeReceived, ShouldEqual, TelemetryResourceOperationOutcomeFailure)
+			})
+			Convey("And the HTTP status code returned by the API should have been submitted", func() {
+				So(statusCodeReceived, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func TestReadSubmitsHTTPStatusTelemetryOnlyForReadOperations(t *testing.T) {
+	Convey("Given a resource factory configured with a resource which has a schema definition containing a status property", t, func() {
+		r, resourceData := testCreateResourceFactoryWithID(t, idProperty, stringProperty, statusProperty)
+		var tfOperationsReceived []TelemetryResourceOperation
+		client := &clientOpenAPIStub{
+			responsePayload: map[string]interface{}{
+				idProperty.Name:     idProperty.Default,
+				stringProperty.Name: stringProperty.Default,
+				statusProperty.Name: statusProperty.Default,
+			},
+			telemetryHandler: &telemetryHandlerStub{
+				submitResourceHTTPStatusMetricsFunc: func(resourceName string, tfOperation TelemetryResourceOperation, statusCode int) {
+					tfOperationsReceived = append(tfOperationsReceived, tfOperation)
+				},
+			},
+		}
+		Convey("When read is called", func() {
+			err := r.read(resourceData, client)
+			Convey("Then the HTTP status metric should have been submitted for the read operation", func() {
+				So(err, ShouldBeNil)
+				So(tfOperationsReceived, ShouldResemble, []TelemetryResourceOperation{TelemetryResourceOperationRead})
+			})
+		})
+		Convey("When the polling state refresh function and the immutable fields check perform GET requests", func() {
+			_, _, err := r.resourceStateRefreshFunc(resourceData, client)()
+			So(err, ShouldBeNil)
+			err = r.checkImmutableFields(resourceData, client)
+			Convey("Then no read HTTP status metric should have been submitted", func() {
+				So(err, ShouldBeNil)
+				So(tfOperationsReceived, ShouldBeEmpty)
+			})
+		})
+	})
+}
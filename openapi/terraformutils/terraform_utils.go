@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
+	"unicode"
 
 	"github.com/iancoleman/strcase"
 
@@ -96,6 +97,39 @@ func ConvertToTerraformCompliantName(name string) string {
 	return compliantName
 }
 
+// QuoteHCLString returns the value as an HCL quoted string. Only the escape sequences supported by HCL are used: \n, \r,
+// \t, \" and \\, and \uNNNN (or \UNNNNNNNN) for the rest of non printable characters. The template sequences (${ and %{)
+// are escaped too so the value is taken literally
+func QuoteHCLString(value string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for idx, r := range value {
+		switch {
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\\':
+			b.WriteString(`\\`)
+		case (r == '$' || r == '%') && strings.HasPrefix(value[idx+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case r > 0xFFFF && !unicode.IsPrint(r):
+			fmt.Fprintf(&b, `\U%08X`, r)
+		case r != ' ' && !unicode.IsPrint(r):
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
 // createSchema creates a terraform schema configured based upon the parameters passed in
 func createSchema(propertyName string, schemaType schema.ValueType, required bool, defaultValue string) *schema.Schema {
 	s := &schema.Schema{
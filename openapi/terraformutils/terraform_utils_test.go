@@ -125,6 +125,32 @@ func TestConvertToTerraformCompliantFieldName(t *testing.T) {
 	}
 }
 
+func TestQuoteHCLString(t *testing.T) {
+	testCases := []struct {
+		name          string
+		value         string
+		expectedValue string
+	}{
+		{name: "plain string", value: "some value", expectedValue: `"some value"`},
+		{name: "string with quotes and backslashes", value: `some "quoted" C:\path`, expectedValue: `"some \"quoted\" C:\\path"`},
+		{name: "string with new lines and tabs", value: "line1\r\nline2\tend", expectedValue: `"line1\r\nline2\tend"`},
+		{name: "string with template sequences", value: "some ${value} and %{directive} but not $ or %", expectedValue: `"some $${value} and %%{directive} but not $ or %"`},
+		{name: "string with control characters that Go escapes differently", value: "\x1b[0m\a\v\x00", expectedValue: `"\u001B[0m\u0007\u000B\u0000"`},
+		{name: "string with printable unicode characters", value: "café ☕", expectedValue: `"café ☕"`},
+		{name: "string with non printable unicode characters", value: "a\u2028b\U000E0001", expectedValue: `"a\u2028b\U000E0001"`},
+	}
+	for _, tc := range testCases {
+		Convey("Given a "+tc.name, t, func() {
+			Convey("When QuoteHCLString is called", func() {
+				quoted := QuoteHCLString(tc.value)
+				Convey("Then the HCL string returned should be the expected one", func() {
+					So(quoted, ShouldEqual, tc.expectedValue)
+				})
+			})
+		})
+	}
+}
+
 func TestCreateSchema(t *testing.T) {
 	Convey("Given an environment variable, schemaType of type string, required property and an empty default value", t, func() {
 		propertyName := "propertyName"
@@ -1,32 +1,257 @@
 package main
 
 import (
-	"github.com/dikhan/terraform-provider-openapi/pkg/terraformdocsgenerator/openapiterraformdocsgenerator"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/dikhan/terraform-provider-openapi/pkg/terraformdocsgenerator/openapiterraformdocsgenerator"
 )
 
+const (
+	formatHTML     = "html"
+	formatMarkdown = "markdown"
+	formatJSON     = "json"
+)
+
+// htmlTemplateFiles contains the names of the files that can be provided in the template directory to override the default HTML
+// templates (table of contents, provider installation, provider configuration, provider resources, data sources and special terms)
+var htmlTemplateFiles = []string{"table_of_contents.html.tmpl", "provider_installation.html.tmpl", "provider_configuration.html.tmpl", "provider_resources.html.tmpl", "data_sources.html.tmpl", "special_terms.html.tmpl"}
+
+// markdownTemplateFiles contains the names of the files that can be provided in the template directory to override the default
+// markdown templates (index, resource, data source instance and data source)
+var markdownTemplateFiles = []string{"index.md.tmpl", "resource.md.tmpl", "data_source_instance.md.tmpl", "data_source.md.tmpl"}
+
+// options contains the command line arguments
+type options struct {
+	providerName string
+	spec         string
+	format       string
+	outputDir    string
+	templateDir  string
+	check        bool
+}
+
 func main() {
-	providerName := "openapi"
-	openAPIDocURL := "https://raw.githubusercontent.com/dikhan/terraform-provider-openapi/master/examples/swaggercodegen/api/resources/swagger.yaml"
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parseOptions(args []string, output io.Writer) (options, error) {
+	opts := options{}
+	flags := flag.NewFlagSet("terraformdocsgenerator", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.StringVar(&opts.providerName, "provider-name", "", "name of the Terraform provider (e,g: openapi) (required)")
+	flags.StringVar(&opts.spec, "spec", "", "URL or path to the file of the OpenAPI document (required)")
+	flags.StringVar(&opts.format, "format", formatHTML, "output format of the documentation: html, markdown or json")
+	flags.StringVar(&opts.outputDir, "output-dir", ".", "directory where the documentation will be written. When the format is markdown this is expected to be the provider's docs folder")
+	flags.StringVar(&opts.templateDir, "template-dir", "", "directory containing custom templates overriding the default ones (only html and markdown formats)")
+	flags.BoolVar(&opts.check, "check", false, "do not write the documentation; fail if the documentation generated differs from the one in the output directory")
+	if err := flags.Parse(args); err != nil {
+		return opts, err
+	}
+	if opts.providerName == "" {
+		return opts, errors.New("missing required flag -provider-name")
+	}
+	if opts.spec == "" {
+		return opts, errors.New("missing required flag -spec")
+	}
+	switch opts.format {
+	case formatHTML, formatMarkdown, formatJSON:
+	default:
+		return opts, fmt.Errorf("format '%s' not supported, please choose one of the following formats: %s, %s, %s", opts.format, formatHTML, formatMarkdown, formatJSON)
+	}
+	if opts.templateDir != "" && opts.format == formatJSON {
+		return opts, fmt.Errorf("custom templates are not supported with the '%s' format", formatJSON)
+	}
+	return opts, nil
+}
 
-	terraformProviderDocGenerator, err := openapiterraformdocsgenerator.NewTerraformProviderDocGenerator(providerName, openAPIDocURL)
+func run(args []string, output io.Writer) error {
+	opts, err := parseOptions(args, output)
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+	terraformProviderDocGenerator, err := openapiterraformdocsgenerator.NewTerraformProviderDocGenerator(opts.providerName, opts.spec)
+	if err != nil {
+		return err
+	}
+	d, err := terraformProviderDocGenerator.GenerateDocumentation()
+	if err != nil {
+		return err
+	}
+	if !opts.check {
+		if err := renderDocumentation(d, opts, opts.outputDir); err != nil {
+			return err
+		}
+		fmt.Fprintf(output, "%s documentation for '%s' provider written to %s\n", opts.format, opts.providerName, opts.outputDir)
+		return nil
 	}
 
-	f, err := os.Create("./example_provider_documentation_output.html")
+	generatedDir, err := ioutil.TempDir("", "terraformdocsgenerator")
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+	defer os.RemoveAll(generatedDir)
+	if err := renderDocumentation(d, opts, generatedDir); err != nil {
+		return err
+	}
+	differences, err := compareDocumentation(generatedDir, opts.outputDir)
+	if err != nil {
+		return err
 	}
+	if len(differences) > 0 {
+		for _, difference := range differences {
+			fmt.Fprintln(output, difference)
+		}
+		return fmt.Errorf("documentation in '%s' is out of date, please re-generate the documentation", opts.outputDir)
+	}
+	fmt.Fprintf(output, "%s documentation for '%s' provider in %s is up to date\n", opts.format, opts.providerName, opts.outputDir)
+	return nil
+}
 
-	d, err := terraformProviderDocGenerator.GenerateDocumentation()
+func renderDocumentation(d openapiterraformdocsgenerator.TerraformProviderDocumentation, opts options, outputDir string) error {
+	switch opts.format {
+	case formatMarkdown:
+		templates, err := loadMarkdownTemplates(opts.templateDir)
+		if err != nil {
+			return err
+		}
+		return d.RenderMarkdownWithTemplates(outputDir, templates)
+	case formatJSON:
+		return renderToFile(filepath.Join(outputDir, getOutputFileName(opts)), d.RenderJSON)
+	default:
+		templates, err := loadHTMLTemplates(opts.templateDir)
+		if err != nil {
+			return err
+		}
+		return renderToFile(filepath.Join(outputDir, getOutputFileName(opts)), func(w io.Writer) error {
+			return d.RenderHTMLWithTemplates(w, templates)
+		})
+	}
+}
+
+// getOutputFileName returns the name of the file the documentation is written to for the formats that render one single file (html and json)
+func getOutputFileName(opts options) string {
+	return fmt.Sprintf("%s_provider_documentation.%s", opts.providerName, opts.format)
+}
+
+func renderToFile(filePath string, renderFunc func(w io.Writer) error) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(filePath)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
+	defer f.Close()
+	return renderFunc(f)
+}
+
+func loadHTMLTemplates(templateDir string) (openapiterraformdocsgenerator.HTMLTemplates, error) {
+	templates := openapiterraformdocsgenerator.DefaultHTMLTemplates()
+	overrides := []*string{&templates.TableOfContents, &templates.ProviderInstallation, &templates.ProviderConfiguration, &templates.ProviderResources, &templates.DataSources, &templates.SpecialTerms}
+	err := loadTemplates(templateDir, htmlTemplateFiles, overrides, "")
+	return templates, err
+}
 
-	err = d.RenderHTML(f)
+func loadMarkdownTemplates(templateDir string) (openapiterraformdocsgenerator.MarkdownTemplates, error) {
+	templates := openapiterraformdocsgenerator.DefaultMarkdownTemplates()
+	overrides := []*string{&templates.Index, &templates.Resource, &templates.DataSourceInstance, &templates.DataSource}
+	// the shared definitions are made available so custom templates can reuse them (e,g: {{template "markdown_property" .}})
+	definitions := openapiterraformdocsgenerator.MarkdownPropertyTmpl + openapiterraformdocsgenerator.MarkdownNestedSchemasTmpl + openapiterraformdocsgenerator.MarkdownObjectPropertiesNoteTmpl
+	err := loadTemplates(templateDir, markdownTemplateFiles, overrides, definitions)
+	return templates, err
+}
+
+// loadTemplates overrides the templates with the content of the template files found in the template directory. Templates
+// whose file does not exist in the template directory keep their default value
+func loadTemplates(templateDir string, fileNames []string, templates []*string, definitions string) error {
+	if templateDir == "" {
+		return nil
+	}
+	if _, err := os.Stat(templateDir); err != nil {
+		return fmt.Errorf("template directory '%s' not valid: %s", templateDir, err)
+	}
+	for idx, fileName := range fileNames {
+		content, err := ioutil.ReadFile(filepath.Join(templateDir, fileName))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read template file '%s': %s", fileName, err)
+		}
+		log.Printf("[INFO] using custom template %s", fileName)
+		*templates[idx] = definitions + string(content)
+	}
+	return nil
+}
+
+// compareDocumentation compares the documentation generated with the one in the output directory and returns the list
+// of differences found: files that are missing or out of date, and files in the generated sub-directories (e,g: resources)
+// that are no longer generated
+func compareDocumentation(generatedDir, outputDir string) ([]string, error) {
+	var differences []string
+	generatedFiles := map[string]bool{}
+	generatedSubDirs := map[string]bool{}
+	err := filepath.Walk(generatedDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(generatedDir, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if relativePath != "." {
+				generatedSubDirs[relativePath] = true
+			}
+			return nil
+		}
+		generatedFiles[relativePath] = true
+		generated, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		existing, err := ioutil.ReadFile(filepath.Join(outputDir, relativePath))
+		if os.IsNotExist(err) {
+			differences = append(differences, fmt.Sprintf("missing: %s", relativePath))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(generated, existing) {
+			differences = append(differences, fmt.Sprintf("out of date: %s", relativePath))
+		}
+		return nil
+	})
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
+	}
+	for subDir := range generatedSubDirs {
+		files, err := ioutil.ReadDir(filepath.Join(outputDir, subDir))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			relativePath := filepath.Join(subDir, f.Name())
+			if !f.IsDir() && !generatedFiles[relativePath] {
+				differences = append(differences, fmt.Sprintf("no longer generated: %s", relativePath))
+			}
+		}
 	}
+	sort.Strings(differences)
+	return differences, nil
 }
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSwagger = `swagger: "2.0"
+host: "localhost:8443"
+schemes:
+  - "https"
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+    delete:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        204:
+          description: "successful operation, no content is returned"
+definitions:
+  ContentDeliveryNetworkV1:
+    type: "object"
+    required:
+      - label
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"
+        description: "The CDN label"`
+
+func writeTestSwagger(t *testing.T, dir string) string {
+	swaggerFile := filepath.Join(dir, "swagger.yaml")
+	require.NoError(t, ioutil.WriteFile(swaggerFile, []byte(testSwagger), 0644))
+	return swaggerFile
+}
+
+func TestParseOptions(t *testing.T) {
+	testCases := []struct {
+		name            string
+		args            []string
+		expectedOptions options
+		expectedErr     string
+	}{
+		{
+			name:            "default values",
+			args:            []string{"-provider-name", "openapi", "-spec", "swagger.yaml"},
+			expectedOptions: options{providerName: "openapi", spec: "swagger.yaml", format: "html", outputDir: "."},
+		},
+		{
+			name:            "all values populated",
+			args:            []string{"-provider-name", "openapi", "-spec", "swagger.yaml", "-format", "markdown", "-output-dir", "docs", "-template-dir", "templates", "-check"},
+			expectedOptions: options{providerName: "openapi", spec: "swagger.yaml", format: "markdown", outputDir: "docs", templateDir: "templates", check: true},
+		},
+		{
+			name:        "missing provider name",
+			args:        []string{"-spec", "swagger.yaml"},
+			expectedErr: "missing required flag -provider-name",
+		},
+		{
+			name:        "missing spec",
+			args:        []string{"-provider-name", "openapi"},
+			expectedErr: "missing required flag -spec",
+		},
+		{
+			name:        "format not supported",
+			args:        []string{"-provider-name", "openapi", "-spec", "swagger.yaml", "-format", "pdf"},
+			expectedErr: "format 'pdf' not supported, please choose one of the following formats: html, markdown, json",
+		},
+		{
+			name:        "templates not supported in json format",
+			args:        []string{"-provider-name", "openapi", "-spec", "swagger.yaml", "-format", "json", "-template-dir", "templates"},
+			expectedErr: "custom templates are not supported with the 'json' format",
+		},
+	}
+	for _, tc := range testCases {
+		opts, err := parseOptions(tc.args, ioutil.Discard)
+		if tc.expectedErr != "" {
+			assert.EqualError(t, err, tc.expectedErr, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedOptions, opts, tc.name)
+	}
+}
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		format        string
+		expectedFiles []string
+	}{
+		{format: "html", expectedFiles: []string{"openapi_provider_documentation.html"}},
+		{format: "json", expectedFiles: []string{"openapi_provider_documentation.json"}},
+		{format: "markdown", expectedFiles: []string{"index.md", "resources/cdns_v1.md", "data-sources/cdns_v1_instance.md"}},
+	}
+	for _, tc := range testCases {
+		dir, err := ioutil.TempDir("", "docs")
+		require.NoError(t, err)
+		outputDir := filepath.Join(dir, "docs")
+		var output bytes.Buffer
+		err = run([]string{"-provider-name", "openapi", "-spec", writeTestSwagger(t, dir), "-format", tc.format, "-output-dir", outputDir}, &output)
+		assert.NoError(t, err, tc.format)
+		for _, expectedFile := range tc.expectedFiles {
+			assert.FileExists(t, filepath.Join(outputDir, expectedFile), tc.format)
+		}
+		os.RemoveAll(dir)
+	}
+}
+
+func TestRun_CustomTemplates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "docs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	templateDir := filepath.Join(dir, "templates")
+	require.NoError(t, os.Mkdir(templateDir, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(templateDir, "resource.md.tmpl"), []byte("# Custom {{.Name}}\n{{range .Arguments}}{{template \"markdown_property\" .}}\n{{end}}"), 0644))
+	outputDir := filepath.Join(dir, "docs")
+
+	err = run([]string{"-provider-name", "openapi", "-spec", writeTestSwagger(t, dir), "-format", "markdown", "-output-dir", outputDir, "-template-dir", templateDir}, ioutil.Discard)
+	assert.NoError(t, err)
+	content, err := ioutil.ReadFile(filepath.Join(outputDir, "resources", "cdns_v1.md"))
+	assert.NoError(t, err)
+	assert.Equal(t, "# Custom cdns_v1\n- `label` (String, Required) The CDN label\n", string(content))
+	// pages without custom template use the default templates
+	content, err = ioutil.ReadFile(filepath.Join(outputDir, "index.md"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "# openapi Provider")
+}
+
+func TestRun_Check(t *testing.T) {
+	dir, err := ioutil.TempDir("", "docs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	swaggerFile := writeTestSwagger(t, dir)
+	outputDir := filepath.Join(dir, "docs")
+	args := []string{"-provider-name", "openapi", "-spec", swaggerFile, "-format", "markdown", "-output-dir", outputDir}
+
+	// docs not generated yet
+	var output bytes.Buffer
+	err = run(append(args, "-check"), &output)
+	assert.EqualError(t, err, "documentation in '"+outputDir+"' is out of date, please re-generate the documentation")
+	assert.Contains(t, output.String(), "missing: index.md")
+
+	// docs up to date
+	require.NoError(t, run(args, ioutil.Discard))
+	output.Reset()
+	err = run(append(args, "-check"), &output)
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), "is up to date")
+	_, err = os.Stat(filepath.Join(outputDir, "resources"))
+	assert.NoError(t, err)
+
+	// docs modified and stale docs present
+	require.NoError(t, ioutil.WriteFile(filepath.Join(outputDir, "resources", "cdns_v1.md"), []byte("modified"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(outputDir, "resources", "removed_v1.md"), []byte("stale"), 0644))
+	output.Reset()
+	err = run(append(args, "-check"), &output)
+	assert.Error(t, err)
+	assert.Equal(t, "no longer generated: resources/removed_v1.md\nout of date: resources/cdns_v1.md\n", output.String())
+}
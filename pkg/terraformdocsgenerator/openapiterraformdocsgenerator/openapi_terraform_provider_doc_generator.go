@@ -130,9 +130,14 @@ func (t TerraformProviderDocGenerator) getDataSourceFilters(dataSourcesFilter []
 			prop := t.resourceSchemaToProperty(*p)
 			props = append(props, prop)
 		}
+		example, err := buildDataSourceExample(t.ProviderName, dataSource.GetResourceName(), dataSourceSchemaDefinition, getParentProperties(dataSource))
+		if err != nil {
+			return nil, err
+		}
 		dataSources = append(dataSources, DataSource{
-			Name:       dataSource.GetResourceName(),
-			Properties: orderProps(props),
+			Name:         dataSource.GetResourceName(),
+			Properties:   orderProps(props),
+			ExampleUsage: []ExampleUsage{{Example: example}},
 		})
 	}
 	return dataSources, nil
@@ -151,9 +156,15 @@ func (t TerraformProviderDocGenerator) getDataSourceInstances(dataSourceInstance
 			prop := t.resourceSchemaToProperty(*p)
 			props = append(props, prop)
 		}
+		dataSourceName := fmt.Sprintf("%s_instance", dataSource.GetResourceName())
+		example, err := buildDataSourceInstanceExample(t.ProviderName, dataSourceName, dataSourceSchemaDefinition, getParentProperties(dataSource))
+		if err != nil {
+			return nil, err
+		}
 		dataSourcesInstance = append(dataSourcesInstance, DataSource{
-			Name:       fmt.Sprintf("%s_instance", dataSource.GetResourceName()),
-			Properties: orderProps(props),
+			Name:         dataSourceName,
+			Properties:   orderProps(props),
+			ExampleUsage: []ExampleUsage{{Example: example}},
 		})
 	}
 	return dataSourcesInstance, nil
@@ -184,10 +195,10 @@ func (t TerraformProviderDocGenerator) getProviderResources(resources []openapi.
 		props = append(props, orderProps(requiredProps)...)
 		props = append(props, orderProps(optionalProps)...)
 
-		parentInfo := resource.GetParentResourceInfo()
-		var parentProperties []string
-		if parentInfo != nil {
-			parentProperties = parentInfo.GetParentPropertiesNames()
+		parentProperties := getParentProperties(resource)
+		example, err := buildResourceExample(t.ProviderName, resource.GetResourceName(), resourceSchema, parentProperties)
+		if err != nil {
+			return nil, err
 		}
 
 		r = append(r, Resource{
@@ -195,14 +206,24 @@ func (t TerraformProviderDocGenerator) getProviderResources(resources []openapi.
 			Description:      "",
 			Properties:       props,
 			ParentProperties: parentProperties,
+			ExampleUsage:     []ExampleUsage{{Example: example}},
 			ArgumentsReference: ArgumentsReference{
 				Notes: []string{},
 			},
+			DeprecationMessage: resource.GetDeprecationMessage(),
 		})
 	}
 	return r, nil
 }
 
+func getParentProperties(resource openapi.SpecResource) []string {
+	parentInfo := resource.GetParentResourceInfo()
+	if parentInfo != nil {
+		return parentInfo.GetParentPropertiesNames()
+	}
+	return nil
+}
+
 func (t TerraformProviderDocGenerator) resourceSchemaToProperty(specSchemaDefinitionProperty openapi.SpecSchemaDefinitionProperty) Property {
 	var schema []Property
 	if specSchemaDefinitionProperty.Type == openapi.TypeObject || specSchemaDefinitionProperty.ArrayItemsType == openapi.TypeObject {
@@ -222,10 +243,24 @@ func (t TerraformProviderDocGenerator) resourceSchemaToProperty(specSchemaDefini
 		IsSensitive:        specSchemaDefinitionProperty.Sensitive,
 		IsParent:           specSchemaDefinitionProperty.IsParentProperty,
 		Description:        specSchemaDefinitionProperty.Description,
+		Default:            getPropertyDefault(specSchemaDefinitionProperty),
+		Deprecated:         specSchemaDefinitionProperty.GetDeprecationMessage(),
 		Schema:             orderProps(schema),
 	}
 }
 
+// getPropertyDefault returns the formatted default value of the property if the default value is used as the terraform
+// default value; empty string is returned if the property does not have a default value or the value is computed by the API
+func getPropertyDefault(specSchemaDefinitionProperty openapi.SpecSchemaDefinitionProperty) string {
+	if specSchemaDefinitionProperty.Default == nil || specSchemaDefinitionProperty.Computed || specSchemaDefinitionProperty.IsRequired() {
+		return ""
+	}
+	if value, ok := specSchemaDefinitionProperty.Default.(string); ok {
+		return fmt.Sprintf("%q", value)
+	}
+	return fmt.Sprintf("%v", specSchemaDefinitionProperty.Default)
+}
+
 func (t TerraformProviderDocGenerator) getRequiredProviderConfigurationProperties(regions []string, globalSecuritySchemes openapi.SpecSecuritySchemes, securityDefinitions *openapi.SpecSecurityDefinitions, headers openapi.SpecHeaderParameters) ([]string, []Property) {
 	var configProps []Property
 	if securityDefinitions != nil {
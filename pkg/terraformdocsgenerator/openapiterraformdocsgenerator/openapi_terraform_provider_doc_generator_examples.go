@@ -0,0 +1,223 @@
+package openapiterraformdocsgenerator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi"
+	"github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/hashicorp/hcl2/hclwrite"
+)
+
+// buildResourceExample synthesises the HCL example usage of a resource given its schema definition. Required properties
+// are always included in the example whereas optional properties are only included if the spec provides an example value
+// for them. Parent properties (sub-resources) reference the parent resources
+func buildResourceExample(providerName, resourceName string, schemaDefinition *openapi.SpecSchemaDefinition, parentProperties []string) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "resource %q %q {\n", providerName+"_"+resourceName, "my_"+resourceName)
+	writeExampleParentProperties(&b, providerName, parentProperties)
+	writeExampleProperties(&b, schemaDefinition, nil)
+	b.WriteString("}\n")
+	return formatExample(b.String())
+}
+
+// buildDataSourceInstanceExample synthesises the HCL example usage of a data source instance (data source that retrieves
+// a resource using its id)
+func buildDataSourceInstanceExample(providerName, dataSourceName string, schemaDefinition *openapi.SpecSchemaDefinition, parentProperties []string) (string, error) {
+	id := "existing_resource_id"
+	for _, p := range schemaDefinition.Properties {
+		if p.IsIdentifier || p.GetTerraformCompliantPropertyName() == "id" {
+			if example, ok := p.Example.(string); ok && example != "" {
+				id = example
+			}
+		}
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "data %q %q {\n", providerName+"_"+dataSourceName, "my_"+dataSourceName)
+	writeExampleParentProperties(&b, providerName, parentProperties)
+	fmt.Fprintf(&b, "id = %s\n", terraformutils.QuoteHCLString(id))
+	b.WriteString("}\n")
+	return formatExample(b.String())
+}
+
+// buildDataSourceExample synthesises the HCL example usage of a data source that retrieves a resource using filters. The
+// filter uses a property with an example value if any, otherwise the first primitive property is used
+func buildDataSourceExample(providerName, dataSourceName string, schemaDefinition *openapi.SpecSchemaDefinition, parentProperties []string) (string, error) {
+	var filterProperty *openapi.SpecSchemaDefinitionProperty
+	for _, p := range sortExampleProperties(schemaDefinition.Properties) {
+		if p.IsParentProperty || p.Sensitive || !isPrimitiveExampleType(string(p.Type)) {
+			continue
+		}
+		if filterProperty == nil || (filterProperty.Example == nil && p.Example != nil) {
+			filterProperty = p
+		}
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "data %q %q {\n", providerName+"_"+dataSourceName, "my_"+dataSourceName)
+	writeExampleParentProperties(&b, providerName, parentProperties)
+	if filterProperty != nil {
+		value := getExamplePrimitiveValue(filterProperty.GetTerraformCompliantPropertyName(), string(filterProperty.Type), filterProperty.Example)
+		// filter values are always strings
+		if !strings.HasPrefix(value, `"`) {
+			value = terraformutils.QuoteHCLString(value)
+		}
+		b.WriteString("filter {\n")
+		fmt.Fprintf(&b, "name = %s\n", terraformutils.QuoteHCLString(filterProperty.GetTerraformCompliantPropertyName()))
+		fmt.Fprintf(&b, "values = [%s]\n", value)
+		b.WriteString("}\n")
+	}
+	b.WriteString("}\n")
+	return formatExample(b.String())
+}
+
+// writeExampleParentProperties writes the parent properties referencing the id of the parent resources. Parent properties
+// are named after the parent resources (e,g: cdns_v1_id) and each parent resource's name is prefixed by its own parents
+// names (e,g: cdns_v1_firewalls_v1)
+func writeExampleParentProperties(b *strings.Builder, providerName string, parentProperties []string) {
+	var parentResourceNames []string
+	for _, parentProperty := range parentProperties {
+		parentResourceNames = append(parentResourceNames, strings.TrimSuffix(parentProperty, "_id"))
+		parentResourceName := strings.Join(parentResourceNames, "_")
+		fmt.Fprintf(b, "%s = %s_%s.my_%s.id\n", parentProperty, providerName, parentResourceName, parentResourceName)
+	}
+}
+
+// writeExampleProperties writes the properties that should be part of the example. The example values provided (e,g:
+// object example from the parent property) are used if the property itself does not have an example
+func writeExampleProperties(b *strings.Builder, schemaDefinition *openapi.SpecSchemaDefinition, examples map[string]interface{}) {
+	if schemaDefinition == nil {
+		return
+	}
+	for _, p := range sortExampleProperties(schemaDefinition.Properties) {
+		if p.IsParentProperty || p.ReadOnly {
+			continue
+		}
+		example := p.Example
+		if example == nil && examples != nil {
+			example = examples[p.Name]
+		}
+		if !p.Required && example == nil {
+			continue
+		}
+		name := p.GetTerraformCompliantPropertyName()
+		switch {
+		case p.Type == openapi.TypeObject:
+			writeExampleBlock(b, name, p.SpecSchemaDefinition, example)
+		case p.Type == openapi.TypeList && p.ArrayItemsType == openapi.TypeObject:
+			items, ok := example.([]interface{})
+			if !ok || len(items) == 0 {
+				items = []interface{}{nil}
+			}
+			for _, item := range items {
+				writeExampleBlock(b, name, p.SpecSchemaDefinition, item)
+			}
+		case p.Type == openapi.TypeList:
+			fmt.Fprintf(b, "%s = %s\n", name, getExampleListValue(name, string(p.ArrayItemsType), example))
+		default:
+			fmt.Fprintf(b, "%s = %s\n", name, getExamplePrimitiveValue(name, string(p.Type), example))
+		}
+	}
+}
+
+func writeExampleBlock(b *strings.Builder, name string, schemaDefinition *openapi.SpecSchemaDefinition, example interface{}) {
+	examples, _ := example.(map[string]interface{})
+	fmt.Fprintf(b, "%s {\n", name)
+	writeExampleProperties(b, schemaDefinition, examples)
+	b.WriteString("}\n")
+}
+
+// sortExampleProperties returns the properties sorted so the examples are deterministic: required properties first and
+// then sorted by name
+func sortExampleProperties(properties openapi.SpecSchemaDefinitionProperties) openapi.SpecSchemaDefinitionProperties {
+	sorted := make(openapi.SpecSchemaDefinitionProperties, len(properties))
+	copy(sorted, properties)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Required != sorted[j].Required {
+			return sorted[i].Required
+		}
+		return sorted[i].GetTerraformCompliantPropertyName() < sorted[j].GetTerraformCompliantPropertyName()
+	})
+	return sorted
+}
+
+func getExampleListValue(name string, itemsType string, example interface{}) string {
+	var values []string
+	if items, ok := example.([]interface{}); ok {
+		for _, item := range items {
+			values = append(values, getExamplePrimitiveValue(name, itemsType, item))
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	}
+	switch itemsType {
+	case string(openapi.TypeInt):
+		return "[1234, 4567]"
+	case string(openapi.TypeFloat):
+		return "[12.36, 99.45]"
+	case string(openapi.TypeBool):
+		return "[true, false]"
+	}
+	return fmt.Sprintf("[%s, %s]", terraformutils.QuoteHCLString(name+"1"), terraformutils.QuoteHCLString(name+"2"))
+}
+
+// getExamplePrimitiveValue returns the HCL value of the example if it matches the property type; otherwise a type-appropriate
+// placeholder is returned
+func getExamplePrimitiveValue(name string, propertyType string, example interface{}) string {
+	switch propertyType {
+	case string(openapi.TypeInt):
+		if number, ok := getExampleNumber(example); ok && number == float64(int64(number)) {
+			return strconv.FormatInt(int64(number), 10)
+		}
+		return "1234"
+	case string(openapi.TypeFloat):
+		if number, ok := getExampleNumber(example); ok {
+			return strconv.FormatFloat(number, 'f', -1, 64)
+		}
+		return "12.95"
+	case string(openapi.TypeBool):
+		if value, ok := example.(bool); ok {
+			return strconv.FormatBool(value)
+		}
+		return "true"
+	}
+	switch value := example.(type) {
+	case string:
+		return terraformutils.QuoteHCLString(value)
+	case nil, map[string]interface{}, []interface{}:
+		return terraformutils.QuoteHCLString(name)
+	default:
+		return terraformutils.QuoteHCLString(fmt.Sprintf("%v", value))
+	}
+}
+
+func getExampleNumber(example interface{}) (float64, bool) {
+	switch value := example.(type) {
+	case float64:
+		return value, true
+	case float32:
+		return float64(value), true
+	case int:
+		return float64(value), true
+	case int32:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	}
+	return 0, false
+}
+
+func isPrimitiveExampleType(propertyType string) bool {
+	return propertyType == string(openapi.TypeString) || propertyType == string(openapi.TypeInt) || propertyType == string(openapi.TypeFloat) || propertyType == string(openapi.TypeBool)
+}
+
+// formatExample formats the example following the HCL canonical style and validates that the example is valid HCL
+func formatExample(example string) (string, error) {
+	formatted := hclwrite.Format([]byte(example))
+	if _, diags := hclsyntax.ParseConfig(formatted, "example.tf", hcl.Pos{Line: 1, Column: 1}); diags.HasErrors() {
+		return "", fmt.Errorf("example generated is not valid HCL: %s", diags.Error())
+	}
+	return strings.TrimSpace(string(formatted)), nil
+}
@@ -0,0 +1,178 @@
+package openapiterraformdocsgenerator
+
+import (
+	"testing"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildResourceExample(t *testing.T) {
+	testCases := []struct {
+		name             string
+		schemaDefinition *openapi.SpecSchemaDefinition
+		parentProperties []string
+		expectedExample  string
+	}{
+		{
+			name: "required properties use placeholders and optional properties without examples are skipped",
+			schemaDefinition: &openapi.SpecSchemaDefinition{
+				Properties: openapi.SpecSchemaDefinitionProperties{
+					&openapi.SpecSchemaDefinitionProperty{Name: "optional_prop", Type: openapi.TypeString},
+					&openapi.SpecSchemaDefinitionProperty{Name: "string_prop", Type: openapi.TypeString, Required: true},
+					&openapi.SpecSchemaDefinitionProperty{Name: "int_prop", Type: openapi.TypeInt, Required: true},
+					&openapi.SpecSchemaDefinitionProperty{Name: "float_prop", Type: openapi.TypeFloat, Required: true},
+					&openapi.SpecSchemaDefinitionProperty{Name: "bool_prop", Type: openapi.TypeBool, Required: true},
+					&openapi.SpecSchemaDefinitionProperty{Name: "list_prop", Type: openapi.TypeList, ArrayItemsType: openapi.TypeString, Required: true},
+					&openapi.SpecSchemaDefinitionProperty{Name: "computed_prop", Type: openapi.TypeString, ReadOnly: true},
+				},
+			},
+			expectedExample: `resource "openapi_cdns_v1" "my_cdns_v1" {
+  bool_prop   = true
+  float_prop  = 12.95
+  int_prop    = 1234
+  list_prop   = ["list_prop1", "list_prop2"]
+  string_prop = "string_prop"
+}`,
+		},
+		{
+			name: "spec examples are used when present",
+			schemaDefinition: &openapi.SpecSchemaDefinition{
+				Properties: openapi.SpecSchemaDefinitionProperties{
+					&openapi.SpecSchemaDefinitionProperty{Name: "label", Type: openapi.TypeString, Required: true, Example: "my-label"},
+					&openapi.SpecSchemaDefinitionProperty{Name: "port", Type: openapi.TypeInt, Example: float64(8080)},
+					&openapi.SpecSchemaDefinitionProperty{Name: "ips", Type: openapi.TypeList, ArrayItemsType: openapi.TypeString, Example: []interface{}{"127.0.0.1"}},
+					&openapi.SpecSchemaDefinitionProperty{Name: "template", Type: openapi.TypeString, Example: "${var.name}"},
+				},
+			},
+			expectedExample: `resource "openapi_cdns_v1" "my_cdns_v1" {
+  label    = "my-label"
+  ips      = ["127.0.0.1"]
+  port     = 8080
+  template = "$${var.name}"
+}`,
+		},
+		{
+			name: "object properties are rendered as nested blocks using the object example",
+			schemaDefinition: &openapi.SpecSchemaDefinition{
+				Properties: openapi.SpecSchemaDefinitionProperties{
+					&openapi.SpecSchemaDefinitionProperty{
+						Name:     "object_prop",
+						Type:     openapi.TypeObject,
+						Required: true,
+						Example:  map[string]interface{}{"origin_port": float64(443)},
+						SpecSchemaDefinition: &openapi.SpecSchemaDefinition{
+							Properties: openapi.SpecSchemaDefinitionProperties{
+								&openapi.SpecSchemaDefinitionProperty{Name: "name", Type: openapi.TypeString, Required: true},
+								&openapi.SpecSchemaDefinitionProperty{Name: "origin_port", Type: openapi.TypeInt},
+							},
+						},
+					},
+					&openapi.SpecSchemaDefinitionProperty{
+						Name:           "list_object_prop",
+						Type:           openapi.TypeList,
+						ArrayItemsType: openapi.TypeObject,
+						Required:       true,
+						SpecSchemaDefinition: &openapi.SpecSchemaDefinition{
+							Properties: openapi.SpecSchemaDefinitionProperties{
+								&openapi.SpecSchemaDefinitionProperty{Name: "enabled", Type: openapi.TypeBool, Required: true},
+							},
+						},
+					},
+				},
+			},
+			expectedExample: `resource "openapi_cdns_v1" "my_cdns_v1" {
+  list_object_prop {
+    enabled = true
+  }
+  object_prop {
+    name        = "name"
+    origin_port = 443
+  }
+}`,
+		},
+		{
+			name: "parent properties reference the parent resources",
+			schemaDefinition: &openapi.SpecSchemaDefinition{
+				Properties: openapi.SpecSchemaDefinitionProperties{
+					&openapi.SpecSchemaDefinitionProperty{Name: "cdns_v1_id", Type: openapi.TypeString, Required: true, IsParentProperty: true},
+					&openapi.SpecSchemaDefinitionProperty{Name: "firewalls_v1_id", Type: openapi.TypeString, Required: true, IsParentProperty: true},
+					&openapi.SpecSchemaDefinitionProperty{Name: "name", Type: openapi.TypeString, Required: true},
+				},
+			},
+			parentProperties: []string{"cdns_v1_id", "firewalls_v1_id"},
+			expectedExample: `resource "openapi_cdns_v1" "my_cdns_v1" {
+  cdns_v1_id      = openapi_cdns_v1.my_cdns_v1.id
+  firewalls_v1_id = openapi_cdns_v1_firewalls_v1.my_cdns_v1_firewalls_v1.id
+  name            = "name"
+}`,
+		},
+	}
+	for _, tc := range testCases {
+		example, err := buildResourceExample("openapi", "cdns_v1", tc.schemaDefinition, tc.parentProperties)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedExample, example, tc.name)
+	}
+}
+
+func TestBuildDataSourceInstanceExample(t *testing.T) {
+	testCases := []struct {
+		name             string
+		schemaDefinition *openapi.SpecSchemaDefinition
+		expectedExample  string
+	}{
+		{
+			name:             "id placeholder is used when the id property does not have an example",
+			schemaDefinition: &openapi.SpecSchemaDefinition{},
+			expectedExample: `data "openapi_cdns_v1_instance" "my_cdns_v1_instance" {
+  id = "existing_resource_id"
+}`,
+		},
+		{
+			name: "id example is used when present",
+			schemaDefinition: &openapi.SpecSchemaDefinition{
+				Properties: openapi.SpecSchemaDefinitionProperties{
+					&openapi.SpecSchemaDefinitionProperty{Name: "id", Type: openapi.TypeString, ReadOnly: true, Example: "f0e2b1c4"},
+				},
+			},
+			expectedExample: `data "openapi_cdns_v1_instance" "my_cdns_v1_instance" {
+  id = "f0e2b1c4"
+}`,
+		},
+	}
+	for _, tc := range testCases {
+		example, err := buildDataSourceInstanceExample("openapi", "cdns_v1_instance", tc.schemaDefinition, nil)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedExample, example, tc.name)
+	}
+}
+
+func TestBuildDataSourceExample(t *testing.T) {
+	schemaDefinition := &openapi.SpecSchemaDefinition{
+		Properties: openapi.SpecSchemaDefinitionProperties{
+			&openapi.SpecSchemaDefinitionProperty{Name: "cdns_v1_id", Type: openapi.TypeString, IsParentProperty: true},
+			&openapi.SpecSchemaDefinitionProperty{Name: "label", Type: openapi.TypeString},
+			&openapi.SpecSchemaDefinitionProperty{Name: "password", Type: openapi.TypeString, Sensitive: true, Example: "secret"},
+			&openapi.SpecSchemaDefinitionProperty{Name: "port", Type: openapi.TypeInt, Example: float64(8080)},
+		},
+	}
+	example, err := buildDataSourceExample("openapi", "cdns_v1_firewalls_v1", schemaDefinition, []string{"cdns_v1_id"})
+	assert.NoError(t, err)
+	assert.Equal(t, `data "openapi_cdns_v1_firewalls_v1" "my_cdns_v1_firewalls_v1" {
+  cdns_v1_id = openapi_cdns_v1.my_cdns_v1.id
+  filter {
+    name   = "port"
+    values = ["8080"]
+  }
+}`, example)
+}
+
+func TestFormatExample(t *testing.T) {
+	example, err := formatExample("resource \"openapi_cdns_v1\" \"my_cdns_v1\" {\nlabel = \"label\"\n}\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "resource \"openapi_cdns_v1\" \"my_cdns_v1\" {\n  label = \"label\"\n}", example)
+
+	_, err = formatExample("resource \"openapi_cdns_v1\" \"my_cdns_v1\" {\nlabel = \n")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "example generated is not valid HCL")
+}
@@ -90,6 +90,7 @@ type specStubResource struct {
 	openapi.SpecResource
 	name                string
 	shouldIgnore        bool
+	deprecationMessage  string
 	schemaDefinition    *openapi.SpecSchemaDefinition
 	parentResourceNames []string
 	error               error
@@ -97,6 +98,8 @@ type specStubResource struct {
 
 func (s *specStubResource) ShouldIgnoreResource() bool { return s.shouldIgnore }
 
+func (s *specStubResource) GetDeprecationMessage() string { return s.deprecationMessage }
+
 func (s *specStubResource) GetResourceSchema() (*openapi.SpecSchemaDefinition, error) {
 	if s.error != nil {
 		return nil, s.error
@@ -357,12 +357,14 @@ func TestGetSecurity(t *testing.T) {
 
 func TestGetDataSourceFilters(t *testing.T) {
 	testCases := []struct {
-		name          string
-		openapiProps  openapi.SpecSchemaDefinitionProperties
-		expectedProps []Property
+		name            string
+		openapiProps    openapi.SpecSchemaDefinitionProperties
+		expectedProps   []Property
+		expectedExample string
 	}{
 		{
-			name: "happy path - string prop",
+			name:            "happy path - string prop",
+			expectedExample: "data \"openapi_test_resource\" \"my_test_resource\" {\n  filter {\n    name   = \"string_prop\"\n    values = [\"string_prop\"]\n  }\n}",
 			openapiProps: openapi.SpecSchemaDefinitionProperties{
 				&openapi.SpecSchemaDefinitionProperty{
 					Name: "string_prop",
@@ -372,7 +374,8 @@ func TestGetDataSourceFilters(t *testing.T) {
 			expectedProps: []Property{{Name: "string_prop", Type: "string", Required: false, Computed: true, IsOptionalComputed: true}},
 		},
 		{
-			name: "happy path - int prop",
+			name:            "happy path - int prop",
+			expectedExample: "data \"openapi_test_resource\" \"my_test_resource\" {\n  filter {\n    name   = \"int_prop\"\n    values = [\"1234\"]\n  }\n}",
 			openapiProps: openapi.SpecSchemaDefinitionProperties{
 				&openapi.SpecSchemaDefinitionProperty{
 					Name: "int_prop",
@@ -382,7 +385,8 @@ func TestGetDataSourceFilters(t *testing.T) {
 			expectedProps: []Property{{Name: "int_prop", Type: "integer", Required: false, Computed: true, IsOptionalComputed: true}},
 		},
 		{
-			name: "happy path - float prop",
+			name:            "happy path - float prop",
+			expectedExample: "data \"openapi_test_resource\" \"my_test_resource\" {\n  filter {\n    name   = \"float_prop\"\n    values = [\"12.95\"]\n  }\n}",
 			openapiProps: openapi.SpecSchemaDefinitionProperties{
 				&openapi.SpecSchemaDefinitionProperty{
 					Name: "float_prop",
@@ -392,7 +396,8 @@ func TestGetDataSourceFilters(t *testing.T) {
 			expectedProps: []Property{{Name: "float_prop", Type: "number", Required: false, Computed: true, IsOptionalComputed: true}},
 		},
 		{
-			name: "happy path - list prop",
+			name:            "happy path - list prop",
+			expectedExample: "data \"openapi_test_resource\" \"my_test_resource\" {\n}",
 			openapiProps: openapi.SpecSchemaDefinitionProperties{
 				&openapi.SpecSchemaDefinitionProperty{
 					Name:           "list_prop",
@@ -403,7 +408,8 @@ func TestGetDataSourceFilters(t *testing.T) {
 			expectedProps: []Property{{Name: "list_prop", Type: "list", ArrayItemsType: "string", Required: false, Computed: true, IsOptionalComputed: true}},
 		},
 		{
-			name: "happy path - obj prop with multiple child props (child props should be ordered by their hash)",
+			name:            "happy path - obj prop with multiple child props (child props should be ordered by their hash)",
+			expectedExample: "data \"openapi_test_resource\" \"my_test_resource\" {\n}",
 			openapiProps: openapi.SpecSchemaDefinitionProperties{
 				&openapi.SpecSchemaDefinitionProperty{
 					Name: "obj_prop",
@@ -442,7 +448,7 @@ func TestGetDataSourceFilters(t *testing.T) {
 				},
 			},
 		}
-		dg := TerraformProviderDocGenerator{}
+		dg := TerraformProviderDocGenerator{ProviderName: "openapi"}
 		actualDataSources, err := dg.getDataSourceFilters(openapiDataSources)
 
 		expectedDataSources := []DataSource{
@@ -450,6 +456,7 @@ func TestGetDataSourceFilters(t *testing.T) {
 				Name:         "test_resource",
 				Properties:   tc.expectedProps,
 				OtherExample: "",
+				ExampleUsage: []ExampleUsage{{Example: tc.expectedExample}},
 			},
 		}
 		assert.NoError(t, err, tc.name)
@@ -558,7 +565,7 @@ func TestGetDataSourceInstances(t *testing.T) {
 				},
 			},
 		}
-		dg := TerraformProviderDocGenerator{}
+		dg := TerraformProviderDocGenerator{ProviderName: "openapi"}
 		dataSourceInstances, err := dg.getDataSourceInstances(openapiResources)
 
 		expectedDataSourceInstances := []DataSource{
@@ -566,6 +573,7 @@ func TestGetDataSourceInstances(t *testing.T) {
 				Name:         "test_resource_instance",
 				Properties:   tc.expectedProps,
 				OtherExample: "",
+				ExampleUsage: []ExampleUsage{{Example: "data \"openapi_test_resource_instance\" \"my_test_resource_instance\" {\n  id = \"existing_resource_id\"\n}"}},
 			},
 		}
 		assert.NoError(t, err, tc.name)
@@ -589,12 +597,14 @@ func TestGetDataSourceInstances_Error(t *testing.T) {
 
 func TestGetProviderResources(t *testing.T) {
 	testCases := []struct {
-		name          string
-		openapiProps  openapi.SpecSchemaDefinitionProperties
-		expectedProps []Property
+		name            string
+		openapiProps    openapi.SpecSchemaDefinitionProperties
+		expectedProps   []Property
+		expectedExample string
 	}{
 		{
-			name: "happy path - string prop",
+			name:            "happy path - string prop",
+			expectedExample: "resource \"openapi_test_resource\" \"my_test_resource\" {\n}",
 			openapiProps: openapi.SpecSchemaDefinitionProperties{
 				&openapi.SpecSchemaDefinitionProperty{
 					Name: "string_prop",
@@ -604,7 +614,8 @@ func TestGetProviderResources(t *testing.T) {
 			expectedProps: []Property{{Name: "string_prop", Type: "string", Required: false, Computed: false}},
 		},
 		{
-			name: "happy path - int prop",
+			name:            "happy path - int prop",
+			expectedExample: "resource \"openapi_test_resource\" \"my_test_resource\" {\n}",
 			openapiProps: openapi.SpecSchemaDefinitionProperties{
 				&openapi.SpecSchemaDefinitionProperty{
 					Name: "int_prop",
@@ -614,7 +625,8 @@ func TestGetProviderResources(t *testing.T) {
 			expectedProps: []Property{{Name: "int_prop", Type: "integer", Required: false, Computed: false}},
 		},
 		{
-			name: "happy path - float prop",
+			name:            "happy path - float prop",
+			expectedExample: "resource \"openapi_test_resource\" \"my_test_resource\" {\n}",
 			openapiProps: openapi.SpecSchemaDefinitionProperties{
 				&openapi.SpecSchemaDefinitionProperty{
 					Name: "float_prop",
@@ -624,7 +636,35 @@ func TestGetProviderResources(t *testing.T) {
 			expectedProps: []Property{{Name: "float_prop", Type: "number", Required: false, Computed: false}},
 		},
 		{
-			name: "happy path - list prop",
+			name:            "happy path - props with default values (only documented if the default value is used as the terraform default)",
+			expectedExample: "resource \"openapi_test_resource\" \"my_test_resource\" {\n}",
+			openapiProps: openapi.SpecSchemaDefinitionProperties{
+				&openapi.SpecSchemaDefinitionProperty{
+					Name:    "int_prop",
+					Type:    openapi.TypeInt,
+					Default: 8080,
+				},
+				&openapi.SpecSchemaDefinitionProperty{
+					Name:    "string_prop",
+					Type:    openapi.TypeString,
+					Default: "http",
+				},
+				&openapi.SpecSchemaDefinitionProperty{
+					Name:     "computed_prop",
+					Type:     openapi.TypeString,
+					Computed: true,
+					Default:  "computed by the API",
+				},
+			},
+			expectedProps: []Property{
+				{Name: "int_prop", Type: "integer", Required: false, Computed: false, Default: "8080"},
+				{Name: "computed_prop", Type: "string", Required: false, Computed: true},
+				{Name: "string_prop", Type: "string", Required: false, Computed: false, Default: `"http"`},
+			},
+		},
+		{
+			name:            "happy path - list prop",
+			expectedExample: "resource \"openapi_test_resource\" \"my_test_resource\" {\n}",
 			openapiProps: openapi.SpecSchemaDefinitionProperties{
 				&openapi.SpecSchemaDefinitionProperty{
 					Name:           "list_prop",
@@ -635,7 +675,8 @@ func TestGetProviderResources(t *testing.T) {
 			expectedProps: []Property{{Name: "list_prop", Type: "list", ArrayItemsType: "string", Required: false, Computed: false}},
 		},
 		{
-			name: "happy path - obj prop with multiple child props (child props should be ordered according to their hash)",
+			name:            "happy path - obj prop with multiple child props (child props should be ordered according to their hash)",
+			expectedExample: "resource \"openapi_test_resource\" \"my_test_resource\" {\n}",
 			openapiProps: openapi.SpecSchemaDefinitionProperties{
 				&openapi.SpecSchemaDefinitionProperty{
 					Name: "obj_prop",
@@ -664,7 +705,8 @@ func TestGetProviderResources(t *testing.T) {
 			},
 		},
 		{
-			name: "happy path - required props should be listed before optional props",
+			name:            "happy path - required props should be listed before optional props",
+			expectedExample: "resource \"openapi_test_resource\" \"my_test_resource\" {\n  required_prop = \"required_prop\"\n}",
 			openapiProps: openapi.SpecSchemaDefinitionProperties{
 				&openapi.SpecSchemaDefinitionProperty{
 					Name:     "optional_prop",
@@ -692,7 +734,7 @@ func TestGetProviderResources(t *testing.T) {
 				},
 			},
 		}
-		dg := TerraformProviderDocGenerator{}
+		dg := TerraformProviderDocGenerator{ProviderName: "openapi"}
 		actualResources, err := dg.getProviderResources(openapiResources)
 
 		expectedResources := []Resource{
@@ -700,7 +742,7 @@ func TestGetProviderResources(t *testing.T) {
 				Name:               "test_resource",
 				Description:        "",
 				Properties:         tc.expectedProps,
-				ExampleUsage:       nil,
+				ExampleUsage:       []ExampleUsage{{Example: tc.expectedExample}},
 				ArgumentsReference: ArgumentsReference{Notes: []string{}},
 			},
 		}
@@ -724,6 +766,27 @@ func TestGetProviderResources_HasParentProps(t *testing.T) {
 	assert.Equal(t, "parentResourceName_id", actualResources[0].ParentProperties[0])
 }
 
+func TestGetProviderResources_Deprecated(t *testing.T) {
+	openapiResources := []openapi.SpecResource{
+		&specStubResource{
+			name:               "deprecated_resource",
+			deprecationMessage: "use other_resource instead",
+			schemaDefinition: &openapi.SpecSchemaDefinition{
+				Properties: openapi.SpecSchemaDefinitionProperties{
+					&openapi.SpecSchemaDefinitionProperty{Name: "deprecated_prop", Type: openapi.TypeString, Description: "some description", Deprecated: true},
+				},
+			},
+		},
+	}
+	dg := TerraformProviderDocGenerator{ProviderName: "openapi"}
+	actualResources, err := dg.getProviderResources(openapiResources)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "use other_resource instead", actualResources[0].DeprecationMessage)
+	assert.Equal(t, "some description", actualResources[0].Properties[0].Description)
+	assert.Equal(t, "property deprecated_prop is deprecated", actualResources[0].Properties[0].Deprecated)
+}
+
 func TestGetProviderResources_IgnoreResource(t *testing.T) {
 	openapiResources := []openapi.SpecResource{
 		&specStubResource{
@@ -1,6 +1,7 @@
 package openapiterraformdocsgenerator
 
 import (
+	"encoding/json"
 	"io"
 )
 
@@ -15,9 +16,43 @@ type TerraformProviderDocumentation struct {
 	ShowSpecialTermsDefinitions bool
 }
 
+// HTMLTemplates defines the templates used to render each of the sections of the HTML documentation
+type HTMLTemplates struct {
+	TableOfContents       string
+	ProviderInstallation  string
+	ProviderConfiguration string
+	ProviderResources     string
+	DataSources           string
+	SpecialTerms          string
+}
+
+// DefaultHTMLTemplates returns the templates used by default to render the HTML documentation
+func DefaultHTMLTemplates() HTMLTemplates {
+	return HTMLTemplates{
+		TableOfContents:       TableOfContentsTmpl,
+		ProviderInstallation:  ProviderInstallationTmpl,
+		ProviderConfiguration: ProviderConfigurationTmpl,
+		ProviderResources:     ProviderResourcesTmpl,
+		DataSources:           DataSourcesTmpl,
+		SpecialTerms:          SpecialTermsTmpl,
+	}
+}
+
 // RenderHTML writes to the given writer argument the Terraform provider documentation
 func (t TerraformProviderDocumentation) RenderHTML(w io.Writer) error {
-	return t.renderZendeskHTML(w, TableOfContentsTmpl, ProviderInstallationTmpl, ProviderConfigurationTmpl, ProviderResourcesTmpl, DataSourcesTmpl, SpecialTermsTmpl)
+	return t.RenderHTMLWithTemplates(w, DefaultHTMLTemplates())
+}
+
+// RenderHTMLWithTemplates writes to the given writer argument the Terraform provider documentation rendered with the templates provided
+func (t TerraformProviderDocumentation) RenderHTMLWithTemplates(w io.Writer, templates HTMLTemplates) error {
+	return t.renderZendeskHTML(w, templates.TableOfContents, templates.ProviderInstallation, templates.ProviderConfiguration, templates.ProviderResources, templates.DataSources, templates.SpecialTerms)
+}
+
+// RenderJSON writes to the given writer argument the Terraform provider documentation model in JSON format
+func (t TerraformProviderDocumentation) RenderJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(t)
 }
 
 // RenderZendeskHTML renders the documentation in HTML
@@ -13,5 +13,6 @@ type DataSource struct {
 	Name         string
 	Description  string
 	OtherExample string
+	ExampleUsage []ExampleUsage
 	Properties   []Property
 }
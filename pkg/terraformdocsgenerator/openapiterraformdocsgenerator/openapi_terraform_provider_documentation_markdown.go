@@ -0,0 +1,431 @@
+package openapiterraformdocsgenerator
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const markdownResourcesDir = "resources"
+const markdownDataSourcesDir = "data-sources"
+
+// markdownProperty represents a property already formatted to be rendered in the Registry markdown layout
+type markdownProperty struct {
+	Name string
+	// Type contains the type of the property and its qualifiers (e,g: String, Required, Sensitive)
+	Type        string
+	Description string
+	// NestedSchemaAnchor contains the anchor of the nested schema section documenting the property schema (only populated for objects and arrays of objects)
+	NestedSchemaAnchor string
+}
+
+// markdownNestedSchema represents the nested schema of an object or array of objects property
+type markdownNestedSchema struct {
+	Path       string
+	Anchor     string
+	Properties []markdownProperty
+}
+
+// markdownIndex is the data used to render the provider index page (index.md)
+type markdownIndex struct {
+	ProviderName                string
+	ProviderNotes               []string
+	InstallationExample         string
+	InstallationOther           string
+	InstallationOtherCommand    string
+	Regions                     []string
+	ConfigProperties            []markdownProperty
+	ExampleUsage                string
+	ShowSpecialTermsDefinitions bool
+}
+
+// markdownResource is the data used to render a resource page (resources/<name>.md)
+type markdownResource struct {
+	ProviderName           string
+	Name                   string
+	Description            string
+	FrontMatterDescription string
+	ExampleUsage           []ExampleUsage
+	GeneratedExample       string
+	Arguments              []markdownProperty
+	Attributes             []markdownProperty
+	NestedSchemas          []markdownNestedSchema
+	ArgumentsNotes         []string
+	ParentProperties       []string
+	ImportIDsExample       string
+	KnownIssues            []KnownIssue
+	ContainsObjectProp     bool
+	DeprecationMessage     string
+}
+
+// markdownDataSource is the data used to render a data source page (data-sources/<name>.md)
+type markdownDataSource struct {
+	ProviderName           string
+	Name                   string
+	Description            string
+	FrontMatterDescription string
+	ExampleUsage           []ExampleUsage
+	FilterProperties       []string
+	Attributes             []markdownProperty
+	NestedSchemas          []markdownNestedSchema
+	ContainsObjectProp     bool
+}
+
+// MarkdownTemplates defines the templates used to render each of the pages of the markdown documentation
+type MarkdownTemplates struct {
+	Index              string
+	Resource           string
+	DataSourceInstance string
+	DataSource         string
+}
+
+// DefaultMarkdownTemplates returns the templates used by default to render the markdown documentation
+func DefaultMarkdownTemplates() MarkdownTemplates {
+	return MarkdownTemplates{
+		Index:              MarkdownIndexTmpl,
+		Resource:           MarkdownResourceTmpl,
+		DataSourceInstance: MarkdownDataSourceInstanceTmpl,
+		DataSource:         MarkdownDataSourceTmpl,
+	}
+}
+
+// RenderMarkdown writes into the given output directory the Terraform provider documentation following the Terraform
+// Registry layout: index.md, resources/<name>.md and data-sources/<name>.md. The output directory is expected to be the
+// provider's docs folder (e,g: docs)
+func (t TerraformProviderDocumentation) RenderMarkdown(outputDir string) error {
+	return t.RenderMarkdownWithTemplates(outputDir, DefaultMarkdownTemplates())
+}
+
+// RenderMarkdownWithTemplates writes into the given output directory the Terraform provider documentation following the
+// Terraform Registry layout rendered with the templates provided
+func (t TerraformProviderDocumentation) RenderMarkdownWithTemplates(outputDir string, templates MarkdownTemplates) error {
+	files, err := t.renderMarkdown(templates.Index, templates.Resource, templates.DataSourceInstance, templates.DataSource)
+	if err != nil {
+		return err
+	}
+	return writeFiles(outputDir, files)
+}
+
+// renderMarkdown renders the documentation in markdown returning the content of the files keyed by their path relative
+// to the docs folder
+func (t TerraformProviderDocumentation) renderMarkdown(indexTemplate, resourceTemplate, dataSourceInstanceTemplate, dataSourceTemplate string) (map[string]string, error) {
+	files := map[string]string{}
+	var b bytes.Buffer
+	if err := render(&b, "MarkdownIndex", indexTemplate, t.toMarkdownIndex()); err != nil {
+		return nil, err
+	}
+	files["index.md"] = b.String()
+	for _, resource := range t.ProviderResources.Resources {
+		b.Reset()
+		if err := render(&b, "MarkdownResource", resourceTemplate, toMarkdownResource(t.ProviderName, resource)); err != nil {
+			return nil, err
+		}
+		files[filepath.Join(markdownResourcesDir, resource.Name+".md")] = b.String()
+	}
+	for _, dataSource := range t.DataSources.DataSourceInstances {
+		b.Reset()
+		if err := render(&b, "MarkdownDataSourceInstance", dataSourceInstanceTemplate, toMarkdownDataSource(t.ProviderName, dataSource, "Retrieve an existing resource using its ID.")); err != nil {
+			return nil, err
+		}
+		files[filepath.Join(markdownDataSourcesDir, dataSource.Name+".md")] = b.String()
+	}
+	for _, dataSource := range t.DataSources.DataSources {
+		b.Reset()
+		defaultDescription := fmt.Sprintf("The %s data source allows you to retrieve an already existing %s resource using filters. Refer to the arguments section to learn more about how to configure the filters.", dataSource.Name, dataSource.Name)
+		if err := render(&b, "MarkdownDataSource", dataSourceTemplate, toMarkdownDataSource(t.ProviderName, dataSource, defaultDescription)); err != nil {
+			return nil, err
+		}
+		files[filepath.Join(markdownDataSourcesDir, dataSource.Name+".md")] = b.String()
+	}
+	return files, nil
+}
+
+func writeFiles(outputDir string, files map[string]string) error {
+	var paths []string
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		filePath := filepath.Join(outputDir, path)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filePath, []byte(files[path]), 0644); err != nil {
+			return fmt.Errorf("failed to write documentation file '%s': %s", filePath, err)
+		}
+	}
+	return nil
+}
+
+func (t TerraformProviderDocumentation) toMarkdownIndex() markdownIndex {
+	var configProperties []markdownProperty
+	var example []string
+	for _, p := range t.ProviderConfiguration.ConfigProperties {
+		configProperties = append(configProperties, markdownProperty{
+			Name:        p.Name,
+			Type:        getMarkdownPropertyType(p, getMarkdownArgumentQualifier(p)),
+			Description: p.Description,
+		})
+		example = append(example, fmt.Sprintf("  %s = \"...\"", p.Name))
+	}
+	if len(t.ProviderConfiguration.Regions) > 0 {
+		configProperties = append(configProperties, markdownProperty{
+			Name:        "region",
+			Type:        "String, Optional",
+			Description: fmt.Sprintf("The region location to be used (%s). If region isn't specified, the default is \"%s\".", strings.Join(t.ProviderConfiguration.Regions, ", "), t.ProviderConfiguration.Regions[0]),
+		})
+	}
+	return markdownIndex{
+		ProviderName:                t.ProviderName,
+		ProviderNotes:               t.ProviderNotes,
+		InstallationExample:         htmlLineBreaksToNewLines(t.ProviderInstallation.Example),
+		InstallationOther:           t.ProviderInstallation.Other,
+		InstallationOtherCommand:    htmlLineBreaksToNewLines(t.ProviderInstallation.OtherCommand),
+		Regions:                     t.ProviderConfiguration.Regions,
+		ConfigProperties:            configProperties,
+		ExampleUsage:                strings.Join(example, "\n"),
+		ShowSpecialTermsDefinitions: t.ShowSpecialTermsDefinitions && t.ProviderResources.ContainsResourcesWithSecretProperties(),
+	}
+}
+
+func toMarkdownResource(providerName string, resource Resource) markdownResource {
+	description := resource.Description
+	if description == "" {
+		description = fmt.Sprintf("Provides the %s_%s resource.", providerName, resource.Name)
+	}
+	r := markdownResource{
+		ProviderName:           providerName,
+		Name:                   resource.Name,
+		Description:            description,
+		FrontMatterDescription: indentFrontMatterValue(description),
+		ExampleUsage:           resource.ExampleUsage,
+		GeneratedExample:       strings.Join(buildMarkdownExample(resource.Properties, "  "), "\n"),
+		ArgumentsNotes:         resource.ArgumentsReference.Notes,
+		ParentProperties:       resource.ParentProperties,
+		ImportIDsExample:       resource.BuildImportIDsExample(),
+		KnownIssues:            resource.KnownIssues,
+		DeprecationMessage:     resource.DeprecationMessage,
+	}
+	for _, p := range resource.Properties {
+		anchor := ""
+		if isMarkdownNestedSchema(p) {
+			anchor = getMarkdownNestedSchemaAnchor(p.Name)
+		}
+		if isArgument(p) {
+			description := p.Description
+			if p.IsParent {
+				description = fmt.Sprintf("The %s that this resource belongs to", p.Name)
+			}
+			r.Arguments = append(r.Arguments, markdownProperty{Name: p.Name, Type: getMarkdownPropertyType(p, getMarkdownArgumentQualifier(p)), Description: appendMarkdownDeprecation(appendMarkdownDefault(description, p), p), NestedSchemaAnchor: anchor})
+		} else if isAttribute(p) {
+			r.Attributes = append(r.Attributes, markdownProperty{Name: p.Name, Type: getMarkdownPropertyType(p, ""), Description: appendMarkdownDeprecation(p.Description, p), NestedSchemaAnchor: anchor})
+		}
+		if p.Type == "object" {
+			r.ContainsObjectProp = true
+		}
+	}
+	r.NestedSchemas = getMarkdownNestedSchemas(resource.Properties, "")
+	return r
+}
+
+func toMarkdownDataSource(providerName string, dataSource DataSource, defaultDescription string) markdownDataSource {
+	description := dataSource.Description
+	if description == "" {
+		description = defaultDescription
+	}
+	d := markdownDataSource{
+		ProviderName:           providerName,
+		Name:                   dataSource.Name,
+		Description:            description,
+		FrontMatterDescription: indentFrontMatterValue(description),
+		ExampleUsage:           dataSource.ExampleUsage,
+	}
+	for _, p := range dataSource.Properties {
+		switch p.Type {
+		case "string", "integer", "number", "boolean":
+			d.FilterProperties = append(d.FilterProperties, p.Name)
+		}
+		if p.Type == "object" {
+			d.ContainsObjectProp = true
+		}
+		if !p.Computed && !p.ContainsComputedSubProperties() {
+			continue
+		}
+		anchor := ""
+		if isMarkdownNestedSchema(p) {
+			anchor = getMarkdownNestedSchemaAnchor(p.Name)
+		}
+		d.Attributes = append(d.Attributes, markdownProperty{Name: p.Name, Type: getMarkdownPropertyType(p, ""), Description: p.Description, NestedSchemaAnchor: anchor})
+	}
+	d.NestedSchemas = getMarkdownNestedSchemas(dataSource.Properties, "")
+	return d
+}
+
+// isArgument follows the same rules as the HTML arguments reference: required, optional and optional computed properties are arguments
+func isArgument(p Property) bool {
+	return p.Required || (!p.Required && !p.Computed) || p.IsOptionalComputed
+}
+
+// isAttribute follows the same rules as the HTML attributes reference: computed properties and objects containing computed properties are attributes
+func isAttribute(p Property) bool {
+	return p.Computed || p.ContainsComputedSubProperties()
+}
+
+func isMarkdownNestedSchema(p Property) bool {
+	return len(p.Schema) > 0 && (p.Type == "object" || p.ArrayItemsType == "object")
+}
+
+func getMarkdownNestedSchemaAnchor(path string) string {
+	return "nestedblock--" + strings.Replace(path, ".", "--", -1)
+}
+
+func getMarkdownNestedSchemas(properties []Property, parentPath string) []markdownNestedSchema {
+	var nestedSchemas []markdownNestedSchema
+	for _, p := range properties {
+		if !isMarkdownNestedSchema(p) {
+			continue
+		}
+		path := p.Name
+		if parentPath != "" {
+			path = parentPath + "." + p.Name
+		}
+		nestedSchema := markdownNestedSchema{Path: path, Anchor: getMarkdownNestedSchemaAnchor(path)}
+		for _, nestedProp := range p.Schema {
+			qualifier := getMarkdownArgumentQualifier(nestedProp)
+			if !isArgument(nestedProp) {
+				qualifier = "Read-Only"
+			}
+			anchor := ""
+			if isMarkdownNestedSchema(nestedProp) {
+				anchor = getMarkdownNestedSchemaAnchor(path + "." + nestedProp.Name)
+			}
+			nestedSchema.Properties = append(nestedSchema.Properties, markdownProperty{Name: nestedProp.Name, Type: getMarkdownPropertyType(nestedProp, qualifier), Description: appendMarkdownDeprecation(appendMarkdownDefault(nestedProp.Description, nestedProp), nestedProp), NestedSchemaAnchor: anchor})
+		}
+		nestedSchemas = append(nestedSchemas, nestedSchema)
+		nestedSchemas = append(nestedSchemas, getMarkdownNestedSchemas(p.Schema, path)...)
+	}
+	return nestedSchemas
+}
+
+// appendMarkdownDefault appends to the description the default value of the property (if any) following the Registry
+// convention (e,g: Defaults to `8080`.)
+func appendMarkdownDefault(description string, p Property) string {
+	if p.Default == "" {
+		return description
+	}
+	defaultDescription := fmt.Sprintf("Defaults to `%s`.", p.Default)
+	if description == "" {
+		return defaultDescription
+	}
+	return strings.TrimSuffix(description, ".") + ". " + defaultDescription
+}
+
+// appendMarkdownDeprecation appends to the description the deprecation message of the property (if any)
+func appendMarkdownDeprecation(description string, p Property) string {
+	if p.Deprecated == "" {
+		return description
+	}
+	deprecationDescription := fmt.Sprintf("**Deprecated:** %s", p.Deprecated)
+	if description == "" {
+		return deprecationDescription
+	}
+	return description + " " + deprecationDescription
+}
+
+func getMarkdownArgumentQualifier(p Property) string {
+	if p.Required {
+		return "Required"
+	}
+	return "Optional"
+}
+
+// getMarkdownPropertyType returns the type description of the property as shown in the Registry (e,g: String, List of Number,
+// Block List, Max: 1) including the qualifier provided (e,g: Required) and whether the property is sensitive
+func getMarkdownPropertyType(p Property, qualifier string) string {
+	var propertyType string
+	switch {
+	case p.Type == "object":
+		propertyType = "Block List, Max: 1"
+	case p.Type == "list" && p.ArrayItemsType == "object":
+		propertyType = "Block List"
+	case p.Type == "list":
+		propertyType = "List of " + getMarkdownPrimitiveType(p.ArrayItemsType)
+	default:
+		propertyType = getMarkdownPrimitiveType(p.Type)
+	}
+	parts := []string{propertyType}
+	if qualifier != "" {
+		parts = append(parts, qualifier)
+	}
+	if p.IsSensitive {
+		parts = append(parts, "Sensitive")
+	}
+	if p.Deprecated != "" {
+		parts = append(parts, "Deprecated")
+	}
+	return strings.Join(parts, ", ")
+}
+
+func getMarkdownPrimitiveType(t string) string {
+	switch t {
+	case "integer", "number":
+		return "Number"
+	case "boolean":
+		return "Boolean"
+	case "":
+		return "String"
+	}
+	return strings.Title(t)
+}
+
+// buildMarkdownExample returns the HCL lines of the example usage populated with the required properties, same as the HTML example usage
+func buildMarkdownExample(properties []Property, indent string) []string {
+	var lines []string
+	for _, p := range properties {
+		if !p.Required {
+			continue
+		}
+		switch {
+		case p.Type == "string":
+			lines = append(lines, fmt.Sprintf("%s%s = \"%s\"", indent, p.Name, p.Name))
+		case p.Type == "integer":
+			lines = append(lines, fmt.Sprintf("%s%s = 1234", indent, p.Name))
+		case p.Type == "boolean":
+			lines = append(lines, fmt.Sprintf("%s%s = true", indent, p.Name))
+		case p.Type == "number":
+			lines = append(lines, fmt.Sprintf("%s%s = 12.95", indent, p.Name))
+		case p.Type == "list" && p.ArrayItemsType == "string":
+			lines = append(lines, fmt.Sprintf("%s%s = [\"%s1\", \"%s2\"]", indent, p.Name, p.Name, p.Name))
+		case p.Type == "list" && p.ArrayItemsType == "integer":
+			lines = append(lines, fmt.Sprintf("%s%s = [1234, 4567]", indent, p.Name))
+		case p.Type == "list" && p.ArrayItemsType == "boolean":
+			lines = append(lines, fmt.Sprintf("%s%s = [true, false]", indent, p.Name))
+		case p.Type == "list" && p.ArrayItemsType == "number":
+			lines = append(lines, fmt.Sprintf("%s%s = [12.36, 99.45]", indent, p.Name))
+		case p.Type == "object" || (p.Type == "list" && p.ArrayItemsType == "object"):
+			lines = append(lines, fmt.Sprintf("%s%s {", indent, p.Name))
+			lines = append(lines, buildMarkdownExample(p.Schema, indent+"  ")...)
+			lines = append(lines, indent+"}")
+		}
+	}
+	return lines
+}
+
+// htmlLineBreaksToNewLines replaces the HTML line breaks that the provider installation commands may contain (since they
+// are also rendered in the HTML documentation) with new lines
+func htmlLineBreaksToNewLines(s string) string {
+	for _, lineBreak := range []string{"<br/>", "<br />", "<br>"} {
+		s = strings.Replace(s, lineBreak, "\n", -1)
+	}
+	return strings.TrimSpace(s)
+}
+
+// indentFrontMatterValue indents the value so it can be used as a YAML front matter block scalar (description: |-)
+func indentFrontMatterValue(s string) string {
+	return "  " + strings.Replace(strings.TrimSpace(s), "\n", "\n  ", -1)
+}
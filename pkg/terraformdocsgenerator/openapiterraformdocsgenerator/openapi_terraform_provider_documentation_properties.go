@@ -11,7 +11,14 @@ type Property struct {
 	IsSensitive        bool
 	IsParent           bool
 	Description        string
-	Schema             []Property // This is used to describe the schema for array of objects or object properties
+	// Default contains the formatted default value of the property (only populated if the default value is used as the
+	// terraform default value, otherwise the value is computed by the API). The field is not taken into account when
+	// ordering the properties by their hash so the order of the properties remains stable
+	Default string `hash:"ignore"`
+	// Deprecated contains the deprecation message if the property is deprecated (not taken into account when ordering
+	// the properties by their hash either)
+	Deprecated string     `hash:"ignore"`
+	Schema     []Property // This is used to describe the schema for array of objects or object properties
 }
 
 // ContainsComputedSubProperties checks if a schema contains properties that are computed recursively
@@ -28,6 +28,8 @@ type Resource struct {
 	ExampleUsage       []ExampleUsage
 	ArgumentsReference ArgumentsReference
 	KnownIssues        []KnownIssue
+	// DeprecationMessage contains the deprecation message if the resource is deprecated
+	DeprecationMessage string
 }
 
 // BuildImportIDsExample creates a string containing the import id hierarchy in case the resource is a sub-resource
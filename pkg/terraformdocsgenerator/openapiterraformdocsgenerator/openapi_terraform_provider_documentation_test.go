@@ -2,9 +2,13 @@ package openapiterraformdocsgenerator
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"text/template"
@@ -154,3 +158,80 @@ func TestTerraformProviderDocumentation_RenderZendeskHTML_Errors(t *testing.T) {
 		assert.EqualError(t, templateErr.Err, tc.expectedErr.Error())
 	}
 }
+
+func TestTerraformProviderDocumentation_RenderMarkdown(t *testing.T) {
+	providerName := "openapi"
+	terraformProviderDocumentation := TerraformProviderDocumentation{
+		ProviderName: providerName,
+		ProviderResources: ProviderResources{
+			ProviderName: providerName,
+			Resources:    []Resource{{Name: "cdn_v1"}, {Name: "lb_v1"}},
+		},
+		DataSources: DataSources{
+			ProviderName:        providerName,
+			DataSources:         []DataSource{{Name: "cdn_v1"}},
+			DataSourceInstances: []DataSource{{Name: "cdn_v1_instance"}},
+		},
+	}
+	outputDir, err := ioutil.TempDir("", "docs")
+	assert.Nil(t, err)
+	defer os.RemoveAll(outputDir)
+
+	err = terraformProviderDocumentation.RenderMarkdown(outputDir)
+	assert.Nil(t, err)
+	expectedFiles := map[string]string{
+		"index.md":                        "# openapi Provider",
+		"resources/cdn_v1.md":             "# openapi_cdn_v1 (Resource)",
+		"resources/lb_v1.md":              "# openapi_lb_v1 (Resource)",
+		"data-sources/cdn_v1.md":          "# openapi_cdn_v1 (Data Source)",
+		"data-sources/cdn_v1_instance.md": "# openapi_cdn_v1_instance (Data Source)",
+	}
+	for path, expectedHeader := range expectedFiles {
+		content, err := ioutil.ReadFile(filepath.Join(outputDir, path))
+		assert.Nil(t, err, path)
+		assert.True(t, strings.HasPrefix(string(content), "---\n"), path)
+		assert.Contains(t, string(content), expectedHeader, path)
+	}
+}
+
+func TestTerraformProviderDocumentation_RenderMarkdown_Errors(t *testing.T) {
+	terraformProviderDocumentation := TerraformProviderDocumentation{
+		ProviderName: "openapi",
+		ProviderResources: ProviderResources{
+			Resources: []Resource{{Name: "cdn_v1"}},
+		},
+	}
+	_, err := terraformProviderDocumentation.renderMarkdown(MarkdownIndexTmpl, `{{.nonExistentVariable}}`, MarkdownDataSourceInstanceTmpl, MarkdownDataSourceTmpl)
+	assert.EqualError(t, err, "template: MarkdownResource:1:2: executing \"MarkdownResource\" at <.nonExistentVariable>: can't evaluate field nonExistentVariable in type openapiterraformdocsgenerator.markdownResource")
+}
+
+func TestTerraformProviderDocumentation_RenderHTMLWithTemplates(t *testing.T) {
+	terraformProviderDocumentation := TerraformProviderDocumentation{ProviderName: "openapi"}
+	templates := DefaultHTMLTemplates()
+	templates.TableOfContents = `<h1>{{.ProviderName}} custom table of contents</h1>`
+	templates.ProviderInstallation = ``
+	templates.ProviderConfiguration = ``
+	templates.ProviderResources = ``
+	templates.DataSources = ``
+	templates.SpecialTerms = ``
+	var buf bytes.Buffer
+	err := terraformProviderDocumentation.RenderHTMLWithTemplates(&buf, templates)
+	assert.Nil(t, err)
+	assert.Equal(t, "<h1>openapi custom table of contents</h1>", buf.String())
+}
+
+func TestTerraformProviderDocumentation_RenderJSON(t *testing.T) {
+	terraformProviderDocumentation := TerraformProviderDocumentation{
+		ProviderName: "openapi",
+		ProviderResources: ProviderResources{
+			ProviderName: "openapi",
+			Resources:    []Resource{{Name: "cdn_v1", Properties: []Property{{Name: "label", Type: "string", Required: true}}}},
+		},
+	}
+	var buf bytes.Buffer
+	err := terraformProviderDocumentation.RenderJSON(&buf)
+	assert.Nil(t, err)
+	var d TerraformProviderDocumentation
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &d))
+	assert.Equal(t, terraformProviderDocumentation, d)
+}
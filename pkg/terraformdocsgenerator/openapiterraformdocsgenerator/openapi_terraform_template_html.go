@@ -178,6 +178,9 @@ var ProviderResourcesTmpl = fmt.Sprintf(`{{define "resource_example"}}
 {{if ne .Description "" -}}
 <p>{{.Description}}</p>
 {{- end}}
+{{- if .DeprecationMessage}}
+<p><b>Deprecated:</b> {{.DeprecationMessage}}</p>
+{{- end}}
 {{- if .KnownIssues}}
 <p>If you experience any issues using this resource, please check the <a href="#resource_{{.Name}}_known_issues" target="_self">Known Issues</a> section to see if there is a fix/workaround.</p>
 {{end -}}
@@ -188,7 +191,7 @@ var ProviderResourcesTmpl = fmt.Sprintf(`{{define "resource_example"}}
 <p>{{.Title}}</p>
 			{{- end}}
 <pre>
-{{- .Example}}
+{{- .Example | escapeHTML}}
 </pre>
 		{{- end}}
 	{{- else}}
@@ -272,7 +275,7 @@ var ArgumentReferenceTmpl = `{{- define "resource_argument_reference" -}}
         {{- $required = "Required" -}}
     {{end}}
 	{{- if or .Required (and (not .Required) (not .Computed)) .IsOptionalComputed -}}
-    <li>{{if eq .Type "object"}}<span class="wysiwyg-color-red">*</span>{{end}} {{.Name}} [{{.Type}} {{- if eq .Type "list" }} of {{.ArrayItemsType}}s{{- end -}}] {{- if .IsSensitive -}}(<a href="#special_terms_definitions_sensitive_property" target="_self">sensitive</a>){{- end}} - ({{$required}}) {{if .IsParent}}The {{.Name}} that this resource belongs to{{else}}{{.Description}}{{end}}
+    <li>{{if eq .Type "object"}}<span class="wysiwyg-color-red">*</span>{{end}} {{.Name}} [{{.Type}} {{- if eq .Type "list" }} of {{.ArrayItemsType}}s{{- end -}}] {{- if .IsSensitive -}}(<a href="#special_terms_definitions_sensitive_property" target="_self">sensitive</a>){{- end}} - ({{$required}}) {{if .IsParent}}The {{.Name}} that this resource belongs to{{else}}{{.Description}}{{end}}{{if .Default}} (defaults to <code>{{.Default | escapeHTML}}</code>){{end}}{{if .Deprecated}} (<b>deprecated:</b> {{.Deprecated}}){{end}}
         {{- if or (eq .Type "object") (eq .ArrayItemsType "object")}}. The following properties compose the object schema
         :<ul dir="ltr">
             {{- range .Schema}}
@@ -289,7 +292,7 @@ var AttributeReferenceTmpl = `{{- define "resource_attribute_reference" -}}
     {{- if or .Computed .ContainsComputedSubProperties -}}
 		{{- if and .Schema (not .ContainsComputedSubProperties) -}}{{- /* objects or arrays of objects that DO NOT have computed props are ignored since they will be documented in the arguments section */ -}}
 		{{- else -}}
-        <li>{{if eq .Type "object"}}<span class="wysiwyg-color-red">*</span>{{end}} {{.Name}} [{{.Type}} {{- if eq .Type "list" }} of {{.ArrayItemsType}}s{{- end -}}] {{ if .IsSensitive }}(<a href="#special_terms_definitions_sensitive_property" target="_self">sensitive</a>) {{end -}}{{- if .Description }}- {{.Description}} {{- end -}}
+        <li>{{if eq .Type "object"}}<span class="wysiwyg-color-red">*</span>{{end}} {{.Name}} [{{.Type}} {{- if eq .Type "list" }} of {{.ArrayItemsType}}s{{- end -}}] {{ if .IsSensitive }}(<a href="#special_terms_definitions_sensitive_property" target="_self">sensitive</a>) {{end -}}{{- if .Description }}- {{.Description}} {{- end -}}{{- if .Deprecated}} (<b>deprecated:</b> {{.Deprecated}}){{- end -}}
             {{- if or (eq .Type "object") (eq .ArrayItemsType "object")}} The following properties compose the object schema:
             <ul dir="ltr">
                 {{- range .Schema}}
@@ -318,9 +321,17 @@ No data sources using resource id are supported at the moment.
 	<p>Retrieve an existing resource using it's ID</p>
 	{{- end}}
     <h4 id="datasource_{{.Name}}_example_usage" dir="ltr">Example usage</h4>
+	{{- if .ExampleUsage}}
+		{{- range .ExampleUsage}}
+<pre>
+{{- .Example | escapeHTML}}
+</pre>
+		{{- end}}
+	{{- else}}
 <pre><span>data </span><span>"{{$.ProviderName}}_{{$datasource.Name}}" "my_{{$datasource.Name}}"</span>{
     id = "existing_resource_id"
 <span>}</span></pre>
+	{{- end}}
     <h4 id="datasource_{{.Name}}_arguments_reference" dir="ltr">Arguments Reference</h4>
     <p dir="ltr">The following arguments are supported:</p>
     <ul dir="ltr">
@@ -359,6 +370,13 @@ No data sources using filters are supported at the moment.
 	<p>The {{.Name}} data source allows you to retrieve an already existing {{.Name}} resource using filters. Refer to the arguments section to learn more about how to configure the filters.</p>
 	{{- end}}
     <h4 id="datasource_{{.Name}}_example_usage" dir="ltr">Example usage</h4>
+	{{- if .ExampleUsage}}
+		{{- range .ExampleUsage}}
+<pre>
+{{- .Example | escapeHTML}}
+</pre>
+		{{- end}}
+	{{- else}}
     <pre>
 <span>data </span><span>"{{$.ProviderName}}_{{$datasource.Name}}" "my_{{$datasource.Name}}"</span>{
     <span>filter  </span><span>{</span>
@@ -366,6 +384,7 @@ No data sources using filters are supported at the moment.
         <span>values  </span>= <span>["filter value"]</span>
     <span>}</span>
 <span>}</span></pre>
+	{{- end}}
 
     <h4 id="datasource_{{.Name}}_arguments_reference" dir="ltr">Arguments Reference</h4>
     <p dir="ltr">The following arguments are supported:</p>
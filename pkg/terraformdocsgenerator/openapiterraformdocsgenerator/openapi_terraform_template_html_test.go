@@ -81,6 +81,26 @@ func TestArgumentReferenceTmpl(t *testing.T) {
 			property:       Property{Name: "optional_prop", Type: "string", Description: "", Required: false},
 			expectedOutput: "<li> optional_prop [string] - (Optional) </li>\n\t",
 		},
+		{
+			name:           "optional property with default value",
+			property:       Property{Name: "optional_prop", Type: "integer", Description: "this is an optional property", Required: false, Default: "8080"},
+			expectedOutput: "<li> optional_prop [integer] - (Optional) this is an optional property (defaults to <code>8080</code>)</li>\n\t",
+		},
+		{
+			name:           "optional property with default value containing html",
+			property:       Property{Name: "optional_prop", Type: "string", Description: "this is an optional property", Required: false, Default: "<script>alert(1)</script>"},
+			expectedOutput: "<li> optional_prop [string] - (Optional) this is an optional property (defaults to <code>&lt;script&gt;alert(1)&lt;/script&gt;</code>)</li>\n\t",
+		},
+		{
+			name:           "optional property with default value already html escaped",
+			property:       Property{Name: "optional_prop", Type: "string", Description: "this is an optional property", Required: false, Default: "a &amp; b"},
+			expectedOutput: "<li> optional_prop [string] - (Optional) this is an optional property (defaults to <code>a &amp; b</code>)</li>\n\t",
+		},
+		{
+			name:           "deprecated optional property",
+			property:       Property{Name: "optional_prop", Type: "string", Description: "this is an optional property", Required: false, Deprecated: "use other_prop instead"},
+			expectedOutput: "<li> optional_prop [string] - (Optional) this is an optional property (<b>deprecated:</b> use other_prop instead)</li>\n\t",
+		},
 		{
 			name:           "required parent property",
 			property:       Property{Name: "required_parent_prop", Type: "string", Description: "", Required: true, IsParent: true},
@@ -164,6 +184,11 @@ func TestAttributeReferenceTmpl(t *testing.T) {
 			property:       Property{Name: "optional_computed_prop", Type: "string", Description: "this is a required property", Required: true},
 			expectedOutput: "",
 		},
+		{
+			name:           "deprecated computed string property",
+			property:       Property{Name: "computed_string_prop", Type: "string", Description: "string property description", Computed: true, Deprecated: "use other_prop instead"},
+			expectedOutput: "<li> computed_string_prop [string] - string property description (<b>deprecated:</b> use other_prop instead)</li>\n\t\t",
+		},
 		{
 			name:           "computed string property without description",
 			property:       createProperty("computed_string_prop", "string", "", false, true),
@@ -253,11 +278,11 @@ func TestProviderConfigurationTmpl(t *testing.T) {
 func TestProviderResourcesTmpl(t *testing.T) {
 	example1 := `
 resource "openapi_cdn" "my_cdn" {
-  label    = "some label"
+  label    = "some &amp; label"
 }`
 	example2 := `
 resource "openapi_cdn" "my_cdn" {
-  label    = "some label"
+  label    = "<b>some & label</b>"
 }`
 	r := ProviderResources{
 		ProviderName: "openapi",
@@ -316,12 +341,12 @@ resource "openapi_cdn" "my_cdn" {
 <p>example title 1:</p>
 <pre>
 resource "openapi_cdn" "my_cdn" {
-  label    = "some label"
+  label    = "some &amp; label"
 }
 </pre>
 <pre>
 resource "openapi_cdn" "my_cdn" {
-  label    = "some label"
+  label    = "&lt;b&gt;some &amp; label&lt;/b&gt;"
 }
 </pre>
 <h4 id="resource_cdn_arguments_reference" dir="ltr">Arguments Reference</h4>
@@ -518,7 +543,7 @@ No data sources using filters are supported at the moment. `
 }
 
 func renderTest(t *testing.T, w io.Writer, templateName string, templateContent string, data interface{}, testName string) {
-	tmpl, err := template.New(templateName).Parse(templateContent)
+	tmpl, err := template.New(templateName).Funcs(templateFuncs).Parse(templateContent)
 	assert.Nil(t, err, testName)
 	err = tmpl.Execute(w, data)
 	assert.Nil(t, err, testName)
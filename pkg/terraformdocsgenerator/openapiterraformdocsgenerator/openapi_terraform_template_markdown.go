@@ -0,0 +1,333 @@
+package openapiterraformdocsgenerator
+
+import "fmt"
+
+// MarkdownPropertyTmpl contains the definition used in the markdown templates to render a property reference
+var MarkdownPropertyTmpl = `{{- define "markdown_property" -}}
+- ` + "`{{.Name}}`" + ` ({{.Type}}){{if .Description}} {{.Description}}{{end}}{{if .NestedSchemaAnchor}} (see [below for nested schema](#{{.NestedSchemaAnchor}})){{end}}
+{{- end -}}`
+
+// MarkdownNestedSchemasTmpl contains the definition used in the markdown templates to render the nested schemas of the object properties
+var MarkdownNestedSchemasTmpl = `{{- define "markdown_nested_schemas" -}}
+{{- range . }}
+
+<a id="{{.Anchor}}"></a>
+### Nested Schema for ` + "`{{.Path}}`" + `
+{{range .Properties}}
+{{template "markdown_property" .}}
+{{- end}}
+{{- end}}
+{{- end -}}`
+
+// MarkdownObjectPropertiesNoteTmpl contains the definition used in the markdown templates to render the note about how object properties are stored in the state
+var MarkdownObjectPropertiesNoteTmpl = `{{- define "markdown_object_properties_note" -}}
+-> **Note:** Object type properties are internally represented (in the state file) as a list of one elem due to [Terraform SDK's limitation for supporting complex object types](https://github.com/hashicorp/terraform-plugin-sdk/issues/155#issuecomment-489699737). Please index on the first elem of the array to reference the object values (eg: ` + "`{{.ProviderName}}_{{.Name}}.my_{{.Name}}.object_property[0].nested_property`" + `).
+{{- end -}}`
+
+// MarkdownIndexTmpl contains the template used to render the provider index page (index.md) following the Terraform Registry layout
+var MarkdownIndexTmpl = fmt.Sprintf(`%s
+---
+page_title: "Provider: {{.ProviderName}}"
+subcategory: ""
+description: |-
+  The {{.ProviderName}} provider is used to manage {{.ProviderName}} resources.
+---
+
+# {{.ProviderName}} Provider
+
+This guide lists the configuration for '{{.ProviderName}}' Terraform provider resources that can be managed using [Terraform v0.12](https://www.hashicorp.com/blog/announcing-terraform-0-12/).
+{{- range .ProviderNotes}}
+
+~> **Note:** {{.}}
+{{- end}}
+
+## Provider Installation
+
+In order to provision '{{.ProviderName}}' Terraform resources, you need to first install the '{{.ProviderName}}' Terraform plugin by running the following command (you must be running Terraform >= 0.12):
+
+`+"```shell"+`
+{{.InstallationExample}}
+`+"```"+`
+{{- if .InstallationOther}}
+
+{{.InstallationOther}}
+{{- end}}
+
+`+"```shell"+`
+{{- if .InstallationOtherCommand}}
+{{.InstallationOtherCommand}}
+{{- end}}
+$ terraform init && terraform plan
+`+"```"+`
+{{- if or .Regions .ConfigProperties}}
+
+## Example Usage
+
+`+"```terraform"+`
+provider "{{.ProviderName}}" {
+{{- if .ExampleUsage}}
+{{.ExampleUsage}}
+{{- end}}
+}
+`+"```"+`
+{{- if .Regions}}
+
+Using the default region ({{index .Regions 0}}):
+
+`+"```terraform"+`
+provider "{{.ProviderName}}" {
+  # Resources using this default provider will be created in the '{{index .Regions 0}}' region
+  ...
+}
+`+"```"+`
+{{- if gt (len .Regions) 1}}
+
+Using a specific region ({{index .Regions 1}}):
+
+`+"```terraform"+`
+provider "{{.ProviderName}}" {
+  alias  = "{{index .Regions 1}}"
+  region = "{{index .Regions 1}}"
+  ...
+}
+
+resource "{{.ProviderName}}_resource" "my_resource" {
+  provider = {{.ProviderName}}.{{index .Regions 1}}
+  ...
+}
+`+"```"+`
+{{- end}}
+{{- end}}
+
+## Argument Reference
+
+The following arguments are supported:
+{{range .ConfigProperties}}
+{{template "markdown_property" .}}
+{{- end}}
+{{- end}}
+{{- if .ShowSpecialTermsDefinitions}}
+
+## Special Terms Definitions
+
+This section describes specific terms used throughout this document to clarify their meaning in the context of Terraform.
+
+### Sensitive Property
+
+The '{{.ProviderName}}' Terraform plugin treats secret properties as 'sensitive'. As per [Terraform documentation](https://github.com/hashicorp/terraform-plugin-sdk/blob/9f0df37a8fdb2627ae32db6ceaf7f036d89b6768/helper/schema/schema.go#L245), this means the attribute's value does not get displayed in logs or regular output.
+
+Please note that even though the secret values don't get displayed in the logs or regular output, the state file will still store the secrets. As per [Terraform's official recommendations](https://www.terraform.io/docs/state/sensitive-data.html) on how to treat Sensitive Data in State, if your state file may contain sensitive information always treat the State itself as sensitive data.
+{{- end}}
+`, MarkdownPropertyTmpl)
+
+// MarkdownResourceTmpl contains the template used to render a resource page (resources/<name>.md) following the Terraform Registry layout
+var MarkdownResourceTmpl = fmt.Sprintf(`%s%s%s
+---
+page_title: "{{.ProviderName}}_{{.Name}} Resource - terraform-provider-{{.ProviderName}}"
+subcategory: ""
+description: |-
+{{.FrontMatterDescription}}
+---
+
+# {{.ProviderName}}_{{.Name}} (Resource)
+
+{{.Description}}
+{{- if .DeprecationMessage}}
+
+~> **Deprecated:** {{.DeprecationMessage}}
+{{- end}}
+{{- if .KnownIssues}}
+
+If you experience any issues using this resource, please check the [Known Issues](#known-issues) section to see if there is a fix/workaround.
+{{- end}}
+
+## Example Usage
+{{- if .ExampleUsage}}
+{{- range .ExampleUsage}}
+{{- if .Title}}
+
+{{.Title}}
+{{- end}}
+
+`+"```terraform"+`
+{{.Example}}
+`+"```"+`
+{{- end}}
+{{- else}}
+
+`+"```terraform"+`
+resource "{{.ProviderName}}_{{.Name}}" "my_{{.Name}}" {
+{{- if .GeneratedExample}}
+{{.GeneratedExample}}
+{{- end}}
+}
+`+"```"+`
+{{- end}}
+
+## Argument Reference
+
+The following arguments are supported:
+{{range .Arguments}}
+{{template "markdown_property" .}}
+{{- end}}
+{{- range .ArgumentsNotes}}
+
+~> **Note:** {{.}}
+{{- end}}
+
+## Attributes Reference
+
+In addition to all arguments above, the following attributes are exported:
+
+- `+"`id`"+` (String) The ID of this resource.
+{{- range .Attributes}}
+{{template "markdown_property" .}}
+{{- end}}
+{{- if .ContainsObjectProp}}
+
+{{template "markdown_object_properties_note" .}}
+{{- end}}
+{{- template "markdown_nested_schemas" .NestedSchemas}}
+
+## Import
+
+{{.Name}} resources can be imported using the `+"`id`"+`
+{{- if .ParentProperties}}. This is a sub-resource so the parent resource IDs (`+"`{{.ParentProperties}}`"+`) are required to be able to retrieve an instance of this resource{{end}}, e.g:
+
+`+"```shell"+`
+$ terraform import {{.ProviderName}}_{{.Name}}.my_{{.Name}} {{.ImportIDsExample}}
+`+"```"+`
+
+-> **Note:** In order for the import to work, the '{{.ProviderName}}' terraform provider must be [properly installed](../index.md#provider-installation). Read more about Terraform import usage [here](https://www.terraform.io/docs/import/usage.html).
+{{- if .KnownIssues}}
+
+## Known Issues
+{{- range .KnownIssues}}
+
+### {{.Title}}
+
+{{.Description}}
+{{- range .Examples}}
+{{- if .Title}}
+
+{{.Title}}
+{{- end}}
+
+`+"```terraform"+`
+{{.Example}}
+`+"```"+`
+{{- end}}
+{{- end}}
+{{- end}}
+`, MarkdownPropertyTmpl, MarkdownNestedSchemasTmpl, MarkdownObjectPropertiesNoteTmpl)
+
+// MarkdownDataSourceInstanceTmpl contains the template used to render the page of a data source that retrieves a resource
+// using its id (data-sources/<name>_instance.md) following the Terraform Registry layout
+var MarkdownDataSourceInstanceTmpl = fmt.Sprintf(`%s%s%s
+---
+page_title: "{{.ProviderName}}_{{.Name}} Data Source - terraform-provider-{{.ProviderName}}"
+subcategory: ""
+description: |-
+{{.FrontMatterDescription}}
+---
+
+# {{.ProviderName}}_{{.Name}} (Data Source)
+
+{{.Description}}
+
+## Example Usage
+{{- if .ExampleUsage}}
+{{- range .ExampleUsage}}
+
+`+"```terraform"+`
+{{.Example}}
+`+"```"+`
+{{- end}}
+{{- else}}
+
+`+"```terraform"+`
+data "{{.ProviderName}}_{{.Name}}" "my_{{.Name}}" {
+  id = "existing_resource_id"
+}
+`+"```"+`
+{{- end}}
+
+## Argument Reference
+
+The following arguments are supported:
+
+- `+"`id`"+` (String, Required) ID of the existing resource to retrieve.
+
+## Attributes Reference
+
+In addition to all arguments above, the following attributes are exported:
+{{range .Attributes}}
+{{template "markdown_property" .}}
+{{- end}}
+{{- if .ContainsObjectProp}}
+
+{{template "markdown_object_properties_note" .}}
+{{- end}}
+{{- template "markdown_nested_schemas" .NestedSchemas}}
+`, MarkdownPropertyTmpl, MarkdownNestedSchemasTmpl, MarkdownObjectPropertiesNoteTmpl)
+
+// MarkdownDataSourceTmpl contains the template used to render the page of a data source that retrieves a resource using
+// filters (data-sources/<name>.md) following the Terraform Registry layout
+var MarkdownDataSourceTmpl = fmt.Sprintf(`%s%s%s
+---
+page_title: "{{.ProviderName}}_{{.Name}} Data Source - terraform-provider-{{.ProviderName}}"
+subcategory: ""
+description: |-
+{{.FrontMatterDescription}}
+---
+
+# {{.ProviderName}}_{{.Name}} (Data Source)
+
+{{.Description}}
+
+## Example Usage
+{{- if .ExampleUsage}}
+{{- range .ExampleUsage}}
+
+`+"```terraform"+`
+{{.Example}}
+`+"```"+`
+{{- end}}
+{{- else}}
+
+`+"```terraform"+`
+data "{{.ProviderName}}_{{.Name}}" "my_{{.Name}}" {
+  filter {
+    name   = "property name to filter by, see docs below for more info about available filter name options"
+    values = ["filter value"]
+  }
+}
+`+"```"+`
+{{- end}}
+
+## Argument Reference
+
+The following arguments are supported:
+
+- `+"`filter`"+` (Block List, Required) Object containing two properties (see [below for nested schema](#nestedblock--filter))
+
+<a id="nestedblock--filter"></a>
+### Nested Schema for `+"`filter`"+`
+
+- `+"`name`"+` (String, Required) The name should match one of the properties to filter by. The following property names are supported: {{range $i, $name := .FilterProperties}}{{if $i}}, {{end}}`+"`{{$name}}`"+`{{end}}.
+- `+"`values`"+` (List of String, Required) Values to filter by (only one value is supported at the moment).
+
+~> **Note:** If more or less than a single match is returned by the search, Terraform will fail. Ensure that your search is specific enough to return a single result only.
+
+## Attributes Reference
+
+In addition to all arguments above, the following attributes are exported:
+{{range .Attributes}}
+{{template "markdown_property" .}}
+{{- end}}
+{{- if .ContainsObjectProp}}
+
+{{template "markdown_object_properties_note" .}}
+{{- end}}
+{{- template "markdown_nested_schemas" .NestedSchemas}}
+`, MarkdownPropertyTmpl, MarkdownNestedSchemasTmpl, MarkdownObjectPropertiesNoteTmpl)
@@ -0,0 +1,285 @@
+package openapiterraformdocsgenerator
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownPropertyTmpl(t *testing.T) {
+	testCases := []struct {
+		name           string
+		property       markdownProperty
+		expectedOutput string
+	}{
+		{
+			name:           "property with description",
+			property:       markdownProperty{Name: "label", Type: "String, Required", Description: "The label"},
+			expectedOutput: "- `label` (String, Required) The label",
+		},
+		{
+			name:           "property without description",
+			property:       markdownProperty{Name: "label", Type: "String, Optional"},
+			expectedOutput: "- `label` (String, Optional)",
+		},
+		{
+			name:           "property with nested schema",
+			property:       markdownProperty{Name: "obj", Type: "Block List, Max: 1, Required", Description: "The object", NestedSchemaAnchor: "nestedblock--obj"},
+			expectedOutput: "- `obj` (Block List, Max: 1, Required) The object (see [below for nested schema](#nestedblock--obj))",
+		},
+	}
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		renderTest(t, &buf, "MarkdownProperty", MarkdownPropertyTmpl+`{{template "markdown_property" .}}`, tc.property, tc.name)
+		assert.Equal(t, tc.expectedOutput, buf.String(), tc.name)
+	}
+}
+
+func TestMarkdownResourceTmpl(t *testing.T) {
+	resource := Resource{
+		Name:             "cdn_v1",
+		Description:      "Manages a CDN",
+		ParentProperties: []string{"parent_id"},
+		Properties: []Property{
+			{Name: "label", Type: "string", Required: true, Description: "The label"},
+			{Name: "obj", Type: "object", Required: true, Schema: []Property{{Name: "port", Type: "integer", Required: true}, {Name: "status", Type: "string", Computed: true}}},
+			{Name: "status", Type: "string", Computed: true, Description: "The status"},
+		},
+		ArgumentsReference: ArgumentsReference{Notes: []string{"some argument note"}},
+	}
+	var buf bytes.Buffer
+	renderTest(t, &buf, "MarkdownResource", MarkdownResourceTmpl, toMarkdownResource("openapi", resource), "resource")
+	expectedOutput := "---\n" +
+		"page_title: \"openapi_cdn_v1 Resource - terraform-provider-openapi\"\n" +
+		"subcategory: \"\"\n" +
+		"description: |-\n" +
+		"  Manages a CDN\n" +
+		"---\n\n" +
+		"# openapi_cdn_v1 (Resource)\n\n" +
+		"Manages a CDN\n\n" +
+		"## Example Usage\n\n" +
+		"```terraform\n" +
+		"resource \"openapi_cdn_v1\" \"my_cdn_v1\" {\n" +
+		"  label = \"label\"\n" +
+		"  obj {\n" +
+		"    port = 1234\n" +
+		"  }\n" +
+		"}\n" +
+		"```\n\n" +
+		"## Argument Reference\n\n" +
+		"The following arguments are supported:\n\n" +
+		"- `label` (String, Required) The label\n" +
+		"- `obj` (Block List, Max: 1, Required) (see [below for nested schema](#nestedblock--obj))\n\n" +
+		"~> **Note:** some argument note\n\n" +
+		"## Attributes Reference\n\n" +
+		"In addition to all arguments above, the following attributes are exported:\n\n" +
+		"- `id` (String) The ID of this resource.\n" +
+		"- `status` (String) The status\n\n" +
+		"-> **Note:** Object type properties are internally represented (in the state file) as a list of one elem due to [Terraform SDK's limitation for supporting complex object types](https://github.com/hashicorp/terraform-plugin-sdk/issues/155#issuecomment-489699737). Please index on the first elem of the array to reference the object values (eg: `openapi_cdn_v1.my_cdn_v1.object_property[0].nested_property`).\n\n" +
+		"<a id=\"nestedblock--obj\"></a>\n" +
+		"### Nested Schema for `obj`\n\n" +
+		"- `port` (Number, Required)\n" +
+		"- `status` (String, Read-Only)\n\n" +
+		"## Import\n\n" +
+		"cdn_v1 resources can be imported using the `id`. This is a sub-resource so the parent resource IDs (`[parent_id]`) are required to be able to retrieve an instance of this resource, e.g:\n\n" +
+		"```shell\n" +
+		"$ terraform import openapi_cdn_v1.my_cdn_v1 parent_id/cdn_v1_id\n" +
+		"```\n\n" +
+		"-> **Note:** In order for the import to work, the 'openapi' terraform provider must be [properly installed](../index.md#provider-installation). Read more about Terraform import usage [here](https://www.terraform.io/docs/import/usage.html).\n"
+	assert.Equal(t, expectedOutput, buf.String())
+}
+
+func TestMarkdownResourceTmpl_CustomExampleUsageAndKnownIssues(t *testing.T) {
+	resource := Resource{
+		Name:         "cdn_v1",
+		ExampleUsage: []ExampleUsage{{Title: "Basic usage", Example: "resource \"openapi_cdn_v1\" \"basic\" {}"}},
+		KnownIssues:  []KnownIssue{{Title: "Some issue", Description: "Issue description", Examples: []ExampleUsage{{Example: "workaround"}}}},
+	}
+	var buf bytes.Buffer
+	renderTest(t, &buf, "MarkdownResource", MarkdownResourceTmpl, toMarkdownResource("openapi", resource), "resource")
+	assert.Contains(t, buf.String(), "# openapi_cdn_v1 (Resource)\n\nProvides the openapi_cdn_v1 resource.\n\nIf you experience any issues using this resource, please check the [Known Issues](#known-issues) section")
+	assert.Contains(t, buf.String(), "## Example Usage\n\nBasic usage\n\n```terraform\nresource \"openapi_cdn_v1\" \"basic\" {}\n```\n")
+	assert.Contains(t, buf.String(), "cdn_v1 resources can be imported using the `id`, e.g:\n\n```shell\n$ terraform import openapi_cdn_v1.my_cdn_v1 id\n```")
+	assert.Contains(t, buf.String(), "## Known Issues\n\n### Some issue\n\nIssue description\n\n```terraform\nworkaround\n```\n")
+}
+
+func TestMarkdownResourceTmpl_Deprecated(t *testing.T) {
+	resource := Resource{
+		Name:               "cdn_v1",
+		DeprecationMessage: "use openapi_cdn_v2 instead",
+		Properties:         []Property{{Name: "label", Type: "string", Description: "The label.", Deprecated: "use name instead"}},
+	}
+	var buf bytes.Buffer
+	renderTest(t, &buf, "MarkdownResource", MarkdownResourceTmpl, toMarkdownResource("openapi", resource), "resource")
+	assert.Contains(t, buf.String(), "# openapi_cdn_v1 (Resource)\n\nProvides the openapi_cdn_v1 resource.\n\n~> **Deprecated:** use openapi_cdn_v2 instead\n")
+	assert.Contains(t, buf.String(), "- `label` (String, Optional, Deprecated) The label. **Deprecated:** use name instead\n")
+}
+
+func TestMarkdownDataSourceInstanceTmpl(t *testing.T) {
+	dataSource := DataSource{
+		Name:       "cdn_v1_instance",
+		Properties: []Property{{Name: "label", Type: "string", Computed: true, Description: "The label"}},
+	}
+	var buf bytes.Buffer
+	renderTest(t, &buf, "MarkdownDataSourceInstance", MarkdownDataSourceInstanceTmpl, toMarkdownDataSource("openapi", dataSource, "Retrieve an existing resource using its ID."), "data source instance")
+	expectedOutput := "---\n" +
+		"page_title: \"openapi_cdn_v1_instance Data Source - terraform-provider-openapi\"\n" +
+		"subcategory: \"\"\n" +
+		"description: |-\n" +
+		"  Retrieve an existing resource using its ID.\n" +
+		"---\n\n" +
+		"# openapi_cdn_v1_instance (Data Source)\n\n" +
+		"Retrieve an existing resource using its ID.\n\n" +
+		"## Example Usage\n\n" +
+		"```terraform\n" +
+		"data \"openapi_cdn_v1_instance\" \"my_cdn_v1_instance\" {\n" +
+		"  id = \"existing_resource_id\"\n" +
+		"}\n" +
+		"```\n\n" +
+		"## Argument Reference\n\n" +
+		"The following arguments are supported:\n\n" +
+		"- `id` (String, Required) ID of the existing resource to retrieve.\n\n" +
+		"## Attributes Reference\n\n" +
+		"In addition to all arguments above, the following attributes are exported:\n\n" +
+		"- `label` (String) The label\n"
+	assert.Equal(t, expectedOutput, buf.String())
+}
+
+func TestMarkdownDataSourceTmpl(t *testing.T) {
+	dataSource := DataSource{
+		Name: "cdn_v1",
+		Properties: []Property{
+			{Name: "label", Type: "string", Computed: true},
+			{Name: "port", Type: "integer", Computed: true},
+			{Name: "tags", Type: "list", ArrayItemsType: "string", Computed: true},
+		},
+	}
+	var buf bytes.Buffer
+	renderTest(t, &buf, "MarkdownDataSource", MarkdownDataSourceTmpl, toMarkdownDataSource("openapi", dataSource, "Filter description"), "data source")
+	assert.Contains(t, buf.String(), "page_title: \"openapi_cdn_v1 Data Source - terraform-provider-openapi\"\n")
+	assert.Contains(t, buf.String(), "# openapi_cdn_v1 (Data Source)\n\nFilter description\n")
+	assert.Contains(t, buf.String(), "- `name` (String, Required) The name should match one of the properties to filter by. The following property names are supported: `label`, `port`.\n")
+	assert.Contains(t, buf.String(), "- `label` (String)\n- `port` (Number)\n- `tags` (List of String)\n")
+}
+
+func TestMarkdownDataSourceTmpl_ExampleUsage(t *testing.T) {
+	dataSource := DataSource{
+		Name:         "cdn_v1",
+		ExampleUsage: []ExampleUsage{{Example: "data \"openapi_cdn_v1\" \"my_cdn_v1\" {\n  filter {\n    name   = \"label\"\n    values = [\"my-label\"]\n  }\n}"}},
+	}
+	var buf bytes.Buffer
+	renderTest(t, &buf, "MarkdownDataSource", MarkdownDataSourceTmpl, toMarkdownDataSource("openapi", dataSource, "Filter description"), "data source")
+	assert.Contains(t, buf.String(), "## Example Usage\n\n```terraform\ndata \"openapi_cdn_v1\" \"my_cdn_v1\" {\n  filter {\n    name   = \"label\"\n    values = [\"my-label\"]\n  }\n}\n```\n\n## Argument Reference")
+	assert.NotContains(t, buf.String(), "property name to filter by")
+}
+
+func TestMarkdownIndexTmpl(t *testing.T) {
+	d := TerraformProviderDocumentation{
+		ProviderName:  "openapi",
+		ProviderNotes: []string{"some note"},
+		ProviderInstallation: ProviderInstallation{
+			Example:      "$ export PROVIDER_NAME=openapi<br>",
+			Other:        "You can then start running the Terraform provider:",
+			OtherCommand: "$ export OTF_VAR_openapi_PLUGIN_CONFIGURATION_FILE=\"https://api.service.com/openapi.yaml\"<br>",
+		},
+		ProviderConfiguration: ProviderConfiguration{
+			Regions:          []string{"rst1"},
+			ConfigProperties: []Property{{Name: "token", Type: "string", Required: true}},
+		},
+	}
+	var buf bytes.Buffer
+	renderTest(t, &buf, "MarkdownIndex", MarkdownIndexTmpl, d.toMarkdownIndex(), "index")
+	expectedOutput := "---\n" +
+		"page_title: \"Provider: openapi\"\n" +
+		"subcategory: \"\"\n" +
+		"description: |-\n" +
+		"  The openapi provider is used to manage openapi resources.\n" +
+		"---\n\n" +
+		"# openapi Provider\n\n" +
+		"This guide lists the configuration for 'openapi' Terraform provider resources that can be managed using [Terraform v0.12](https://www.hashicorp.com/blog/announcing-terraform-0-12/).\n\n" +
+		"~> **Note:** some note\n\n" +
+		"## Provider Installation\n\n" +
+		"In order to provision 'openapi' Terraform resources, you need to first install the 'openapi' Terraform plugin by running the following command (you must be running Terraform >= 0.12):\n\n" +
+		"```shell\n" +
+		"$ export PROVIDER_NAME=openapi\n" +
+		"```\n\n" +
+		"You can then start running the Terraform provider:\n\n" +
+		"```shell\n" +
+		"$ export OTF_VAR_openapi_PLUGIN_CONFIGURATION_FILE=\"https://api.service.com/openapi.yaml\"\n" +
+		"$ terraform init && terraform plan\n" +
+		"```\n\n" +
+		"## Example Usage\n\n" +
+		"```terraform\n" +
+		"provider \"openapi\" {\n" +
+		"  token = \"...\"\n" +
+		"}\n" +
+		"```\n\n" +
+		"Using the default region (rst1):\n\n" +
+		"```terraform\n" +
+		"provider \"openapi\" {\n" +
+		"  # Resources using this default provider will be created in the 'rst1' region\n" +
+		"  ...\n" +
+		"}\n" +
+		"```\n\n" +
+		"## Argument Reference\n\n" +
+		"The following arguments are supported:\n\n" +
+		"- `token` (String, Required)\n" +
+		"- `region` (String, Optional) The region location to be used (rst1). If region isn't specified, the default is \"rst1\".\n"
+	assert.Equal(t, expectedOutput, buf.String())
+}
+
+func TestGetMarkdownPropertyType(t *testing.T) {
+	testCases := []struct {
+		name         string
+		property     Property
+		qualifier    string
+		expectedType string
+	}{
+		{name: "string property", property: Property{Type: "string"}, qualifier: "Required", expectedType: "String, Required"},
+		{name: "integer property", property: Property{Type: "integer"}, qualifier: "Optional", expectedType: "Number, Optional"},
+		{name: "float property", property: Property{Type: "number"}, expectedType: "Number"},
+		{name: "boolean property", property: Property{Type: "boolean"}, expectedType: "Boolean"},
+		{name: "sensitive property", property: Property{Type: "string", IsSensitive: true}, qualifier: "Optional", expectedType: "String, Optional, Sensitive"},
+		{name: "deprecated property", property: Property{Type: "string", Deprecated: "use other instead"}, qualifier: "Optional", expectedType: "String, Optional, Deprecated"},
+		{name: "list of strings property", property: Property{Type: "list", ArrayItemsType: "string"}, expectedType: "List of String"},
+		{name: "list of objects property", property: Property{Type: "list", ArrayItemsType: "object"}, qualifier: "Required", expectedType: "Block List, Required"},
+		{name: "object property", property: Property{Type: "object"}, qualifier: "Required", expectedType: "Block List, Max: 1, Required"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedType, getMarkdownPropertyType(tc.property, tc.qualifier), tc.name)
+	}
+}
+
+func TestAppendMarkdownDefault(t *testing.T) {
+	testCases := []struct {
+		name                string
+		description         string
+		property            Property
+		expectedDescription string
+	}{
+		{name: "property without default value", description: "The port.", property: Property{}, expectedDescription: "The port."},
+		{name: "property with default value", description: "The port.", property: Property{Default: "8080"}, expectedDescription: "The port. Defaults to `8080`."},
+		{name: "property with default value and description without trailing dot", description: "The port", property: Property{Default: "8080"}, expectedDescription: "The port. Defaults to `8080`."},
+		{name: "property with default value and no description", property: Property{Default: `"http"`}, expectedDescription: "Defaults to `\"http\"`."},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedDescription, appendMarkdownDefault(tc.description, tc.property), tc.name)
+	}
+}
+
+func TestAppendMarkdownDeprecation(t *testing.T) {
+	testCases := []struct {
+		name                string
+		description         string
+		property            Property
+		expectedDescription string
+	}{
+		{name: "property not deprecated", description: "The port.", property: Property{}, expectedDescription: "The port."},
+		{name: "deprecated property", description: "The port.", property: Property{Deprecated: "use ports instead"}, expectedDescription: "The port. **Deprecated:** use ports instead"},
+		{name: "deprecated property with no description", property: Property{Deprecated: "use ports instead"}, expectedDescription: "**Deprecated:** use ports instead"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedDescription, appendMarkdownDeprecation(tc.description, tc.property), tc.name)
+	}
+}
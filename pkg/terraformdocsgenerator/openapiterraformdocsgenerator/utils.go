@@ -2,11 +2,20 @@ package openapiterraformdocsgenerator
 
 import (
 	"io"
+	"regexp"
+	"strings"
 	"text/template"
 )
 
+// templateFuncs contains the functions available in the documentation templates
+var templateFuncs = template.FuncMap{
+	"escapeHTML": escapeHTML,
+}
+
+var htmlCharacterReferenceRegex = regexp.MustCompile(`^&(#[0-9]+|#[xX][0-9a-fA-F]+|[a-zA-Z][a-zA-Z0-9]*);`)
+
 func render(w io.Writer, templateName string, templateContent string, data interface{}) error {
-	tmpl, err := template.New(templateName).Parse(templateContent)
+	tmpl, err := template.New(templateName).Funcs(templateFuncs).Parse(templateContent)
 	if err != nil {
 		return err
 	}
@@ -16,3 +25,26 @@ func render(w io.Writer, templateName string, templateContent string, data inter
 	}
 	return nil
 }
+
+// escapeHTML escapes the characters that would otherwise be interpreted as HTML markup (&, < and >). Character references
+// (e,g: &amp;) are kept as they are so values that are already escaped do not get escaped twice
+func escapeHTML(value string) string {
+	var b strings.Builder
+	for idx, r := range value {
+		switch r {
+		case '&':
+			if htmlCharacterReferenceRegex.MatchString(value[idx:]) {
+				b.WriteRune(r)
+			} else {
+				b.WriteString("&amp;")
+			}
+		case '<':
+			b.WriteString("&lt;")
+		case '>':
+			b.WriteString("&gt;")
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
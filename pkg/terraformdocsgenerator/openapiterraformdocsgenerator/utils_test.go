@@ -42,3 +42,19 @@ func TestRender(t *testing.T) {
 	}
 
 }
+
+func TestEscapeHTML(t *testing.T) {
+	testCases := []struct {
+		name           string
+		value          string
+		expectedOutput string
+	}{
+		{name: "value without html characters", value: `label = "some label"`, expectedOutput: `label = "some label"`},
+		{name: "value with html markup", value: "<b>a & b</b>", expectedOutput: "&lt;b&gt;a &amp; b&lt;/b&gt;"},
+		{name: "value already html escaped", value: "&lt;b&gt;a &amp; b&#38;c&#x26;d&lt;/b&gt;", expectedOutput: "&lt;b&gt;a &amp; b&#38;c&#x26;d&lt;/b&gt;"},
+		{name: "value with ampersands that are not character references", value: "a &b && &#; &amp", expectedOutput: "a &amp;b &amp;&amp; &amp;#; &amp;amp"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, escapeHTML(tc.value), tc.name)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi"
+)
+
+const (
+	importFormatBlock   = "block"
+	importFormatCommand = "command"
+)
+
+// providerConfigFlag holds the provider configuration provided as multiple -config key=value flags
+type providerConfigFlag map[string]interface{}
+
+func (c providerConfigFlag) String() string {
+	var keys []string
+	for key := range c {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+func (c providerConfigFlag) Set(value string) error {
+	keyValue := strings.SplitN(value, "=", 2)
+	if len(keyValue) != 2 || keyValue[0] == "" {
+		return fmt.Errorf("provider configuration '%s' must follow the format key=value", value)
+	}
+	c[keyValue[0]] = keyValue[1]
+	return nil
+}
+
+// options contains the command line arguments
+type options struct {
+	providerName   string
+	spec           string
+	providerConfig providerConfigFlag
+	output         string
+	importFormat   string
+	importScript   string
+	verbose        bool
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parseOptions(args []string, output io.Writer) (options, error) {
+	opts := options{providerConfig: providerConfigFlag{}}
+	flags := flag.NewFlagSet("terraformexport", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.StringVar(&opts.providerName, "provider-name", "", "name of the Terraform provider (e,g: openapi) (required)")
+	flags.StringVar(&opts.spec, "spec", "", "URL or path to the file of the OpenAPI document (required)")
+	flags.Var(opts.providerConfig, "config", "provider configuration property in the format key=value (e,g: -config apikey_auth=secret). Can be provided multiple times")
+	flags.StringVar(&opts.output, "output", "", "file where the exported configuration will be written. If not provided, the configuration is written to the standard output")
+	flags.StringVar(&opts.importFormat, "import-format", importFormatBlock, "how the exported resources are imported: block (import blocks written along with the resources) or command (terraform import commands written to the -import-script file)")
+	flags.StringVar(&opts.importScript, "import-script", "", "file where the terraform import commands will be written (required if -import-format is command)")
+	flags.BoolVar(&opts.verbose, "verbose", false, "print the provider logs")
+	if err := flags.Parse(args); err != nil {
+		return opts, err
+	}
+	if opts.providerName == "" {
+		return opts, errors.New("missing required flag -provider-name")
+	}
+	if opts.spec == "" {
+		return opts, errors.New("missing required flag -spec")
+	}
+	if opts.importFormat != importFormatBlock && opts.importFormat != importFormatCommand {
+		return opts, fmt.Errorf("import format '%s' not supported, please choose one of the following formats: %s, %s", opts.importFormat, importFormatBlock, importFormatCommand)
+	}
+	if opts.importFormat == importFormatCommand && opts.importScript == "" {
+		return opts, errors.New("missing required flag -import-script when using the command import format")
+	}
+	return opts, nil
+}
+
+func run(args []string, output io.Writer) error {
+	opts, err := parseOptions(args, output)
+	if err != nil {
+		return err
+	}
+	if !opts.verbose {
+		log.SetOutput(ioutil.Discard)
+		defer log.SetOutput(os.Stderr)
+	}
+	exportedResources, err := openapi.ExportRemoteResources(opts.providerName, openapi.NewServiceConfigV1(opts.spec, false, nil), opts.providerConfig)
+	if err != nil {
+		return err
+	}
+	var config, importCommands strings.Builder
+	for idx, exportedResource := range exportedResources {
+		if idx > 0 {
+			config.WriteString("\n")
+		}
+		if variableBlocks := exportedResource.VariableBlocks(); variableBlocks != "" {
+			config.WriteString(variableBlocks)
+			config.WriteString("\n")
+		}
+		config.WriteString(exportedResource.Config)
+		if opts.importFormat == importFormatBlock {
+			config.WriteString("\n")
+			config.WriteString(exportedResource.ImportBlock())
+		} else {
+			fmt.Fprintln(&importCommands, exportedResource.ImportCommand())
+		}
+	}
+	if opts.importFormat == importFormatCommand {
+		if err := writeFile(opts.importScript, "#!/bin/sh\nset -e\n"+importCommands.String(), 0755); err != nil {
+			return err
+		}
+	}
+	if opts.output == "" {
+		_, err := io.WriteString(output, config.String())
+		return err
+	}
+	return writeFile(opts.output, config.String(), 0644)
+}
+
+func writeFile(fileName, content string, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, []byte(content), perm)
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSwaggerTemplate = `swagger: "2.0"
+host: "%s"
+schemes:
+  - "http"
+securityDefinitions:
+  apikey_auth:
+    type: "apiKey"
+    name: "Authorization"
+    in: "header"
+security:
+  - apikey_auth: []
+paths:
+  /v1/cdns:
+    get:
+      responses:
+        200:
+          schema:
+            type: "array"
+            items:
+              $ref: "#/definitions/ContentDeliveryNetworkV1"
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+    delete:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        204:
+          description: "successful operation, no content is returned"
+definitions:
+  ContentDeliveryNetworkV1:
+    type: "object"
+    required:
+      - label
+      - api_key
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"
+      api_key:
+        type: "string"
+        x-terraform-sensitive: true`
+
+func newTestServer(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cdn := map[string]interface{}{"id": "cdn-1", "label": "my-cdn"}
+		switch r.URL.Path {
+		case "/swagger.yaml":
+			fmt.Fprintf(w, testSwaggerTemplate, strings.TrimPrefix(server.URL, "http://"))
+		case "/v1/cdns":
+			require.NoError(t, json.NewEncoder(w).Encode([]interface{}{cdn}))
+		case "/v1/cdns/cdn-1":
+			require.NoError(t, json.NewEncoder(w).Encode(cdn))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func TestParseOptions(t *testing.T) {
+	testCases := []struct {
+		name            string
+		args            []string
+		expectedOptions options
+		expectedErr     string
+	}{
+		{
+			name:            "default values",
+			args:            []string{"-provider-name", "openapi", "-spec", "swagger.yaml"},
+			expectedOptions: options{providerName: "openapi", spec: "swagger.yaml", providerConfig: providerConfigFlag{}, importFormat: importFormatBlock},
+		},
+		{
+			name:            "all values populated",
+			args:            []string{"-provider-name", "openapi", "-spec", "swagger.yaml", "-config", "apikey_auth=secret", "-config", "region=rst1", "-output", "main.tf", "-import-format", "command", "-import-script", "import.sh", "-verbose"},
+			expectedOptions: options{providerName: "openapi", spec: "swagger.yaml", providerConfig: providerConfigFlag{"apikey_auth": "secret", "region": "rst1"}, output: "main.tf", importFormat: importFormatCommand, importScript: "import.sh", verbose: true},
+		},
+		{
+			name:        "missing provider name",
+			args:        []string{"-spec", "swagger.yaml"},
+			expectedErr: "missing required flag -provider-name",
+		},
+		{
+			name:        "missing spec",
+			args:        []string{"-provider-name", "openapi"},
+			expectedErr: "missing required flag -spec",
+		},
+		{
+			name:        "wrong provider configuration format",
+			args:        []string{"-provider-name", "openapi", "-spec", "swagger.yaml", "-config", "apikey_auth"},
+			expectedErr: "invalid value \"apikey_auth\" for flag -config: provider configuration 'apikey_auth' must follow the format key=value",
+		},
+		{
+			name:        "import format not supported",
+			args:        []string{"-provider-name", "openapi", "-spec", "swagger.yaml", "-import-format", "other"},
+			expectedErr: "import format 'other' not supported, please choose one of the following formats: block, command",
+		},
+		{
+			name:        "missing import script",
+			args:        []string{"-provider-name", "openapi", "-spec", "swagger.yaml", "-import-format", "command"},
+			expectedErr: "missing required flag -import-script when using the command import format",
+		},
+	}
+	for _, tc := range testCases {
+		opts, err := parseOptions(tc.args, ioutil.Discard)
+		if tc.expectedErr != "" {
+			assert.EqualError(t, err, tc.expectedErr, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedOptions, opts, tc.name)
+	}
+}
+
+func TestRun(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+	swaggerURL := server.URL + "/swagger.yaml"
+
+	var output bytes.Buffer
+	require.NoError(t, run([]string{"-provider-name", "openapi", "-spec", swaggerURL, "-config", "apikey_auth=secret"}, &output))
+	assert.Equal(t, `variable "openapi_cdns_v1_my_cdn_api_key" {
+  description = "Value of the sensitive property 'api_key' of openapi_cdns_v1.my_cdn"
+  sensitive   = true
+}
+
+resource "openapi_cdns_v1" "my_cdn" {
+  # api_key is sensitive and is not exported, set its value in the variable openapi_cdns_v1_my_cdn_api_key
+  api_key = var.openapi_cdns_v1_my_cdn_api_key
+  label   = "my-cdn"
+}
+
+import {
+  to = openapi_cdns_v1.my_cdn
+  id = "cdn-1"
+}
+`, output.String())
+
+	dir, err := ioutil.TempDir("", "export")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	outputFile := filepath.Join(dir, "main.tf")
+	importScript := filepath.Join(dir, "import.sh")
+	require.NoError(t, run([]string{"-provider-name", "openapi", "-spec", swaggerURL, "-config", "apikey_auth=secret", "-output", outputFile, "-import-format", "command", "-import-script", importScript}, ioutil.Discard))
+	content, err := ioutil.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "variable \"openapi_cdns_v1_my_cdn_api_key\" {\n")
+	assert.Contains(t, string(content), "resource \"openapi_cdns_v1\" \"my_cdn\" {\n")
+	assert.NotContains(t, string(content), "import {")
+	content, err = ioutil.ReadFile(importScript)
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\nset -e\nterraform import openapi_cdns_v1.my_cdn 'cdn-1'\n", string(content))
+
+	assert.EqualError(t, run([]string{"-provider-name", "openapi", "-spec", swaggerURL}, ioutil.Discard), `invalid provider configuration: "apikey_auth": required field is not set`)
+}
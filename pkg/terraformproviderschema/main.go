@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi"
+)
+
+// options contains the command line arguments
+type options struct {
+	providerName string
+	spec         string
+	output       string
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parseOptions(args []string, output io.Writer) (options, error) {
+	opts := options{}
+	flags := flag.NewFlagSet("terraformproviderschema", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.StringVar(&opts.providerName, "provider-name", "", "name of the Terraform provider (e,g: openapi) (required)")
+	flags.StringVar(&opts.spec, "spec", "", "URL or path to the file of the OpenAPI document (required)")
+	flags.StringVar(&opts.output, "output", "", "file where the provider schema will be written. If not provided, the schema is written to the standard output")
+	if err := flags.Parse(args); err != nil {
+		return opts, err
+	}
+	if opts.providerName == "" {
+		return opts, errors.New("missing required flag -provider-name")
+	}
+	if opts.spec == "" {
+		return opts, errors.New("missing required flag -spec")
+	}
+	return opts, nil
+}
+
+func run(args []string, output io.Writer) error {
+	opts, err := parseOptions(args, output)
+	if err != nil {
+		return err
+	}
+	providerSchemas, err := openapi.ExportProviderSchemas(opts.providerName, openapi.NewServiceConfigV1(opts.spec, false, nil))
+	if err != nil {
+		return err
+	}
+	if opts.output == "" {
+		return writeProviderSchemas(output, providerSchemas)
+	}
+	if err := os.MkdirAll(filepath.Dir(opts.output), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(opts.output)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return writeProviderSchemas(f, providerSchemas)
+}
+
+func writeProviderSchemas(w io.Writer, providerSchemas *openapi.ProviderSchemasJSON) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(providerSchemas)
+}
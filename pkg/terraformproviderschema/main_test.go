@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSwagger = `swagger: "2.0"
+host: "localhost:8443"
+schemes:
+  - "https"
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+    delete:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        204:
+          description: "successful operation, no content is returned"
+definitions:
+  ContentDeliveryNetworkV1:
+    type: "object"
+    required:
+      - label
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"`
+
+func writeTestSwagger(t *testing.T, dir string) string {
+	swaggerFile := filepath.Join(dir, "swagger.yaml")
+	require.NoError(t, ioutil.WriteFile(swaggerFile, []byte(testSwagger), 0644))
+	return swaggerFile
+}
+
+func TestParseOptions(t *testing.T) {
+	testCases := []struct {
+		name            string
+		args            []string
+		expectedOptions options
+		expectedErr     string
+	}{
+		{
+			name:            "default values",
+			args:            []string{"-provider-name", "openapi", "-spec", "swagger.yaml"},
+			expectedOptions: options{providerName: "openapi", spec: "swagger.yaml"},
+		},
+		{
+			name:            "all values populated",
+			args:            []string{"-provider-name", "openapi", "-spec", "swagger.yaml", "-output", "schema.json"},
+			expectedOptions: options{providerName: "openapi", spec: "swagger.yaml", output: "schema.json"},
+		},
+		{
+			name:        "missing provider name",
+			args:        []string{"-spec", "swagger.yaml"},
+			expectedErr: "missing required flag -provider-name",
+		},
+		{
+			name:        "missing spec",
+			args:        []string{"-provider-name", "openapi"},
+			expectedErr: "missing required flag -spec",
+		},
+	}
+	for _, tc := range testCases {
+		opts, err := parseOptions(tc.args, ioutil.Discard)
+		if tc.expectedErr != "" {
+			assert.EqualError(t, err, tc.expectedErr, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedOptions, opts, tc.name)
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "schema")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	swaggerFile := writeTestSwagger(t, dir)
+
+	var output bytes.Buffer
+	require.NoError(t, run([]string{"-provider-name", "openapi", "-spec", swaggerFile}, &output))
+	providerSchemas := openapi.ProviderSchemasJSON{}
+	require.NoError(t, json.Unmarshal(output.Bytes(), &providerSchemas))
+	assert.Equal(t, "0.1", providerSchemas.FormatVersion)
+	assert.Contains(t, providerSchemas.Schemas["openapi"].ResourceSchemas, "openapi_cdns_v1")
+	assert.True(t, providerSchemas.Schemas["openapi"].ResourceSchemas["openapi_cdns_v1"].Block.Attributes["label"].Required)
+
+	outputFile := filepath.Join(dir, "out", "schema.json")
+	require.NoError(t, run([]string{"-provider-name", "openapi", "-spec", swaggerFile, "-output", outputFile}, ioutil.Discard))
+	content, err := ioutil.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Equal(t, output.String(), string(content))
+}
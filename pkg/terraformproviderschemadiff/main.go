@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// options contains the command line arguments
+type options struct {
+	providerName string
+	oldSpec      string
+	newSpec      string
+	format       string
+}
+
+// main exits with a non-zero exit code if breaking changes are found so the command can be used in CI
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parseOptions(args []string, output io.Writer) (options, error) {
+	opts := options{}
+	flags := flag.NewFlagSet("terraformproviderschemadiff", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.StringVar(&opts.providerName, "provider-name", "", "name of the Terraform provider (e,g: openapi) (required)")
+	flags.StringVar(&opts.oldSpec, "old-spec", "", "URL or path to the file of the old version of the OpenAPI document (required)")
+	flags.StringVar(&opts.newSpec, "new-spec", "", "URL or path to the file of the new version of the OpenAPI document (required)")
+	flags.StringVar(&opts.format, "format", formatText, "output format of the report: text or json")
+	if err := flags.Parse(args); err != nil {
+		return opts, err
+	}
+	if opts.providerName == "" {
+		return opts, errors.New("missing required flag -provider-name")
+	}
+	if opts.oldSpec == "" {
+		return opts, errors.New("missing required flag -old-spec")
+	}
+	if opts.newSpec == "" {
+		return opts, errors.New("missing required flag -new-spec")
+	}
+	if opts.format != formatText && opts.format != formatJSON {
+		return opts, fmt.Errorf("format '%s' not supported, please choose one of the following formats: %s, %s", opts.format, formatText, formatJSON)
+	}
+	return opts, nil
+}
+
+func run(args []string, output io.Writer) error {
+	opts, err := parseOptions(args, output)
+	if err != nil {
+		return err
+	}
+	diff, err := openapi.DiffProviderSchemas(opts.providerName, openapi.NewServiceConfigV1(opts.oldSpec, false, nil), openapi.NewServiceConfigV1(opts.newSpec, false, nil))
+	if err != nil {
+		return err
+	}
+	if opts.format == formatJSON {
+		encoder := json.NewEncoder(output)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(diff); err != nil {
+			return err
+		}
+	} else {
+		writeTextReport(output, diff)
+	}
+	if diff.HasBreakingChanges() {
+		return fmt.Errorf("%d breaking change(s) found between '%s' and '%s'", len(diff.Breaking), opts.oldSpec, opts.newSpec)
+	}
+	return nil
+}
+
+func writeTextReport(w io.Writer, diff *openapi.ProviderSchemaDiff) {
+	writeTextChanges(w, "Breaking changes", diff.Breaking)
+	fmt.Fprintln(w)
+	writeTextChanges(w, "Non-breaking changes", diff.NonBreaking)
+}
+
+func writeTextChanges(w io.Writer, title string, changes []openapi.SchemaChange) {
+	fmt.Fprintf(w, "%s (%d):\n", title, len(changes))
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "  none")
+	}
+	for _, change := range changes {
+		fmt.Fprintf(w, "  - %s: %s [%s]\n", change.Address, change.Description, change.Kind)
+	}
+}
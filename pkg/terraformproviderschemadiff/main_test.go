@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSwagger = `swagger: "2.0"
+host: "localhost:8443"
+schemes:
+  - "https"
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+    delete:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        204:
+          description: "successful operation, no content is returned"
+definitions:
+  ContentDeliveryNetworkV1:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"`
+
+func writeTestSwagger(t *testing.T, dir, name, content string) string {
+	swaggerFile := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(swaggerFile, []byte(content), 0644))
+	return swaggerFile
+}
+
+func TestParseOptions(t *testing.T) {
+	testCases := []struct {
+		name            string
+		args            []string
+		expectedOptions options
+		expectedErr     string
+	}{
+		{
+			name:            "default values",
+			args:            []string{"-provider-name", "openapi", "-old-spec", "old.yaml", "-new-spec", "new.yaml"},
+			expectedOptions: options{providerName: "openapi", oldSpec: "old.yaml", newSpec: "new.yaml", format: "text"},
+		},
+		{
+			name:            "json format",
+			args:            []string{"-provider-name", "openapi", "-old-spec", "old.yaml", "-new-spec", "new.yaml", "-format", "json"},
+			expectedOptions: options{providerName: "openapi", oldSpec: "old.yaml", newSpec: "new.yaml", format: "json"},
+		},
+		{
+			name:        "missing provider name",
+			args:        []string{"-old-spec", "old.yaml", "-new-spec", "new.yaml"},
+			expectedErr: "missing required flag -provider-name",
+		},
+		{
+			name:        "missing old spec",
+			args:        []string{"-provider-name", "openapi", "-new-spec", "new.yaml"},
+			expectedErr: "missing required flag -old-spec",
+		},
+		{
+			name:        "missing new spec",
+			args:        []string{"-provider-name", "openapi", "-old-spec", "old.yaml"},
+			expectedErr: "missing required flag -new-spec",
+		},
+		{
+			name:        "format not supported",
+			args:        []string{"-provider-name", "openapi", "-old-spec", "old.yaml", "-new-spec", "new.yaml", "-format", "xml"},
+			expectedErr: "format 'xml' not supported, please choose one of the following formats: text, json",
+		},
+	}
+	for _, tc := range testCases {
+		opts, err := parseOptions(tc.args, ioutil.Discard)
+		if tc.expectedErr != "" {
+			assert.EqualError(t, err, tc.expectedErr, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedOptions, opts, tc.name)
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "schemadiff")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	oldSpec := writeTestSwagger(t, dir, "old.yaml", testSwagger)
+	newSpec := writeTestSwagger(t, dir, "new.yaml", testSwagger+`
+      port:
+        type: "integer"`)
+
+	// new optional attribute is not a breaking change
+	var output bytes.Buffer
+	err = run([]string{"-provider-name", "openapi", "-old-spec", oldSpec, "-new-spec", newSpec}, &output)
+	assert.NoError(t, err)
+	assert.Equal(t, "Breaking changes (0):\n  none\n\n"+
+		"Non-breaking changes (2):\n"+
+		"  - openapi_cdns_v1.port: attribute added [attribute_added]\n"+
+		"  - data.openapi_cdns_v1_instance.port: attribute added [attribute_added]\n", output.String())
+
+	// removed attribute is a breaking change
+	output.Reset()
+	err = run([]string{"-provider-name", "openapi", "-old-spec", newSpec, "-new-spec", oldSpec, "-format", "json"}, &output)
+	assert.EqualError(t, err, "2 breaking change(s) found between '"+newSpec+"' and '"+oldSpec+"'")
+	diff := openapi.ProviderSchemaDiff{}
+	require.NoError(t, json.Unmarshal(output.Bytes(), &diff))
+	assert.Equal(t, []openapi.SchemaChange{
+		{Kind: "attribute_removed", Address: "openapi_cdns_v1.port", Description: "attribute removed"},
+		{Kind: "attribute_removed", Address: "data.openapi_cdns_v1_instance.port", Description: "attribute removed"},
+	}, diff.Breaking)
+	assert.Empty(t, diff.NonBreaking)
+}
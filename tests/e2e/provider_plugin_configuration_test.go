@@ -191,19 +191,18 @@ data "openapi_cdns_v1_instance" "my_cdn" {
 						if metricsReceived[0] != expectedPluginVersionMetric {
 							return fmt.Errorf("metrics received [%s] don't match the expected ones [%s]", metricsReceived[0], expectedPluginVersionMetric)
 						}
-						expectedDataSourceInstanceMetric := `{"metric_type":"IncCounter","metric_name":"terraform.provider","tags":["provider_name:openapi","resource_name:data_cdns_v1_instance","terraform_operation:read"]}`
-						err := assertMetricExists(expectedDataSourceInstanceMetric, metricsReceived, []int{1, 2})
-						if err != nil {
-							return err
+						// besides the total runs counters, the provider also submits the operation duration, HTTP status class
+						// and polling metrics so the expected counters are looked up by name rather than by position
+						expectedMetrics := []string{
+							`{"metric_type":"IncCounter","metric_name":"terraform.provider","tags":["provider_name:openapi","resource_name:data_cdns_v1_instance","terraform_operation:read"]}`,
+							`{"metric_type":"IncCounter","metric_name":"terraform.provider","tags":["provider_name:openapi","resource_name:data_cdns_v1","terraform_operation:read"]}`,
+							`{"metric_type":"IncCounter","metric_name":"terraform.provider","tags":["provider_name:openapi","resource_name:cdns_v1","terraform_operation:create"]}`,
+							`{"metric_type":"IncCounter","metric_name":"terraform.provider.http_status","tags":["provider_name:openapi","resource_name:cdns_v1","terraform_operation:create","status_class:2xx"]}`,
 						}
-						expectedDataSourceWithFiltersMetric := `{"metric_type":"IncCounter","metric_name":"terraform.provider","tags":["provider_name:openapi","resource_name:data_cdns_v1","terraform_operation:read"]}`
-						err = assertMetricExists(expectedDataSourceWithFiltersMetric, metricsReceived, []int{1, 2})
-						if err != nil {
-							return err
-						}
-						expectedResourceMetrics := `{"metric_type":"IncCounter","metric_name":"terraform.provider","tags":["provider_name:openapi","resource_name:cdns_v1","terraform_operation:create"]}`
-						if metricsReceived[5] != expectedResourceMetrics {
-							return fmt.Errorf("metrics received [%s] don't match the expected ones [%s]", metricsReceived[4], expectedResourceMetrics)
+						for _, expectedMetric := range expectedMetrics {
+							if err := assertMetricReceived(expectedMetric, metricsReceived); err != nil {
+								return err
+							}
 						}
 						return nil
 					},
@@ -214,13 +213,13 @@ data "openapi_cdns_v1_instance" "my_cdn" {
 	os.Unsetenv(otfVarPluginConfigEnvVariableName)
 }
 
-func assertMetricExists(expectedMetric string, metrics []string, expectedIdx []int) error {
-	for _, idx := range expectedIdx {
-		if metrics[idx] == expectedMetric {
+func assertMetricReceived(expectedMetric string, metrics []string) error {
+	for _, metric := range metrics {
+		if metric == expectedMetric {
 			return nil
 		}
 	}
-	return fmt.Errorf("metrics received [%s] don't match the expected ones [%s]", metrics, expectedMetric)
+	return fmt.Errorf("metrics received [%s] don't contain the expected one [%s]", metrics, expectedMetric)
 }
 
 // TestAcc_ProviderConfiguration_PluginExternalFile_GraphiteTelemetry confirms regressions introduced in the logic related to the plugin